@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpAuditCmd     = "Audit log of requests which modified the cluster"
+	helpAuditListCmd = "List audit log entries"
+)
+
+var (
+	flagAuditUser   string
+	flagAuditSince  time.Duration
+	flagAuditOffset int
+	flagAuditLimit  int
+
+	auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: helpAuditCmd,
+	}
+
+	auditListCmd = &cobra.Command{
+		Use:   "list",
+		Short: helpAuditListCmd,
+		Args:  cobra.NoArgs,
+		Run:   auditListCmdRun,
+	}
+)
+
+func init() {
+	auditListCmd.Flags().StringVar(&flagAuditUser, "user", "", "Only list requests made by this user")
+	auditListCmd.Flags().DurationVar(&flagAuditSince, "since", 0, "Only list requests made within this duration, e.g. 24h")
+	auditListCmd.Flags().IntVar(&flagAuditOffset, "offset", 0, "Number of matching entries to skip")
+	auditListCmd.Flags().IntVar(&flagAuditLimit, "limit", 0, "Maximum number of entries to list, at most 1000 (default 100)")
+	auditCmd.AddCommand(auditListCmd)
+}
+
+func auditListCmdRun(cmd *cobra.Command, args []string) {
+	var since time.Time
+	if flagAuditSince > 0 {
+		since = time.Now().Add(-flagAuditSince)
+	}
+
+	entries, err := client.AuditList(flagAuditUser, since, time.Time{}, flagAuditOffset, flagAuditLimit)
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).Error("error getting audit log")
+		}
+		failure("Error getting audit log", err, 1)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Time", "User", "Request", "Volume", "Peer", "Snapshot", "Status", "Duration (ms)"})
+	for _, e := range entries {
+		table.Append([]string{
+			e.Time.Local().Format(time.RFC3339),
+			e.User,
+			e.Route,
+			e.Volume,
+			e.Peer,
+			e.Snapshot,
+			strconv.Itoa(e.Status),
+			strconv.FormatInt(e.DurationMs, 10),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpBackupCmd     = "Back up the cluster store and volfiles"
+	backupCmdHelpLong = "Save a snapshot of the cluster store along with the volfiles to a gzipped tar archive. Start glusterd2 with --restore <archive> to restore a node from the archive."
+)
+
+var (
+	backupCmd = &cobra.Command{
+		Use:   "backup <archive>",
+		Short: helpBackupCmd,
+		Long:  backupCmdHelpLong,
+		Args:  cobra.ExactArgs(1),
+		Run:   backupCmdRun,
+	}
+)
+
+func backupCmdRun(cmd *cobra.Command, args []string) {
+	archive := args[0]
+
+	f, err := os.OpenFile(archive, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		failure("Failed to create backup archive", err, 1)
+	}
+
+	err = client.ClusterBackup(f)
+	if err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+	if err != nil {
+		os.Remove(archive)
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("archive", archive).Error("cluster backup failed")
+		}
+		failure("Cluster backup failed", err, 1)
+	}
+	fmt.Printf("Cluster backup saved to %s\n", archive)
+}
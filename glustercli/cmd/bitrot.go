@@ -170,6 +170,10 @@ var bitrotScrubCmd = &cobra.Command{
 				}
 				failure(fmt.Sprintf("Failed to get bitrot scrub status for volume %s\n", volname), err, 1)
 			}
+			if GlobalFlag.XMLOutput {
+				printXML(newXMLVolScrub(scrubStatus))
+				return
+			}
 			fmt.Println()
 			fmt.Printf("Volume: %s\n", scrubStatus.Volume)
 			fmt.Printf("Scrub state: %s\n", scrubStatus.State)
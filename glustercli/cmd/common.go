@@ -21,7 +21,18 @@ var (
 
 func initRESTClient(hostname, user, secret, cacert string, insecure bool) {
 	var err error
-	client, err = restclient.New(hostname, user, secret, cacert, insecure)
+	client, err = restclient.NewClientWithOpts(
+		restclient.WithBaseURL(hostname),
+		restclient.WithTLSConfig(&restclient.TLSOptions{
+			CaCertFile:         cacert,
+			InsecureSkipVerify: insecure,
+			CertFile:           GlobalFlag.CertFile,
+			KeyFile:            GlobalFlag.KeyFile,
+		}),
+		restclient.WithUsername(user),
+		restclient.WithPassword(secret),
+		restclient.WithDebugRoundTripper(),
+	)
 	if err != nil {
 		failure("failed to setup client", err, 1)
 	}
@@ -54,6 +65,11 @@ func handleGlusterdConnectFailure(msg, endpoints string, err error, errcode int)
 
 func failure(msg string, err error, errcode int) {
 
+	if GlobalFlag.XMLOutput {
+		printXMLFailure(msg, err, errcode)
+		os.Exit(errcode)
+	}
+
 	handleGlusterdConnectFailure(msg, GlobalFlag.Endpoints[0], err, errcode)
 
 	w := os.Stderr
@@ -12,17 +12,25 @@ import (
 )
 
 const (
-	helpDeviceCmd     = "Gluster Devices Management"
-	helpDeviceAddCmd  = "Add device"
-	helpDeviceInfoCmd = "Get device info"
+	helpDeviceCmd       = "Gluster Devices Management"
+	helpDeviceAddCmd    = "Add device"
+	helpDeviceInfoCmd   = "Get device info"
+	helpDeviceDeleteCmd = "Delete device"
+	helpDeviceResizeCmd = "Update device size after the disk is grown"
 )
 
-var flagDeviceAddProvisioner string
+var (
+	flagDeviceAddProvisioner string
+	flagDeviceDeleteEvacuate bool
+)
 
 func init() {
 	deviceAddCmd.Flags().StringVar(&flagDeviceAddProvisioner, "provisioner", "lvm", "Provisioner Type(lvm, loop)")
 	deviceCmd.AddCommand(deviceAddCmd)
 	deviceCmd.AddCommand(deviceInfoCmd)
+	deviceDeleteCmd.Flags().BoolVar(&flagDeviceDeleteEvacuate, "evacuate", false, "Replace the bricks on the device with new bricks on other devices, and wait for them to be healed, before deleting it. The evacuation runs as a job. Only bricks of replicate and disperse subvolumes can be evacuated")
+	deviceCmd.AddCommand(deviceDeleteCmd)
+	deviceCmd.AddCommand(deviceResizeCmd)
 }
 
 var deviceCmd = &cobra.Command{
@@ -128,3 +136,68 @@ var deviceAddCmd = &cobra.Command{
 		fmt.Println("Device add successful")
 	},
 }
+
+var deviceDeleteCmd = &cobra.Command{
+	Use:   "delete <PeerID> <DEVICE>",
+	Short: helpDeviceDeleteCmd,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		peerid := args[0]
+		devname := args[1]
+
+		if flagDeviceDeleteEvacuate {
+			job, err := client.DeviceEvacuate(peerid, devname)
+			if err != nil {
+				if GlobalFlag.Verbose {
+					log.WithError(err).WithFields(log.Fields{
+						"device": devname,
+						"peerid": peerid,
+					}).Error("device evacuate failed")
+				}
+				failure("Device evacuate failed", err, 1)
+			}
+			if GlobalFlag.XMLOutput {
+				printXML(newXMLJob(job.ID.String()))
+			}
+			fmt.Printf("Device evacuation started. Check its progress with \"glustercli job status %s\"\n", job.ID)
+			return
+		}
+
+		err := client.DeviceDelete(peerid, devname)
+
+		if err != nil {
+			if GlobalFlag.Verbose {
+				log.WithError(err).WithFields(log.Fields{
+					"device": devname,
+					"peerid": peerid,
+				}).Error("device delete failed")
+			}
+			failure("Device delete failed", err, 1)
+		}
+		fmt.Println("Device delete successful")
+	},
+}
+
+var deviceResizeCmd = &cobra.Command{
+	Use:   "resize <PeerID> <DEVICE>",
+	Short: helpDeviceResizeCmd,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		peerid := args[0]
+		devname := args[1]
+
+		deviceinfo, err := client.DeviceResize(peerid, devname)
+
+		if err != nil {
+			if GlobalFlag.Verbose {
+				log.WithError(err).WithFields(log.Fields{
+					"device": devname,
+					"peerid": peerid,
+				}).Error("device resize failed")
+			}
+			failure("Device resize failed", err, 1)
+		}
+		fmt.Printf("Device resize successful. Total Size: %s, Free Size: %s\n",
+			humanReadable(deviceinfo.TotalSize), humanReadable(deviceinfo.AvailableSize))
+	},
+}
@@ -487,6 +487,11 @@ var georepStatusCmd = &cobra.Command{
 			}
 		}
 
+		if GlobalFlag.XMLOutput {
+			printXML(newXMLGeoRep(sessions))
+			return
+		}
+
 		for _, session := range sessions {
 			fmt.Println()
 			fmt.Printf("SESSION: %s ==> %s@%s::%s  STATUS: %s\n",
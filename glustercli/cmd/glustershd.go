@@ -67,6 +67,10 @@ var selfHealInfoCmd = &cobra.Command{
 			}
 			failure(fmt.Sprintf("Failed to get heal info for volume %s\n", volname), err, 1)
 		}
+		if GlobalFlag.XMLOutput {
+			printXML(newXMLHealInfo(selfHealInfo))
+			return
+		}
 		for index := range selfHealInfo {
 			fmt.Printf("Brick: %s\n", selfHealInfo[index].Name)
 			fmt.Printf("Status: %s\n", selfHealInfo[index].Status)
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpJobCmd       = "Jobs of requests processed asynchronously"
+	helpJobListCmd   = "List jobs"
+	helpJobStatusCmd = "Show the status of a job"
+	helpJobCancelCmd = "Cancel a running job"
+)
+
+var (
+	jobCmd = &cobra.Command{
+		Use:   "job",
+		Short: helpJobCmd,
+	}
+
+	jobListCmd = &cobra.Command{
+		Use:   "list",
+		Short: helpJobListCmd,
+		Args:  cobra.NoArgs,
+		Run:   jobListCmdRun,
+	}
+
+	jobStatusCmd = &cobra.Command{
+		Use:   "status <jobid>",
+		Short: helpJobStatusCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   jobStatusCmdRun,
+	}
+
+	jobCancelCmd = &cobra.Command{
+		Use:   "cancel <jobid>",
+		Short: helpJobCancelCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   jobCancelCmdRun,
+	}
+)
+
+func init() {
+	jobCmd.AddCommand(jobListCmd)
+	jobCmd.AddCommand(jobStatusCmd)
+	jobCmd.AddCommand(jobCancelCmd)
+}
+
+func jobListCmdRun(cmd *cobra.Command, args []string) {
+	jobs, err := client.JobList()
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).Error("error getting jobs")
+		}
+		failure("Error getting jobs", err, 1)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Request", "User", "State", "Status", "Created"})
+	for _, j := range jobs {
+		table.Append([]string{
+			j.ID.String(),
+			j.Route,
+			j.User,
+			string(j.State),
+			strconv.Itoa(j.Status),
+			j.CreatedAt.Local().Format(time.RFC3339),
+		})
+	}
+	table.Render()
+}
+
+func printJob(job api.JobGetResp) {
+	fmt.Println("ID:", job.ID)
+	fmt.Println("Request:", job.Route, job.Method, job.Path)
+	fmt.Println("Peer ID:", job.PeerID)
+	fmt.Println("State:", job.State)
+	if job.State != api.JobRunning {
+		fmt.Println("Status:", job.Status)
+	}
+	for _, s := range job.Steps {
+		if s.Error != "" {
+			fmt.Printf("  %s: %s (%s)\n", s.Name, s.State, s.Error)
+		} else {
+			fmt.Printf("  %s: %s\n", s.Name, s.State)
+		}
+	}
+	if len(job.Response) > 0 {
+		fmt.Println("Response:", string(job.Response))
+	}
+}
+
+func jobStatusCmdRun(cmd *cobra.Command, args []string) {
+	job, err := client.JobGet(args[0])
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("job", args[0]).Error("error getting job")
+		}
+		failure("Error getting job", err, 1)
+	}
+	printJob(job)
+}
+
+func jobCancelCmdRun(cmd *cobra.Command, args []string) {
+	job, err := client.JobCancel(args[0])
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("job", args[0]).Error("error cancelling job")
+		}
+		failure("Error cancelling job", err, 1)
+	}
+	fmt.Println("Job cancelled")
+	printJob(job)
+}
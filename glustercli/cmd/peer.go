@@ -15,11 +15,12 @@ import (
 )
 
 const (
-	helpPeerCmd       = "Gluster Peer Management"
-	helpPeerAddCmd    = "add peer specified by <HOSTNAME>"
-	helpPeerRemoveCmd = "remove peer specified by <PeerID>"
-	helpPeerStatusCmd = "list status of peers"
-	helpPeerListCmd   = "list all the nodes in the pool (including localhost)"
+	helpPeerCmd          = "Gluster Peer Management"
+	helpPeerAddCmd       = "add peer specified by <HOSTNAME>"
+	helpPeerRemoveCmd    = "remove peer specified by <PeerID>"
+	helpPeerStatusCmd    = "list status of peers"
+	helpPeerListCmd      = "list all the nodes in the pool (including localhost)"
+	helpPeerTLSReloadCmd = "reload the peer TLS certificates on all the peers"
 )
 
 var (
@@ -39,6 +40,8 @@ func init() {
 	peerListCmd.Flags().StringVar(&flagCmdFilterKey, "key", "", "Filter by metadata key")
 	peerListCmd.Flags().StringVar(&flagCmdFilterValue, "value", "", "Filter by metadata value")
 	peerCmd.AddCommand(peerListCmd)
+
+	peerCmd.AddCommand(peerTLSReloadCmd)
 }
 
 var peerCmd = &cobra.Command{
@@ -113,6 +116,10 @@ func peerStatusHandler(cmd *cobra.Command) {
 		}
 		failure("Failed to get Peers list", err, 1)
 	}
+	if GlobalFlag.XMLOutput {
+		printXML(newXMLPeerStatus(peers))
+		return
+	}
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader([]string{"ID", "Name", "Client Addresses", "Peer Addresses", "Online", "PID"})
 
@@ -139,3 +146,29 @@ var peerListCmd = &cobra.Command{
 		peerStatusHandler(cmd)
 	},
 }
+
+var peerTLSReloadCmd = &cobra.Command{
+	Use:   "tls-reload",
+	Short: helpPeerTLSReloadCmd,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		resp, err := client.PeerTLSReload()
+		if err != nil {
+			if GlobalFlag.Verbose {
+				log.WithError(err).Error("peer TLS reload failed")
+			}
+			failure("Failed to reload peer TLS certificates", err, 1)
+		}
+		if GlobalFlag.XMLOutput {
+			printXML(newXMLPeerTLSReload(resp))
+			return
+		}
+		fmt.Println("Peer TLS certificates reloaded")
+		if len(resp.RestartRequired) > 0 {
+			fmt.Println("The CA certificate has changed, restart glusterd2 on the following peers for the embedded store to use it:")
+			for _, id := range resp.RestartRequired {
+				fmt.Println(id)
+			}
+		}
+	},
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpQuotaCmd            = "Gluster Directory Quota"
+	helpQuotaEnableCmd      = "Enable Quota"
+	helpQuotaDisableCmd     = "Disable Quota"
+	helpQuotaLimitUsageCmd  = "Set usage limit on a directory"
+	helpQuotaRemoveCmd      = "Remove usage limit set on a directory"
+	helpQuotaListCmd        = "List usage limits and usage of directories"
+	quotaEnableOptionKey    = "quota.enable"
+	quotaEnableOptionValue  = "on"
+	quotaDisableOptionValue = "off"
+)
+
+var (
+	// Limit usage command flags
+	flagQuotaSoftLimit uint64
+)
+
+func init() {
+	quotaCmd.AddCommand(quotaEnableCmd)
+	quotaCmd.AddCommand(quotaDisableCmd)
+
+	quotaLimitUsageCmd.Flags().Uint64Var(&flagQuotaSoftLimit, "soft-limit", 0, "Soft limit as a percentage of hard limit")
+	quotaCmd.AddCommand(quotaLimitUsageCmd)
+
+	quotaCmd.AddCommand(quotaRemoveCmd)
+	quotaCmd.AddCommand(quotaListCmd)
+
+	volumeCmd.AddCommand(quotaCmd)
+}
+
+var quotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: helpQuotaCmd,
+}
+
+func quotaSetEnableOption(cmd *cobra.Command, volname, value string) error {
+	// Set Option set flag to advanced
+	flagSetAdv = true
+	return volumeOptionJSONHandler(cmd, volname, []string{quotaEnableOptionKey, value})
+}
+
+var quotaEnableCmd = &cobra.Command{
+	Use:   "enable <volname>",
+	Short: helpQuotaEnableCmd,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		volname := args[0]
+		if err := quotaSetEnableOption(cmd, volname, quotaEnableOptionValue); err != nil {
+			if GlobalFlag.Verbose {
+				log.WithError(err).WithField("volume", volname).Error("failed to enable quota")
+			}
+			failure(fmt.Sprintf("Failed to enable quota for volume %s\n", volname), err, 1)
+		}
+		fmt.Printf("Quota enabled successfully for volume %s\n", volname)
+	},
+}
+
+var quotaDisableCmd = &cobra.Command{
+	Use:   "disable <volname>",
+	Short: helpQuotaDisableCmd,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		volname := args[0]
+		if err := quotaSetEnableOption(cmd, volname, quotaDisableOptionValue); err != nil {
+			if GlobalFlag.Verbose {
+				log.WithError(err).WithField("volume", volname).Error("failed to disable quota")
+			}
+			failure(fmt.Sprintf("Failed to disable quota for volume %s\n", volname), err, 1)
+		}
+		fmt.Printf("Quota disabled successfully for volume %s\n", volname)
+	},
+}
+
+var quotaLimitUsageCmd = &cobra.Command{
+	Use:   "limit-usage <volname> <path> <size> [--soft-limit <percent>]",
+	Short: helpQuotaLimitUsageCmd,
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		volname := args[0]
+		dirpath := args[1]
+		hardLimit, err := sizeToBytes(args[2])
+		if err != nil {
+			failure("Invalid limit size specified", err, 1)
+		}
+
+		req := quotaapi.SetLimitReq{
+			Path:      dirpath,
+			HardLimit: hardLimit,
+			SoftLimit: flagQuotaSoftLimit,
+		}
+		if _, err := client.QuotaLimit(volname, req); err != nil {
+			if GlobalFlag.Verbose {
+				log.WithError(err).WithFields(log.Fields{
+					"volume": volname,
+					"path":   dirpath,
+				}).Error("failed to set quota limit")
+			}
+			failure(fmt.Sprintf("Failed to set limit on %s for volume %s\n", dirpath, volname), err, 1)
+		}
+		fmt.Printf("Limit set successfully on %s for volume %s\n", dirpath, volname)
+	},
+}
+
+var quotaRemoveCmd = &cobra.Command{
+	Use:   "remove <volname> <path>",
+	Short: helpQuotaRemoveCmd,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		volname := args[0]
+		dirpath := args[1]
+		if err := client.QuotaRemove(volname, dirpath); err != nil {
+			if GlobalFlag.Verbose {
+				log.WithError(err).WithFields(log.Fields{
+					"volume": volname,
+					"path":   dirpath,
+				}).Error("failed to remove quota limit")
+			}
+			failure(fmt.Sprintf("Failed to remove limit on %s for volume %s\n", dirpath, volname), err, 1)
+		}
+		fmt.Printf("Limit removed successfully on %s for volume %s\n", dirpath, volname)
+	},
+}
+
+var quotaListCmd = &cobra.Command{
+	Use:   "list <volname>",
+	Short: helpQuotaListCmd,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		volname := args[0]
+		limits, err := client.QuotaList(volname)
+		if err != nil {
+			if GlobalFlag.Verbose {
+				log.WithError(err).WithField("volume", volname).Error("failed to list quota limits")
+			}
+			failure(fmt.Sprintf("Failed to list quota limits for volume %s\n", volname), err, 1)
+		}
+
+		if len(limits) == 0 {
+			fmt.Println("No quota limits configured")
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.SetHeader([]string{"Path", "Hard-limit", "Soft-limit", "Used", "Available", "Soft-limit exceeded?", "Hard-limit exceeded?"})
+		for _, limit := range limits {
+			softLimit := "default"
+			if limit.SoftLimit != 0 {
+				softLimit = strconv.FormatUint(limit.SoftLimit, 10) + "%"
+			}
+			table.Append([]string{limit.Path, humanReadable(limit.HardLimit), softLimit,
+				humanReadable(limit.Used), humanReadable(limit.Available),
+				formatBoolYesNo(limit.SoftLimitExceeded), formatBoolYesNo(limit.HardLimitExceeded)})
+		}
+		table.Render()
+	},
+}
@@ -35,8 +35,12 @@ func NewGlustercliCmd() *cobra.Command {
 		Use:   "glustercli",
 		Short: "Gluster Console Manager (command line utility)",
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			checkXMLSupport(cmd, args)
 			opts.Init()
 		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			finishXMLOutput()
+		},
 	}
 	opts.flagSet = rootCmd.PersistentFlags()
 	opts.AddPersistentFlag(opts.flagSet)
@@ -55,6 +59,11 @@ func addSubCommands(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(snapshotCmd)
 	rootCmd.AddCommand(volumeCmd)
 	rootCmd.AddCommand(traceCmd)
+	rootCmd.AddCommand(userCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(jobCmd)
+	rootCmd.AddCommand(txnCmd)
 }
 
 // GlustercliOption will have all global flags set during run time
@@ -66,6 +75,8 @@ type GlustercliOption struct {
 	Insecure   bool
 	Verbose    bool
 	Cacert     string
+	CertFile   string
+	KeyFile    string
 	LogLevel   string
 	User       string
 	Secret     string
@@ -97,10 +108,15 @@ func (gOpt *GlustercliOption) AddPersistentFlag(flagSet *pflag.FlagSet) {
 	flagSet.StringVarP(&gOpt.Cacert, "cacert", "", "", "Path to CA certificate")
 	flagSet.BoolVarP(&gOpt.Insecure, "insecure", "", false,
 		"Skip server certificate validation")
+	flagSet.StringVar(&gOpt.CertFile, "client-cert", "", "Path to client certificate to authenticate with")
+	flagSet.StringVar(&gOpt.KeyFile, "client-key", "", "Path to the private key of the client certificate")
 }
 
 //Init will initialize logging, secret and rest client
 func (gOpt *GlustercliOption) Init() {
+	// Set up XML output before logging as the logs are written to stdout
+	initXMLOutput()
+
 	//Initialize logging
 	if err := logging.Init("", "stdout", gOpt.LogLevel, false); err != nil {
 		fmt.Println("Error initializing log file ", err)
@@ -120,6 +136,7 @@ func (gOpt *GlustercliOption) Init() {
 // Secret is taken in following order of precedence (highest to lowest):
 // --secret
 // --secret-file
+// --client-cert (no secret is used)
 // GD2_AUTH_SECRET (environment variable)
 // --secret-file (default path)
 //
@@ -141,6 +158,11 @@ func (gOpt *GlustercliOption) SetSecret() {
 		return
 	}
 
+	// --client-cert
+	if gOpt.CertFile != "" {
+		return
+	}
+
 	// GD2_AUTH_SECRET
 	if secret := os.Getenv("GD2_AUTH_SECRET"); secret != "" {
 		gOpt.Secret = secret
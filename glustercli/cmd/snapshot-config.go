@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpSnapshotConfigCmd      = "Manage Gluster Snapshot configuration"
+	helpSnapshotConfigGetCmd   = "Get snapshot configuration of the cluster or a volume"
+	helpSnapshotConfigSetCmd   = "Set snapshot configuration of the cluster or a volume"
+	helpSnapshotConfigResetCmd = "Reset snapshot configuration of the cluster or a volume to defaults"
+	snapshotConfigSetHelpLong  = "Set snapshot configuration. Supported keys are snap-max-hard-limit, snap-max-soft-limit, auto-delete and activate-on-create. Only snap-max-hard-limit can be set for a volume."
+)
+
+var (
+	flagSnapshotConfigVolume string
+
+	snapshotConfigCmd = &cobra.Command{
+		Use:   "config",
+		Short: helpSnapshotConfigCmd,
+	}
+
+	snapshotConfigGetCmd = &cobra.Command{
+		Use:   "get",
+		Short: helpSnapshotConfigGetCmd,
+		Args:  cobra.NoArgs,
+		Run:   snapshotConfigGetCmdRun,
+	}
+
+	snapshotConfigSetCmd = &cobra.Command{
+		Use:   "set <key> <value> [<key> <value>]...",
+		Short: helpSnapshotConfigSetCmd,
+		Long:  snapshotConfigSetHelpLong,
+		Args:  cobra.MinimumNArgs(2),
+		Run:   snapshotConfigSetCmdRun,
+	}
+
+	snapshotConfigResetCmd = &cobra.Command{
+		Use:   "reset [<key>]...",
+		Short: helpSnapshotConfigResetCmd,
+		Run:   snapshotConfigResetCmdRun,
+	}
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{snapshotConfigGetCmd, snapshotConfigSetCmd, snapshotConfigResetCmd} {
+		cmd.Flags().StringVar(&flagSnapshotConfigVolume, "volume", "", "Volume name")
+		snapshotConfigCmd.AddCommand(cmd)
+	}
+
+	snapshotCmd.AddCommand(snapshotConfigCmd)
+}
+
+func snapshotConfigDisplay(resp api.SnapConfigResp) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Key", "Value"})
+	table.Append([]string{"snap-max-hard-limit", strconv.FormatUint(resp.HardLimit, 10)})
+	table.Append([]string{"snap-max-soft-limit", fmt.Sprintf("%d%%", resp.SoftLimit)})
+	table.Append([]string{"auto-delete", formatBoolYesNo(resp.AutoDelete)})
+	table.Append([]string{"activate-on-create", formatBoolYesNo(resp.ActivateOnCreate)})
+	table.Render()
+}
+
+func snapshotConfigGetCmdRun(cmd *cobra.Command, args []string) {
+	resp, err := client.SnapshotConfigGet(flagSnapshotConfigVolume)
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", flagSnapshotConfigVolume).Error("failed to get snapshot config")
+		}
+		failure("Failed to get snapshot config", err, 1)
+	}
+	snapshotConfigDisplay(resp)
+}
+
+func snapshotConfigSetCmdRun(cmd *cobra.Command, args []string) {
+	if len(args)%2 != 0 {
+		failure("Invalid snapshot config", errors.New("each key should be followed by a value"), 1)
+	}
+
+	req := api.SnapConfigReq{
+		VolName: flagSnapshotConfigVolume,
+		Options: make(map[string]string),
+	}
+	for i := 0; i < len(args); i += 2 {
+		req.Options[args[i]] = args[i+1]
+	}
+
+	resp, err := client.SnapshotConfigSet(req)
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", flagSnapshotConfigVolume).Error("failed to set snapshot config")
+		}
+		failure("Failed to set snapshot config", err, 1)
+	}
+	fmt.Println("Snapshot config set successfully")
+	snapshotConfigDisplay(resp)
+}
+
+func snapshotConfigResetCmdRun(cmd *cobra.Command, args []string) {
+	req := api.SnapConfigResetReq{
+		VolName: flagSnapshotConfigVolume,
+		Options: args,
+	}
+
+	resp, err := client.SnapshotConfigReset(req)
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", flagSnapshotConfigVolume).Error("failed to reset snapshot config")
+		}
+		failure("Failed to reset snapshot config", err, 1)
+	}
+	fmt.Println("Snapshot config reset successfully")
+	snapshotConfigDisplay(resp)
+}
@@ -53,6 +53,9 @@ func snapshotInfoDisplay(snap api.SnapGetResp) {
 	}
 	fmt.Println("Labels:", "To Be Added")
 	fmt.Println("Snapshot Description:", snap.Description)
+	if snap.Schedule != "" {
+		fmt.Println("Snapshot Schedule:", snap.Schedule)
+	}
 	fmt.Println()
 
 	return
@@ -68,6 +71,10 @@ func snapshotInfoHandler(cmd *cobra.Command) error {
 	if err != nil {
 		return err
 	}
+	if GlobalFlag.XMLOutput {
+		printXML(newXMLSnapInfo(snap))
+		return nil
+	}
 	snapshotInfoDisplay(snap)
 	return err
 }
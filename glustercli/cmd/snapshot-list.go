@@ -33,6 +33,11 @@ func snapshotListHandler(cmd *cobra.Command) error {
 		return err
 	}
 
+	if GlobalFlag.XMLOutput {
+		printXML(newXMLSnapList(snaps))
+		return nil
+	}
+
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetAutoMergeCells(true)
 	table.SetRowLine(true)
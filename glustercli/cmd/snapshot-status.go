@@ -56,6 +56,8 @@ func snapshotStatusHandler(cmd *cobra.Command) error {
 
 	if snapname == "" {
 		// TODO Status for all snapshot
+	} else if GlobalFlag.XMLOutput {
+		printXML(newXMLSnapStatus(snap))
 	} else {
 		displaySnapshotStatus(snap)
 	}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpTxnCmd     = "Pending transactions and the transaction history"
+	helpTxnListCmd = "List transactions"
+	helpTxnInfoCmd = "Show the steps and the status on each peer of a transaction"
+)
+
+var (
+	txnCmd = &cobra.Command{
+		Use:   "transaction",
+		Short: helpTxnCmd,
+	}
+
+	txnListCmd = &cobra.Command{
+		Use:   "list",
+		Short: helpTxnListCmd,
+		Args:  cobra.NoArgs,
+		Run:   txnListCmdRun,
+	}
+
+	txnInfoCmd = &cobra.Command{
+		Use:   "info <txnid>",
+		Short: helpTxnInfoCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   txnInfoCmdRun,
+	}
+)
+
+func init() {
+	txnCmd.AddCommand(txnListCmd)
+	txnCmd.AddCommand(txnInfoCmd)
+}
+
+func txnListCmdRun(cmd *cobra.Command, args []string) {
+	txns, err := client.TransactionList()
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).Error("error getting transactions")
+		}
+		failure("Error getting transactions", err, 1)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Request ID", "State", "Rollback", "Started", "Error"})
+	for _, t := range txns {
+		table.Append([]string{
+			t.ID.String(),
+			t.ReqID.String(),
+			t.State,
+			t.Rollback,
+			t.StartTime.Local().Format(time.RFC3339),
+			t.Error,
+		})
+	}
+	table.Render()
+}
+
+func txnInfoCmdRun(cmd *cobra.Command, args []string) {
+	txn, err := client.TransactionGet(args[0])
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("txn", args[0]).Error("error getting transaction")
+		}
+		failure("Error getting transaction", err, 1)
+	}
+
+	fmt.Println("ID:", txn.ID)
+	fmt.Println("Request ID:", txn.ReqID)
+	fmt.Println("State:", txn.State)
+	if txn.Error != "" {
+		fmt.Println("Error:", txn.Error)
+	}
+	if txn.Rollback != "" {
+		fmt.Println("Rollback:", txn.Rollback)
+	}
+	fmt.Println("Started:", txn.StartTime.Local().Format(time.RFC3339))
+	if !txn.EndTime.IsZero() {
+		fmt.Println("Finished:", txn.EndTime.Local().Format(time.RFC3339))
+	}
+
+	fmt.Println("Steps:")
+	for i, s := range txn.Steps {
+		var nodes []string
+		for _, n := range s.Nodes {
+			nodes = append(nodes, n.String())
+		}
+		skip := ""
+		if s.Skip {
+			skip = " (skipped)"
+		}
+		fmt.Printf("  %d. %s%s on %s\n", i, s.DoFunc, skip, strings.Join(nodes, ", "))
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Peer ID", "State", "Last Executed Step", "Rolled Back", "Reason"})
+	for _, n := range txn.Nodes {
+		table.Append([]string{
+			n.PeerID.String(),
+			n.State,
+			strconv.Itoa(n.LastExecutedStep),
+			strconv.FormatBool(n.RolledBack),
+			n.Reason,
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpUserCmd       = "REST API User Management"
+	helpUserCreateCmd = "Create a REST API user"
+	helpUserEditCmd   = "Change the role or the secret of a REST API user"
+	helpUserDeleteCmd = "Delete a REST API user"
+	helpUserListCmd   = "List REST API users"
+	helpUserRoleFlag  = "Role of the user: read-only, volume-admin or cluster-admin"
+)
+
+var (
+	flagUserCreateRole     string
+	flagUserCreateSecret   string
+	flagUserCreateCertOnly bool
+	flagUserEditRole       string
+	flagUserEditSecret     string
+
+	userCmd = &cobra.Command{
+		Use:   "user",
+		Short: helpUserCmd,
+	}
+
+	userCreateCmd = &cobra.Command{
+		Use:   "create <username>",
+		Short: helpUserCreateCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   userCreateCmdRun,
+	}
+
+	userEditCmd = &cobra.Command{
+		Use:   "edit <username>",
+		Short: helpUserEditCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   userEditCmdRun,
+	}
+
+	userDeleteCmd = &cobra.Command{
+		Use:   "delete <username>",
+		Short: helpUserDeleteCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   userDeleteCmdRun,
+	}
+
+	userListCmd = &cobra.Command{
+		Use:   "list",
+		Short: helpUserListCmd,
+		Args:  cobra.NoArgs,
+		Run:   userListCmdRun,
+	}
+)
+
+func init() {
+	userCreateCmd.Flags().StringVar(&flagUserCreateRole, "role", "read-only", helpUserRoleFlag)
+	userCreateCmd.Flags().StringVar(&flagUserCreateSecret, "secret", "", "Shared secret of the user, generated if not specified")
+	userCreateCmd.Flags().BoolVar(&flagUserCreateCertOnly, "cert-only", false, "User can only authenticate with a client certificate")
+	userCmd.AddCommand(userCreateCmd)
+
+	userEditCmd.Flags().StringVar(&flagUserEditRole, "role", "", helpUserRoleFlag)
+	userEditCmd.Flags().StringVar(&flagUserEditSecret, "secret", "", "New shared secret of the user")
+	userCmd.AddCommand(userEditCmd)
+
+	userCmd.AddCommand(userDeleteCmd)
+	userCmd.AddCommand(userListCmd)
+}
+
+func userCreateCmdRun(cmd *cobra.Command, args []string) {
+	username := args[0]
+	resp, err := client.UserCreate(api.UserCreateReq{
+		Name:     username,
+		Secret:   flagUserCreateSecret,
+		Role:     flagUserCreateRole,
+		CertOnly: flagUserCreateCertOnly,
+	})
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("user", username).Error("user create failed")
+		}
+		failure("User create failed", err, 1)
+	}
+	fmt.Printf("User %s created with role %s\n", resp.Name, resp.Role)
+	if resp.Secret != "" {
+		fmt.Println("Secret:", resp.Secret)
+	}
+}
+
+func userEditCmdRun(cmd *cobra.Command, args []string) {
+	username := args[0]
+	resp, err := client.UserEdit(username, api.UserEditReq{
+		Secret: flagUserEditSecret,
+		Role:   flagUserEditRole,
+	})
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("user", username).Error("user edit failed")
+		}
+		failure("User edit failed", err, 1)
+	}
+	fmt.Printf("User %s updated, role %s\n", resp.Name, resp.Role)
+}
+
+func userDeleteCmdRun(cmd *cobra.Command, args []string) {
+	username := args[0]
+	if err := client.UserDelete(username); err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("user", username).Error("user delete failed")
+		}
+		failure("User delete failed", err, 1)
+	}
+	fmt.Printf("User %s deleted\n", username)
+}
+
+func userListCmdRun(cmd *cobra.Command, args []string) {
+	users, err := client.Users()
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).Error("error getting users list")
+		}
+		failure("Error getting users list", err, 1)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Role"})
+	for _, u := range users {
+		table.Append([]string{u.Name, u.Role})
+	}
+	table.Render()
+}
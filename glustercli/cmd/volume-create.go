@@ -30,6 +30,7 @@ var (
 	flagCreateDepOpts                 bool
 	flagCreateThinArbiter             string
 	flagCreateVolumeOptions           []string
+	flagCreateTemplate                string
 
 	flagCreateVolumeSize            string
 	flagCreateDistributeCount       int
@@ -66,6 +67,7 @@ func init() {
 	volumeCreateCmd.Flags().BoolVar(&flagCreateForce, "force", false, "Force")
 	volumeCreateCmd.Flags().StringSliceVar(&flagCreateVolumeOptions, "options", nil,
 		"Volume options in the format option:value,option:value")
+	volumeCreateCmd.Flags().StringVar(&flagCreateTemplate, "template", "", "Volfile template used to generate the volfiles of the volume")
 
 	// set volume options during volume create
 	volumeCreateCmd.Flags().BoolVar(&flagCreateAdvOpts, "allow-advanced-options", false, "Allow setting advanced volume options")
@@ -148,6 +150,7 @@ func smartVolumeCreate(cmd *cobra.Command, args []string) {
 		SubvolZonesOverlap:      flagCreateSubvolZoneOverlap,
 		Force:                   flagCreateForce,
 		ProvisionerType:         flagProvisionerType,
+		Template:                flagCreateTemplate,
 	}
 
 	vol, err := client.VolumeCreate(req)
@@ -295,9 +298,10 @@ func volumeCreateCmdRun(cmd *cobra.Command, args []string) {
 	}
 
 	req := api.VolCreateReq{
-		Name:    volname,
-		Subvols: subvols,
-		Force:   flagCreateForce,
+		Name:     volname,
+		Subvols:  subvols,
+		Force:    flagCreateForce,
+		Template: flagCreateTemplate,
 		VolOptionReq: api.VolOptionReq{
 			Options: options,
 			VolOptionFlags: api.VolOptionFlags{
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpVolumeRenameCmd     = "Rename a Gluster Volume"
+	volumeRenameCmdHelpLong = "Rename a stopped volume. Snapshots, snapshot schedules and geo-replication sessions of the volume are updated to refer to the new name. Volumes hosting block volumes cannot be renamed. Clients have to mount the volume using the new name."
+)
+
+var (
+	volumeRenameCmd = &cobra.Command{
+		Use:   "rename <volname> <newname>",
+		Short: helpVolumeRenameCmd,
+		Long:  volumeRenameCmdHelpLong,
+		Args:  cobra.ExactArgs(2),
+		Run:   volumeRenameCmdRun,
+	}
+)
+
+func init() {
+	volumeCmd.AddCommand(volumeRenameCmd)
+}
+
+func volumeRenameCmdRun(cmd *cobra.Command, args []string) {
+	volname := args[0]
+	vol, err := client.VolumeRename(volname, api.VolRenameReq{
+		NewName: args[1],
+	})
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("volume rename failed")
+		}
+		failure("Volume rename failed", err, 1)
+	}
+	fmt.Printf("%s Volume renamed successfully to %s\n", volname, vol.Name)
+}
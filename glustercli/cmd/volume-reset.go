@@ -16,7 +16,7 @@ const (
 )
 
 var (
-	flagForce, flagResetAll bool
+	flagForce, flagResetAll, flagResetDryRun bool
 )
 
 var volumeResetCmd = &cobra.Command{
@@ -40,6 +40,14 @@ var volumeResetCmd = &cobra.Command{
 				req.Options = options
 			}
 		}
+		if flagResetDryRun {
+			resp, err := client.VolumeResetDryRun(volname, req)
+			if err != nil {
+				failure("Volume reset dry run failed", err, 1)
+			}
+			printVolfileDiffs(resp.Volfiles)
+			return
+		}
 		err := client.VolumeReset(volname, req)
 		if err != nil {
 			if GlobalFlag.Verbose {
@@ -54,5 +62,6 @@ var volumeResetCmd = &cobra.Command{
 func init() {
 	volumeResetCmd.Flags().BoolVar(&flagForce, "force", false, "Force reset the volume option")
 	volumeResetCmd.Flags().BoolVar(&flagResetAll, "all", false, "Reset all the volume options")
+	volumeResetCmd.Flags().BoolVar(&flagResetDryRun, "dry-run", false, "Show the changes to the volfiles without resetting the options")
 	volumeCmd.AddCommand(volumeResetCmd)
 }
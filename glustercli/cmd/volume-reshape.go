@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpVolumeReshapeCmd     = "Change the replica count of a Gluster Volume"
+	volumeReshapeCmdHelpLong = "Change the replica count of a volume by adding or removing one brick per subvolume. Bricks are listed in subvolume order. A full self-heal is triggered to populate added bricks."
+)
+
+var (
+	flagReshapeCmdReplicaCount int
+	flagReshapeCmdArbiter      bool
+	flagReshapeCmdForce        bool
+
+	volumeReshapeCmd = &cobra.Command{
+		Use:   "reshape <volname> <brick> [<brick>]...",
+		Short: helpVolumeReshapeCmd,
+		Long:  volumeReshapeCmdHelpLong,
+		Args:  cobra.MinimumNArgs(2),
+		Run:   volumeReshapeCmdRun,
+	}
+)
+
+func init() {
+	volumeReshapeCmd.Flags().IntVar(&flagReshapeCmdReplicaCount, "replica", 0, "New Replica Count")
+	volumeReshapeCmd.Flags().BoolVar(&flagReshapeCmdArbiter, "arbiter", false, "Use an arbiter brick as the last brick of every subvolume")
+	volumeReshapeCmd.Flags().BoolVarP(&flagReshapeCmdForce, "force", "f", false, "Force")
+	volumeReshapeCmd.MarkFlagRequired("replica")
+
+	volumeCmd.AddCommand(volumeReshapeCmd)
+}
+
+func volumeReshapeCmdRun(cmd *cobra.Command, args []string) {
+	volname := args[0]
+	bricks, err := bricksAsUUID(args[1:])
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("error getting brick UUIDs")
+		}
+		failure("Error getting brick UUIDs", err, 1)
+	}
+
+	vol, err := client.VolumeReshape(volname, api.VolReshapeReq{
+		ReplicaCount: flagReshapeCmdReplicaCount,
+		Arbiter:      flagReshapeCmdArbiter,
+		Bricks:       bricks,
+		Force:        flagReshapeCmdForce,
+	})
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("volume reshape failed")
+		}
+		failure("Volume reshape failed", err, 1)
+	}
+	fmt.Printf("%s Volume reshaped successfully\n", vol.Name)
+	fmt.Println("Volume Type:", vol.Type)
+}
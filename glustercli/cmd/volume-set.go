@@ -16,7 +16,7 @@ const (
 )
 
 var (
-	flagSetAdv, flagSetExp, flagSetDep bool
+	flagSetAdv, flagSetExp, flagSetDep, flagSetDryRun bool
 
 	volumeSetCmd = &cobra.Command{
 		Use:   "set <volname> <option> <value> [<option> <value>]...",
@@ -31,6 +31,7 @@ func init() {
 	volumeSetCmd.Flags().BoolVar(&flagSetAdv, "advanced", false, "Allow setting advanced options")
 	volumeSetCmd.Flags().BoolVar(&flagSetExp, "experimental", false, "Allow setting experimental options")
 	volumeSetCmd.Flags().BoolVar(&flagSetDep, "deprecated", false, "Allow setting deprecated options")
+	volumeSetCmd.Flags().BoolVar(&flagSetDryRun, "dry-run", false, "Show the changes to the volfiles without setting the options")
 	volumeCmd.AddCommand(volumeSetCmd)
 }
 
@@ -57,7 +58,7 @@ func volumeSetCmdRun(cmd *cobra.Command, args []string) {
 				"volume", volname).Error("volume option set failed")
 		}
 		failure("Volume option set failed", err, 1)
-	} else {
+	} else if !flagSetDryRun {
 		fmt.Printf("Options set successfully for %s volume\n", volname)
 	}
 }
@@ -77,14 +78,23 @@ func volumeOptionJSONHandler(cmd *cobra.Command, volname string, options []strin
 		return err
 	}
 
-	err := client.VolumeSet(volname, api.VolOptionReq{
+	req := api.VolOptionReq{
 		Options: vopt,
 		VolOptionFlags: api.VolOptionFlags{
 			AllowAdvanced:     flagSetAdv,
 			AllowExperimental: flagSetExp,
 			AllowDeprecated:   flagSetDep,
 		},
-	})
+	}
+
+	if flagSetDryRun {
+		resp, err := client.VolumeSetDryRun(volname, req)
+		if err != nil {
+			return err
+		}
+		printVolfileDiffs(resp.Volfiles)
+		return nil
+	}
 
-	return err
+	return client.VolumeSet(volname, req)
 }
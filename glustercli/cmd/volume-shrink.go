@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpVolumeShrinkCmd       = "Remove bricks from a Gluster Volume"
+	helpVolumeShrinkStartCmd  = "Start migrating data off the bricks to be removed"
+	helpVolumeShrinkStatusCmd = "Show the progress of data migration off the bricks being removed"
+	helpVolumeShrinkCommitCmd = "Remove the bricks from the volume once data migration has completed"
+	helpVolumeShrinkStopCmd   = "Stop removing bricks, retaining them in the volume"
+)
+
+var (
+	flagShrinkStartCmdForce  bool
+	flagShrinkCommitCmdForce bool
+
+	volumeShrinkCmd = &cobra.Command{
+		Use:   "remove-brick",
+		Short: helpVolumeShrinkCmd,
+	}
+
+	volumeShrinkStartCmd = &cobra.Command{
+		Use:   "start <volname> <brick> [<brick>]...",
+		Short: helpVolumeShrinkStartCmd,
+		Args:  cobra.MinimumNArgs(2),
+		Run:   volumeShrinkStartCmdRun,
+	}
+
+	volumeShrinkStatusCmd = &cobra.Command{
+		Use:   "status <volname>",
+		Short: helpVolumeShrinkStatusCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   volumeShrinkStatusCmdRun,
+	}
+
+	volumeShrinkCommitCmd = &cobra.Command{
+		Use:   "commit <volname>",
+		Short: helpVolumeShrinkCommitCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   volumeShrinkCommitCmdRun,
+	}
+
+	volumeShrinkStopCmd = &cobra.Command{
+		Use:   "stop <volname>",
+		Short: helpVolumeShrinkStopCmd,
+		Args:  cobra.ExactArgs(1),
+		Run:   volumeShrinkStopCmdRun,
+	}
+)
+
+func init() {
+	volumeShrinkStartCmd.Flags().BoolVarP(&flagShrinkStartCmdForce, "force", "f", false, "Remove bricks without migrating data")
+	volumeShrinkCmd.AddCommand(volumeShrinkStartCmd)
+
+	volumeShrinkCmd.AddCommand(volumeShrinkStatusCmd)
+
+	volumeShrinkCommitCmd.Flags().BoolVarP(&flagShrinkCommitCmdForce, "force", "f", false, "Commit even if data migration has not completed successfully")
+	volumeShrinkCmd.AddCommand(volumeShrinkCommitCmd)
+
+	volumeShrinkCmd.AddCommand(volumeShrinkStopCmd)
+
+	volumeCmd.AddCommand(volumeShrinkCmd)
+}
+
+// formatMigrationStatus converts the status reported by the rebalance process
+// to a human readable form
+func formatMigrationStatus(status string) string {
+	switch status {
+	case "0":
+		return "not started"
+	case "1":
+		return "in progress"
+	case "2":
+		return "stopped"
+	case "3":
+		return "completed"
+	case "4":
+		return "failed"
+	default:
+		return status
+	}
+}
+
+func volumeShrinkStatusDisplay(resp api.VolumeShrinkStatusResp) {
+	fmt.Println("Volume:", resp.VolName)
+	fmt.Println("Migration Complete:", formatBoolYesNo(resp.Complete))
+	fmt.Println("Bricks:")
+	for _, b := range resp.Bricks {
+		fmt.Printf("  %s:%s\n", b.Hostname, b.Path)
+	}
+
+	if len(resp.Nodes) == 0 {
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Peer ID", "Status", "Migrated Files", "Size", "Scanned", "Skipped", "Failures", "Run Time"})
+	for _, n := range resp.Nodes {
+		table.Append([]string{
+			n.PeerID.String(),
+			formatMigrationStatus(n.Status),
+			n.MigratedFiles,
+			n.MigratedSize,
+			n.ScannedFiles,
+			n.SkippedFiles,
+			n.Failures,
+			n.RunTime,
+		})
+	}
+	table.Render()
+}
+
+func volumeShrinkStartCmdRun(cmd *cobra.Command, args []string) {
+	volname := args[0]
+	bricks, err := bricksAsUUID(args[1:])
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("error getting brick UUIDs")
+		}
+		failure("Error getting brick UUIDs", err, 1)
+	}
+
+	resp, err := client.VolumeShrink(volname, api.VolShrinkReq{
+		Bricks: bricks,
+		Force:  flagShrinkStartCmdForce,
+	})
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("volume shrink failed")
+		}
+		failure("Removal of bricks failed", err, 1)
+	}
+
+	if flagShrinkStartCmdForce {
+		fmt.Printf("Bricks removed from volume %s\n", volname)
+		return
+	}
+	fmt.Printf("Started migrating data off the bricks of volume %s. Check progress with \"volume remove-brick status\"\n", volname)
+	volumeShrinkStatusDisplay(resp)
+}
+
+func volumeShrinkStatusCmdRun(cmd *cobra.Command, args []string) {
+	volname := args[0]
+	resp, err := client.VolumeShrinkStatus(volname)
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("failed to get volume shrink status")
+		}
+		failure("Failed to get remove-brick status", err, 1)
+	}
+	volumeShrinkStatusDisplay(resp)
+}
+
+func volumeShrinkCommitCmdRun(cmd *cobra.Command, args []string) {
+	volname := args[0]
+	_, err := client.VolumeShrinkCommit(volname, api.VolShrinkCommitReq{
+		Force: flagShrinkCommitCmdForce,
+	})
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("volume shrink commit failed")
+		}
+		failure("Failed to commit remove-brick", err, 1)
+	}
+	fmt.Printf("Bricks removed from volume %s\n", volname)
+}
+
+func volumeShrinkStopCmdRun(cmd *cobra.Command, args []string) {
+	volname := args[0]
+	_, err := client.VolumeShrinkStop(volname)
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("volume shrink stop failed")
+		}
+		failure("Failed to stop remove-brick", err, 1)
+	}
+	fmt.Printf("Stopped removing bricks from volume %s\n", volname)
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+)
+
+var flagStatusBrick string
+
+func volumeStatusDetailCmdRun(volname, option string) {
+	status, err := client.VolumeStatusDetail(volname, option, flagStatusBrick)
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithFields(log.Fields{
+				"volume": volname,
+				"option": option,
+			}).Error("error getting detailed volume status")
+		}
+		failure("Error getting volume status", err, 1)
+	}
+
+	fmt.Println("Volume :", volname)
+	for _, b := range status.Bricks {
+		fmt.Printf("\nBrick: %s:%s\n", b.Info.Hostname, b.Info.Path)
+		if !b.Online {
+			fmt.Println("Brick is offline")
+			continue
+		}
+		if b.Error != "" {
+			fmt.Println("Error:", b.Error)
+			continue
+		}
+
+		switch option {
+		case "detail":
+			printBrickStatusDetail(b)
+		case "clients":
+			printBrickClients(b.Clients)
+		case "mem":
+			printBrickMemInfo(b.Memory)
+		case "inode":
+			printInodeTables(b.InodeTables)
+		case "fd":
+			printFdTables(b.FdTables)
+		case "callpool":
+			printCallPool(b.CallPool)
+		}
+	}
+}
+
+func printBrickStatusDetail(b api.BrickStatusDetail) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Append([]string{"TCP Port", strconv.Itoa(b.Port)})
+	table.Append([]string{"Pid", strconv.Itoa(b.Pid)})
+	table.Append([]string{"File System", b.FS})
+	table.Append([]string{"Device", b.Device})
+	table.Append([]string{"Mount Options", b.MountOpts})
+	table.Append([]string{"Block Size", humanReadable(b.BlockSize)})
+	table.Append([]string{"Disk Space Free", humanReadable(b.Size.Free)})
+	table.Append([]string{"Total Disk Space", humanReadable(b.Size.Capacity)})
+	table.Append([]string{"Inode Count", strconv.FormatUint(b.Inodes, 10)})
+	table.Append([]string{"Free Inodes", strconv.FormatUint(b.FreeInodes, 10)})
+	table.Render()
+}
+
+func printBrickClients(clients []api.BrickClient) {
+	fmt.Println("Clients connected:", len(clients))
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Hostname", "Bytes Read", "Bytes Written", "Op Version"})
+	for _, c := range clients {
+		table.Append([]string{c.Hostname, strconv.FormatUint(c.BytesRead, 10),
+			strconv.FormatUint(c.BytesWritten, 10), strconv.FormatUint(uint64(c.OpVersion), 10)})
+	}
+	table.Render()
+}
+
+func printBrickMemInfo(mem *api.BrickMemInfo) {
+	if mem == nil {
+		return
+	}
+
+	fmt.Println("Mallinfo")
+	var keys []string
+	for k := range mem.Mallinfo {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	table := tablewriter.NewWriter(os.Stdout)
+	for _, k := range keys {
+		table.Append([]string{k, strconv.FormatUint(mem.Mallinfo[k], 10)})
+	}
+	table.Render()
+
+	fmt.Println("Mempool Stats")
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "HotCount", "ColdCount", "PaddedSizeof", "AllocCount", "MaxAlloc", "Misses", "Max-StdAlloc"})
+	for _, p := range mem.MemPools {
+		table.Append([]string{p.Name, strconv.Itoa(p.HotCount), strconv.Itoa(p.ColdCount),
+			strconv.FormatUint(p.PaddedSize, 10), strconv.FormatUint(p.AllocCount, 10),
+			strconv.Itoa(p.MaxAlloc), strconv.FormatUint(p.PoolMisses, 10), strconv.Itoa(p.MaxStdAlloc)})
+	}
+	table.Render()
+}
+
+func printInodes(title string, inodes []api.BrickInode) {
+	if len(inodes) == 0 {
+		return
+	}
+	fmt.Println(title)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"GFID", "Lookups", "Ref", "IA Type"})
+	for _, i := range inodes {
+		table.Append([]string{i.GFID, strconv.FormatUint(i.NLookup, 10),
+			strconv.FormatUint(uint64(i.Ref), 10), strconv.Itoa(i.IAType)})
+	}
+	table.Render()
+}
+
+func printInodeTables(tables []api.InodeTable) {
+	for i, t := range tables {
+		fmt.Printf("Connection %d:\n", i+1)
+		fmt.Printf("Active inodes: %d, LRU inodes: %d, Purge inodes: %d\n", t.ActiveSize, t.LRUSize, t.PurgeSize)
+		printInodes("Active inodes:", t.Active)
+		printInodes("LRU inodes:", t.LRU)
+		printInodes("Purged inodes:", t.Purge)
+	}
+}
+
+func printFdTables(tables []api.FdTable) {
+	for i, t := range tables {
+		fmt.Printf("Connection %d:\n", i+1)
+		fmt.Printf("RefCount: %d, MaxFds: %d, FirstFree: %d, OpenFds: %d\n", t.RefCount, t.MaxFds, t.FirstFree, t.OpenFds)
+		if len(t.Fds) == 0 {
+			continue
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"FD Entry", "PID", "RefCount", "Flags"})
+		for _, fd := range t.Fds {
+			table.Append([]string{strconv.Itoa(fd.Entry), strconv.Itoa(fd.Pid),
+				strconv.Itoa(fd.RefCount), strconv.Itoa(fd.Flags)})
+		}
+		table.Render()
+	}
+}
+
+func printCallPool(stacks []api.CallStack) {
+	fmt.Println("Pending calls:", len(stacks))
+	for i, s := range stacks {
+		fmt.Printf("Call Stack%d: Unique: %d, Op: %s, UID: %d, GID: %d, PID: %d, Type: %d, Frames: %d\n",
+			i+1, s.Unique, s.Op, s.UID, s.GID, s.PID, s.Type, len(s.Frames))
+		if len(s.Frames) == 0 {
+			continue
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Translator", "RefCount", "Complete", "Parent", "Wind From", "Wind To", "Unwind From", "Unwind To"})
+		for _, f := range s.Frames {
+			table.Append([]string{f.Translator, strconv.Itoa(f.RefCount), strconv.FormatBool(f.Complete),
+				f.Parent, f.WindFrom, f.WindTo, f.UnwindFrom, f.UnwindTo})
+		}
+		table.Render()
+	}
+}
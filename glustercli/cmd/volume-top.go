@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/olekukonko/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpVolumeTopCmd     = "Show the most used files of a Gluster Volume"
+	volumeTopCmdHelpLong = "Show the files and directories of a volume with the most calls for a metric, across all the bricks and for each brick. Metrics are open, read, write, opendir, readdir, read-perf and write-perf. For read-perf and write-perf, a throughput test is run on the bricks when --block-size and --count are given."
+)
+
+var (
+	flagTopBrick     string
+	flagTopLimit     int
+	flagTopBlockSize uint64
+	flagTopCount     uint64
+	flagTopPerBrick  bool
+
+	volumeTopCmd = &cobra.Command{
+		Use:   "top <volname> <open|read|write|opendir|readdir|read-perf|write-perf>",
+		Short: helpVolumeTopCmd,
+		Long:  volumeTopCmdHelpLong,
+		Args:  cobra.ExactArgs(2),
+		Run:   volumeTopCmdRun,
+	}
+)
+
+func init() {
+	volumeTopCmd.Flags().StringVar(&flagTopBrick, "brick", "", "Show only the files of the brick, given as <host>:<path>")
+	volumeTopCmd.Flags().IntVar(&flagTopLimit, "limit", 0, "Maximum number of files to show (default 100)")
+	volumeTopCmd.Flags().Uint64Var(&flagTopBlockSize, "block-size", 0, "Block size in bytes of the throughput test, at most 1 GiB")
+	volumeTopCmd.Flags().Uint64Var(&flagTopCount, "count", 0, "Number of blocks read or written by the throughput test, at most 10 GiB in all")
+	volumeTopCmd.Flags().BoolVar(&flagTopPerBrick, "per-brick", false, "Show the files of each brick separately")
+
+	volumeCmd.AddCommand(volumeTopCmd)
+}
+
+func volumeTopCmdRun(cmd *cobra.Command, args []string) {
+	volname, metric := args[0], args[1]
+
+	var top api.VolumeTopResp
+	var err error
+	if flagTopBlockSize > 0 || flagTopCount > 0 {
+		top, err = client.VolumeTopThroughput(volname, metric, api.VolumeTopThroughputReq{
+			BlockSize: flagTopBlockSize,
+			Count:     flagTopCount,
+			Brick:     flagTopBrick,
+			Limit:     flagTopLimit,
+		})
+	} else {
+		top, err = client.VolumeTop(volname, metric, flagTopBrick, flagTopLimit)
+	}
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithFields(log.Fields{
+				"volume": volname,
+				"metric": metric,
+			}).Error("failed to get volume top")
+		}
+		failure("Failed to get volume top", err, 1)
+	}
+
+	valueHeader := "Calls"
+	if metric == "read-perf" || metric == "write-perf" {
+		valueHeader = "MBps"
+	}
+
+	if !flagTopPerBrick {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{valueHeader, "Filename", "Brick"})
+		for _, e := range top.Entries {
+			table.Append([]string{formatTopValue(e.Value), e.Filename, e.Brick})
+		}
+		table.Render()
+	}
+
+	for _, b := range top.Bricks {
+		if !flagTopPerBrick && b.Throughput == 0 {
+			continue
+		}
+
+		fmt.Printf("\nBrick: %s\n", b.Brick)
+		if metric == "open" {
+			fmt.Printf("Current open fds: %d, Max open fds: %d, Max open fd time: %s\n", b.CurrentOpen, b.MaxOpen, b.MaxOpenTime)
+		}
+		if b.Throughput != 0 {
+			fmt.Printf("Throughput %.2f MBps time %.4f secs\n", b.Throughput, b.Duration)
+		}
+		if !flagTopPerBrick {
+			continue
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{valueHeader, "Filename", "Time"})
+		for _, e := range b.Entries {
+			table.Append([]string{formatTopValue(e.Value), e.Filename, e.Time})
+		}
+		table.Render()
+	}
+}
+
+func formatTopValue(value float64) string {
+	if value == float64(uint64(value)) {
+		return fmt.Sprintf("%d", uint64(value))
+	}
+	return fmt.Sprintf("%.2f", value)
+}
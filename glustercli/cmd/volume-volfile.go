@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	helpVolumeVolfileCmd     = "Show the volfiles of a Gluster Volume"
+	volumeVolfileCmdHelpLong = "Show the client volfile and the brick volfiles of a volume, or only the volfile with the given volfile ID. The volfiles are generated from the current options of the volume."
+)
+
+var (
+	volumeVolfileCmd = &cobra.Command{
+		Use:   "volfile <volname> [<volfileid>]",
+		Short: helpVolumeVolfileCmd,
+		Long:  volumeVolfileCmdHelpLong,
+		Args:  cobra.RangeArgs(1, 2),
+		Run:   volumeVolfileCmdRun,
+	}
+)
+
+func init() {
+	volumeCmd.AddCommand(volumeVolfileCmd)
+}
+
+func volumeVolfileCmdRun(cmd *cobra.Command, args []string) {
+	volname := args[0]
+
+	var (
+		volfiles api.VolfileListResp
+		err      error
+	)
+	if len(args) > 1 {
+		var volfile api.VolfileGetResp
+		volfile, err = client.VolumeVolfile(volname, args[1])
+		volfiles = api.VolfileListResp{volfile}
+	} else {
+		volfiles, err = client.VolumeVolfiles(volname)
+	}
+	if err != nil {
+		if GlobalFlag.Verbose {
+			log.WithError(err).WithField("volume", volname).Error("failed to get volfiles")
+		}
+		failure("Failed to get volfiles", err, 1)
+	}
+
+	for _, v := range volfiles {
+		fmt.Printf("# %s\n", v.VolfileID)
+		fmt.Print(v.Content)
+	}
+}
+
+// printVolfileDiffs prints the changes to volfiles returned for a dry run
+// of a request
+func printVolfileDiffs(diffs []api.VolfileDiff) {
+	if len(diffs) == 0 {
+		fmt.Println("No changes to the volfiles")
+		return
+	}
+	for _, d := range diffs {
+		fmt.Print(d.Diff)
+	}
+}
@@ -83,6 +83,7 @@ func init() {
 	volumeInfoCmd.Flags().StringVar(&flagCmdFilterValue, "value", "", "Filter by metadata value")
 	volumeCmd.AddCommand(volumeInfoCmd)
 
+	volumeStatusCmd.Flags().StringVar(&flagStatusBrick, "brick", "", "Show the detailed status of only the brick, given as <host>:<path>")
 	volumeCmd.AddCommand(volumeStatusCmd)
 
 	volumeListCmd.Flags().StringVar(&flagCmdFilterKey, "key", "", "Filter by metadata Key")
@@ -349,6 +350,15 @@ func volumeInfoHandler2(cmd *cobra.Command, isInfo bool) error {
 		return err
 	}
 
+	if GlobalFlag.XMLOutput {
+		if isInfo {
+			printXML(newXMLVolInfo(vols))
+		} else {
+			printXML(newXMLVolList(vols))
+		}
+		return nil
+	}
+
 	if len(vols) <= 0 {
 		fmt.Println("No volumes found")
 		return nil
@@ -421,6 +431,7 @@ func volumeStatusDisplay(vol api.BricksStatusResp) {
 func volumeStatusHandler(cmd *cobra.Command) error {
 	var vol api.BricksStatusResp
 	var err error
+	xmlStatus := &xmlVolStatus{}
 	volname := ""
 	if len(cmd.Flags().Args()) > 0 {
 		volname = cmd.Flags().Args()[0]
@@ -437,6 +448,7 @@ func volumeStatusHandler(cmd *cobra.Command) error {
 			fmt.Println("Volume :", volume.Name)
 			if err == nil {
 				volumeStatusDisplay(vol)
+				xmlStatus.Volumes = append(xmlStatus.Volumes, newXMLStatusVolume(volume.Name, vol))
 			} else {
 				if GlobalFlag.Verbose {
 					log.WithError(err).Error("error getting volume status")
@@ -449,16 +461,24 @@ func volumeStatusHandler(cmd *cobra.Command) error {
 		fmt.Println("Volume :", volname)
 		if err == nil {
 			volumeStatusDisplay(vol)
+			xmlStatus.Volumes = append(xmlStatus.Volumes, newXMLStatusVolume(volname, vol))
 		}
 	}
+	if err == nil && GlobalFlag.XMLOutput {
+		printXML(xmlStatus)
+	}
 	return err
 }
 
 var volumeStatusCmd = &cobra.Command{
-	Use:   "status",
+	Use:   "status [<volname> [detail|clients|mem|inode|fd|callpool]]",
 	Short: helpVolumeStatusCmd,
-	Args:  cobra.RangeArgs(0, 1),
+	Args:  cobra.RangeArgs(0, 2),
 	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 2 {
+			volumeStatusDetailCmdRun(args[0], args[1])
+			return
+		}
 		err := volumeStatusHandler(cmd)
 		if err != nil {
 			if GlobalFlag.Verbose {
@@ -0,0 +1,657 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/gluster/glusterd2/pkg/api"
+	bitrotapi "github.com/gluster/glusterd2/plugins/bitrot/api"
+	georepapi "github.com/gluster/glusterd2/plugins/georeplication/api"
+	glustershdapi "github.com/gluster/glusterd2/plugins/glustershd/api"
+
+	"github.com/spf13/cobra"
+)
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+var (
+	// xmlWriter is where the XML document is written. The text output of
+	// the commands is discarded when --xml is set.
+	xmlWriter io.Writer = os.Stdout
+
+	// xmlWritten is set once a command has written its XML document
+	xmlWritten bool
+)
+
+// cliOutput is the root element of the XML output. Its layout follows the
+// output of the gluster CLI run with --xml.
+type cliOutput struct {
+	XMLName  xml.Name `xml:"cliOutput"`
+	OpRet    int      `xml:"opRet"`
+	OpErrno  int      `xml:"opErrno"`
+	OpErrstr string   `xml:"opErrstr"`
+	Output   interface{}
+}
+
+// xmlCommands are the commands which support --xml. Commands which print
+// data write it as an XML element, the others only print a success message
+// and write an empty successful document.
+var xmlCommands = map[string]bool{
+	"glustercli volume info":                true,
+	"glustercli volume list":                true,
+	"glustercli volume status":              true,
+	"glustercli volume start":               true,
+	"glustercli volume stop":                true,
+	"glustercli volume delete":              true,
+	"glustercli volume set":                 true,
+	"glustercli volume reset":               true,
+	"glustercli volume rename":              true,
+	"glustercli volume edit-metadata":       true,
+	"glustercli volume remove-brick start":  true,
+	"glustercli volume remove-brick commit": true,
+	"glustercli volume remove-brick stop":   true,
+	"glustercli peer status":                true,
+	"glustercli peer list":                  true,
+	"glustercli peer add":                   true,
+	"glustercli peer remove":                true,
+	"glustercli peer tls-reload":            true,
+	"glustercli snapshot list":              true,
+	"glustercli snapshot info":              true,
+	"glustercli snapshot status":            true,
+	"glustercli snapshot activate":          true,
+	"glustercli snapshot deactivate":        true,
+	"glustercli snapshot delete":            true,
+	"glustercli snapshot restore":           true,
+	"glustercli snapshot config set":        true,
+	"glustercli snapshot config reset":      true,
+	"glustercli geo-replication status":     true,
+	"glustercli geo-replication create":     true,
+	"glustercli geo-replication start":      true,
+	"glustercli geo-replication stop":       true,
+	"glustercli geo-replication pause":      true,
+	"glustercli geo-replication resume":     true,
+	"glustercli geo-replication delete":     true,
+	"glustercli geo-replication set":        true,
+	"glustercli geo-replication reset":      true,
+	"glustercli bitrot enable":              true,
+	"glustercli bitrot disable":             true,
+	"glustercli bitrot scrub-throttle":      true,
+	"glustercli bitrot scrub-freq":          true,
+	"glustercli bitrot scrub":               true,
+	"glustercli volume heal info":           true,
+	"glustercli volume heal index":          true,
+	"glustercli volume heal full":           true,
+	"glustercli volume heal split-brain":    true,
+	"glustercli volume quota enable":        true,
+	"glustercli volume quota disable":       true,
+	"glustercli volume quota limit-usage":   true,
+	"glustercli volume quota remove":        true,
+	"glustercli events webhook-add":         true,
+	"glustercli events webhook-del":         true,
+	"glustercli trace enable":               true,
+	"glustercli trace update":               true,
+	"glustercli trace disable":              true,
+	"glustercli device add":                 true,
+	"glustercli device delete":              true,
+}
+
+// xmlSupported tells if the command run with the given path and arguments
+// can write its output as XML
+func xmlSupported(cmdPath string, args []string) bool {
+	// The detail views of volume status are only printed as text
+	if cmdPath == "glustercli volume status" && len(args) > 1 {
+		return false
+	}
+	return xmlCommands[cmdPath]
+}
+
+// checkXMLSupport fails the command when --xml is set and the command has
+// no XML output, instead of throwing its text output away
+func checkXMLSupport(cmd *cobra.Command, args []string) {
+	if GlobalFlag.XMLOutput && !xmlSupported(cmd.CommandPath(), args) {
+		failure("XML output not supported", nil, 1)
+	}
+}
+
+// initXMLOutput makes the text printed by the commands go to /dev/null so
+// that only the XML document is written to stdout
+func initXMLOutput() {
+	if !GlobalFlag.XMLOutput {
+		return
+	}
+
+	devnull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		failure("failed to setup XML output", err, 1)
+	}
+	xmlWriter = os.Stdout
+	os.Stdout = devnull
+}
+
+func writeXML(out cliOutput) {
+	b, err := xml.MarshalIndent(out, "", "  ")
+	if err != nil {
+		// Not calling failure() as it writes the error as XML
+		fmt.Fprintln(os.Stderr, "failed to generate XML output:", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(xmlWriter, "%s%s\n", xmlHeader, b)
+	xmlWritten = true
+}
+
+// printXML writes the XML document of a successful command. v is the
+// element holding the output of the command, it can be nil for commands
+// which have no output.
+func printXML(v interface{}) {
+	writeXML(cliOutput{Output: v})
+}
+
+// printXMLFailure writes the XML document of a failed command
+func printXMLFailure(msg string, err error, errcode int) {
+	if err != nil {
+		msg = fmt.Sprintf("%s: %s", msg, err)
+	}
+	writeXML(cliOutput{
+		OpRet:    -1,
+		OpErrno:  errcode,
+		OpErrstr: msg,
+	})
+}
+
+// finishXMLOutput writes the XML document for commands which completed
+// successfully without writing one
+func finishXMLOutput() {
+	if GlobalFlag.XMLOutput && !xmlWritten {
+		printXML(nil)
+	}
+}
+
+func xmlBool(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Volume types and transports are numbered as in glusterd
+var xmlVolTypes = map[api.VolType]int{
+	api.Distribute:    0,
+	api.Replicate:     2,
+	api.Disperse:      4,
+	api.DistReplicate: 7,
+	api.DistDisperse:  9,
+}
+
+var xmlTransports = map[string]int{
+	"tcp":      0,
+	"rdma":     1,
+	"tcp,rdma": 2,
+}
+
+type xmlJob struct {
+	XMLName xml.Name `xml:"job"`
+	ID      string   `xml:"id"`
+}
+
+func newXMLJob(id string) *xmlJob {
+	return &xmlJob{ID: id}
+}
+
+type xmlOption struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value"`
+}
+
+type xmlBrick struct {
+	UUID      string `xml:"uuid,attr"`
+	Text      string `xml:",chardata"`
+	Name      string `xml:"name"`
+	HostUUID  string `xml:"hostUuid"`
+	IsArbiter int    `xml:"isArbiter"`
+}
+
+type xmlVolume struct {
+	Name            string      `xml:"name"`
+	ID              string      `xml:"id"`
+	Status          int         `xml:"status"`
+	StatusStr       string      `xml:"statusStr"`
+	SnapshotCount   int         `xml:"snapshotCount"`
+	BrickCount      int         `xml:"brickCount"`
+	DistCount       int         `xml:"distCount"`
+	ReplicaCount    int         `xml:"replicaCount"`
+	ArbiterCount    int         `xml:"arbiterCount"`
+	DisperseCount   int         `xml:"disperseCount"`
+	RedundancyCount int         `xml:"redundancyCount"`
+	Type            int         `xml:"type"`
+	TypeStr         string      `xml:"typeStr"`
+	Transport       int         `xml:"transport"`
+	Capacity        uint64      `xml:"capacity,omitempty"`
+	Bricks          []xmlBrick  `xml:"bricks>brick"`
+	OptCount        int         `xml:"optCount"`
+	Options         []xmlOption `xml:"options>option"`
+}
+
+type xmlVolInfo struct {
+	XMLName xml.Name    `xml:"volInfo"`
+	Count   int         `xml:"volumes>count"`
+	Volumes []xmlVolume `xml:"volumes>volume"`
+}
+
+func newXMLVolInfo(vols api.VolumeListResp) *xmlVolInfo {
+	info := &xmlVolInfo{Count: len(vols)}
+	for _, vol := range vols {
+		v := xmlVolume{
+			Name:            vol.Name,
+			ID:              vol.ID.String(),
+			Status:          int(vol.State),
+			StatusStr:       vol.State.String(),
+			SnapshotCount:   len(vol.SnapList),
+			DistCount:       vol.DistCount,
+			ReplicaCount:    vol.ReplicaCount,
+			ArbiterCount:    vol.ArbiterCount,
+			DisperseCount:   vol.DisperseCount,
+			RedundancyCount: vol.DisperseRedundancyCount,
+			Type:            xmlVolTypes[vol.Type],
+			TypeStr:         vol.Type.String(),
+			Transport:       xmlTransports[vol.Transport],
+			Capacity:        vol.Capacity,
+		}
+		for _, subvol := range vol.Subvols {
+			for _, b := range subvol.Bricks {
+				name := b.Hostname + ":" + b.Path
+				v.Bricks = append(v.Bricks, xmlBrick{
+					UUID:      b.PeerID.String(),
+					Text:      name,
+					Name:      name,
+					HostUUID:  b.PeerID.String(),
+					IsArbiter: xmlBool(b.Type == api.Arbiter),
+				})
+			}
+		}
+		v.BrickCount = len(v.Bricks)
+
+		keys := make([]string, 0, len(vol.Options))
+		for k := range vol.Options {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v.Options = append(v.Options, xmlOption{Name: k, Value: vol.Options[k]})
+		}
+		v.OptCount = len(v.Options)
+
+		info.Volumes = append(info.Volumes, v)
+	}
+	return info
+}
+
+type xmlVolList struct {
+	XMLName xml.Name `xml:"volList"`
+	Count   int      `xml:"count"`
+	Volumes []string `xml:"volume"`
+}
+
+func newXMLVolList(vols api.VolumeListResp) *xmlVolList {
+	list := &xmlVolList{Count: len(vols)}
+	for _, vol := range vols {
+		list.Volumes = append(list.Volumes, vol.Name)
+	}
+	return list
+}
+
+type xmlStatusNode struct {
+	Hostname   string `xml:"hostname"`
+	Path       string `xml:"path"`
+	PeerID     string `xml:"peerid"`
+	Status     int    `xml:"status"`
+	Port       string `xml:"port"`
+	TCPPort    string `xml:"ports>tcp"`
+	RDMAPort   string `xml:"ports>rdma"`
+	Pid        int    `xml:"pid"`
+	SizeTotal  uint64 `xml:"sizeTotal"`
+	SizeFree   uint64 `xml:"sizeFree"`
+	Device     string `xml:"device"`
+	MntOptions string `xml:"mntOptions"`
+	FsName     string `xml:"fsName"`
+}
+
+type xmlStatusVolume struct {
+	VolName   string          `xml:"volName"`
+	NodeCount int             `xml:"nodeCount"`
+	Nodes     []xmlStatusNode `xml:"node"`
+}
+
+type xmlVolStatus struct {
+	XMLName xml.Name          `xml:"volStatus"`
+	Volumes []xmlStatusVolume `xml:"volumes>volume"`
+}
+
+func newXMLStatusVolume(volname string, bricks api.BricksStatusResp) xmlStatusVolume {
+	v := xmlStatusVolume{VolName: volname, NodeCount: len(bricks)}
+	for _, b := range bricks {
+		port := "N/A"
+		if b.Online {
+			port = fmt.Sprintf("%d", b.Port)
+		}
+		v.Nodes = append(v.Nodes, xmlStatusNode{
+			Hostname:   b.Info.Hostname,
+			Path:       b.Info.Path,
+			PeerID:     b.Info.PeerID.String(),
+			Status:     xmlBool(b.Online),
+			Port:       port,
+			TCPPort:    port,
+			RDMAPort:   "N/A",
+			Pid:        b.Pid,
+			SizeTotal:  b.Size.Capacity,
+			SizeFree:   b.Size.Free,
+			Device:     b.Device,
+			MntOptions: b.MountOpts,
+			FsName:     b.FS,
+		})
+	}
+	return v
+}
+
+type xmlPeer struct {
+	UUID      string   `xml:"uuid"`
+	Hostname  string   `xml:"hostname"`
+	Hostnames []string `xml:"hostnames>hostname"`
+	Connected int      `xml:"connected"`
+}
+
+type xmlPeerStatus struct {
+	XMLName xml.Name  `xml:"peerStatus"`
+	Peers   []xmlPeer `xml:"peer"`
+}
+
+func newXMLPeerStatus(peers api.PeerListResp) *xmlPeerStatus {
+	status := &xmlPeerStatus{}
+	for _, p := range peers {
+		status.Peers = append(status.Peers, xmlPeer{
+			UUID:      p.ID.String(),
+			Hostname:  p.Name,
+			Hostnames: p.PeerAddresses,
+			Connected: xmlBool(p.Online),
+		})
+	}
+	return status
+}
+
+type xmlPeerTLSReload struct {
+	XMLName         xml.Name `xml:"peerTLSReload"`
+	RestartRequired []string `xml:"restartRequired>uuid"`
+}
+
+func newXMLPeerTLSReload(resp api.PeerTLSReloadResp) *xmlPeerTLSReload {
+	reload := &xmlPeerTLSReload{}
+	for _, id := range resp.RestartRequired {
+		reload.RestartRequired = append(reload.RestartRequired, id.String())
+	}
+	return reload
+}
+
+type xmlSnapList struct {
+	XMLName   xml.Name `xml:"snapList"`
+	Count     int      `xml:"count"`
+	Snapshots []string `xml:"snapshot"`
+}
+
+func newXMLSnapList(snaps api.SnapListResp) *xmlSnapList {
+	list := &xmlSnapList{}
+	for _, snap := range snaps {
+		for _, s := range snap.SnapList {
+			list.Snapshots = append(list.Snapshots, s.VolInfo.Name)
+		}
+	}
+	list.Count = len(list.Snapshots)
+	return list
+}
+
+type xmlSnapshot struct {
+	Name         string `xml:"name"`
+	UUID         string `xml:"uuid"`
+	Description  string `xml:"description"`
+	CreateTime   string `xml:"createTime"`
+	VolCount     int    `xml:"volCount"`
+	SnapVolName  string `xml:"snapVolume>name"`
+	SnapVolState string `xml:"snapVolume>status"`
+	OriginVolume string `xml:"snapVolume>originVolume>name"`
+}
+
+type xmlSnapInfo struct {
+	XMLName   xml.Name      `xml:"snapInfo"`
+	Count     int           `xml:"count"`
+	Snapshots []xmlSnapshot `xml:"snapshots>snapshot"`
+}
+
+func newXMLSnapInfo(snap api.SnapGetResp) *xmlSnapInfo {
+	return &xmlSnapInfo{
+		Count: 1,
+		Snapshots: []xmlSnapshot{{
+			Name:         snap.VolInfo.Name,
+			UUID:         snap.VolInfo.ID.String(),
+			Description:  snap.Description,
+			CreateTime:   snap.CreatedAt.UTC().Format("2006-01-02 15:04:05"),
+			VolCount:     1,
+			SnapVolName:  snap.VolInfo.Name,
+			SnapVolState: snap.VolInfo.State.String(),
+			OriginVolume: snap.ParentVolName,
+		}},
+	}
+}
+
+type xmlSnapBrick struct {
+	Path           string  `xml:"path"`
+	VolumeGroup    string  `xml:"volumeGroup"`
+	BrickRunning   string  `xml:"brick_running"`
+	Pid            int     `xml:"pid"`
+	Port           int     `xml:"port"`
+	Device         string  `xml:"device"`
+	DataPercentage float32 `xml:"dataPercentage"`
+	LvSize         string  `xml:"lvSize"`
+	PoolLV         string  `xml:"poolLV"`
+}
+
+type xmlSnapStatusSnapshot struct {
+	Name         string         `xml:"name"`
+	UUID         string         `xml:"uuid"`
+	OriginVolume string         `xml:"originVolume"`
+	VolCount     int            `xml:"volCount"`
+	BrickCount   int            `xml:"volume>brickCount"`
+	Bricks       []xmlSnapBrick `xml:"volume>brick"`
+}
+
+type xmlSnapStatus struct {
+	XMLName   xml.Name                `xml:"snapStatus"`
+	Snapshots []xmlSnapStatusSnapshot `xml:"snapshots>snapshot"`
+}
+
+func newXMLSnapStatus(snap api.SnapStatusResp) *xmlSnapStatus {
+	s := xmlSnapStatusSnapshot{
+		Name:         snap.SnapName,
+		UUID:         snap.ID.String(),
+		OriginVolume: snap.ParentName,
+		VolCount:     1,
+		BrickCount:   len(snap.BrickStatus),
+	}
+	for _, entry := range snap.BrickStatus {
+		running := "No"
+		if entry.Brick.Online {
+			running = "Yes"
+		}
+		s.Bricks = append(s.Bricks, xmlSnapBrick{
+			Path:           entry.Brick.Info.Hostname + ":" + entry.Brick.Info.Path,
+			VolumeGroup:    entry.LvData.VgName,
+			BrickRunning:   running,
+			Pid:            entry.Brick.Pid,
+			Port:           entry.Brick.Port,
+			Device:         entry.Brick.Device,
+			DataPercentage: entry.LvData.DataPercentage,
+			LvSize:         entry.LvData.LvSize,
+			PoolLV:         entry.LvData.PoolLV,
+		})
+	}
+	return &xmlSnapStatus{Snapshots: []xmlSnapStatusSnapshot{s}}
+}
+
+type xmlGeorepPair struct {
+	MasterNode               string `xml:"master_node"`
+	MasterBrick              string `xml:"master_brick"`
+	MasterNodeUUID           string `xml:"master_node_uuid"`
+	SlaveUser                string `xml:"slave_user"`
+	Slave                    string `xml:"slave"`
+	SlaveNode                string `xml:"slave_node"`
+	Status                   string `xml:"status"`
+	CrawlStatus              string `xml:"crawl_status"`
+	Entry                    string `xml:"entry"`
+	Data                     string `xml:"data"`
+	Meta                     string `xml:"meta"`
+	Failures                 string `xml:"failures"`
+	LastSynced               string `xml:"last_synced"`
+	CheckpointCompleted      string `xml:"checkpoint_completed"`
+	CheckpointTime           string `xml:"checkpoint_time"`
+	CheckpointCompletionTime string `xml:"checkpoint_completion_time"`
+}
+
+type xmlGeorepSession struct {
+	SessionSlave string          `xml:"session_slave"`
+	Pairs        []xmlGeorepPair `xml:"pair"`
+}
+
+type xmlGeorepVolume struct {
+	Name     string             `xml:"name"`
+	Sessions []xmlGeorepSession `xml:"sessions>session"`
+}
+
+type xmlGeoRep struct {
+	XMLName xml.Name          `xml:"geoRep"`
+	Volumes []xmlGeorepVolume `xml:"volume"`
+}
+
+func newXMLGeoRep(sessions georepapi.GeorepSessionList) *xmlGeoRep {
+	georep := &xmlGeoRep{}
+	volIndex := make(map[string]int)
+	for _, s := range sessions {
+		var remoteHost string
+		if len(s.RemoteHosts) > 0 {
+			remoteHost = s.RemoteHosts[0].Hostname
+		}
+		slave := fmt.Sprintf("%s::%s", remoteHost, s.RemoteVol)
+		session := xmlGeorepSession{
+			SessionSlave: fmt.Sprintf("%s:ssh://%s:%s", s.MasterID, slave, s.RemoteID),
+		}
+		for _, w := range s.Workers {
+			session.Pairs = append(session.Pairs, xmlGeorepPair{
+				MasterNode:               w.MasterPeerHostname,
+				MasterBrick:              w.MasterBrickPath,
+				MasterNodeUUID:           w.MasterPeerID,
+				SlaveUser:                s.RemoteUser,
+				Slave:                    fmt.Sprintf("%s@%s", s.RemoteUser, slave),
+				SlaveNode:                w.RemotePeerHostname,
+				Status:                   w.Status,
+				CrawlStatus:              w.CrawlStatus,
+				Entry:                    w.EntryOps,
+				Data:                     w.DataOps,
+				Meta:                     w.MetaOps,
+				Failures:                 w.FailedOps,
+				LastSynced:               w.LastSyncedTime,
+				CheckpointCompleted:      w.CheckpointCompleted,
+				CheckpointTime:           w.CheckpointTime,
+				CheckpointCompletionTime: w.CheckpointCompletedTime,
+			})
+		}
+
+		idx, ok := volIndex[s.MasterVol]
+		if !ok {
+			idx = len(georep.Volumes)
+			volIndex[s.MasterVol] = idx
+			georep.Volumes = append(georep.Volumes, xmlGeorepVolume{Name: s.MasterVol})
+		}
+		georep.Volumes[idx].Sessions = append(georep.Volumes[idx].Sessions, session)
+	}
+	return georep
+}
+
+type xmlScrubNode struct {
+	NodeName          string   `xml:"node_name"`
+	ScrubRunning      string   `xml:"scrub_running"`
+	ScrubbedFiles     string   `xml:"scrubbed_files"`
+	UnsignedFiles     string   `xml:"unsigned_files"`
+	LastScrubTime     string   `xml:"last_scrub_time"`
+	LastScrubDuration string   `xml:"last_scrub_duration"`
+	ErrorCount        string   `xml:"error_count"`
+	BadFiles          []string `xml:"bad_file>gfid"`
+}
+
+type xmlVolScrub struct {
+	XMLName      xml.Name       `xml:"volScrub"`
+	VolName      string         `xml:"volName"`
+	State        string         `xml:"state"`
+	Throttle     string         `xml:"throttle"`
+	Frequency    string         `xml:"frequency"`
+	BitdLogFile  string         `xml:"bitd_log_file"`
+	ScrubLogFile string         `xml:"scrub_log_file"`
+	Nodes        []xmlScrubNode `xml:"node"`
+}
+
+func newXMLVolScrub(status bitrotapi.ScrubStatus) *xmlVolScrub {
+	scrub := &xmlVolScrub{
+		VolName:      status.Volume,
+		State:        status.State,
+		Throttle:     status.Throttle,
+		Frequency:    status.Frequency,
+		BitdLogFile:  status.BitdLogFile,
+		ScrubLogFile: status.ScrubLogFile,
+	}
+	for _, n := range status.Nodes {
+		scrub.Nodes = append(scrub.Nodes, xmlScrubNode{
+			NodeName:          n.Node,
+			ScrubRunning:      n.ScrubRunning,
+			ScrubbedFiles:     n.NumScrubbedFiles,
+			UnsignedFiles:     n.NumSkippedFiles,
+			LastScrubTime:     n.LastScrubCompletedTime,
+			LastScrubDuration: n.LastScrubDuration,
+			ErrorCount:        n.ErrorCount,
+			BadFiles:          n.CorruptedObjects,
+		})
+	}
+	return scrub
+}
+
+type xmlHealBrick struct {
+	HostUUID               string                   `xml:"hostUuid,attr"`
+	Name                   string                   `xml:"name"`
+	Status                 string                   `xml:"status"`
+	TotalEntries           *int64                   `xml:"totalNumberOfEntries,omitempty"`
+	EntriesInHealPending   *int64                   `xml:"numberOfEntriesInHealPending,omitempty"`
+	EntriesInSplitBrain    *int64                   `xml:"numberOfEntriesInSplitBrain,omitempty"`
+	EntriesPossiblyHealing *int64                   `xml:"numberOfEntriesPossiblyHealing,omitempty"`
+	Entries                *int64                   `xml:"numberOfEntries,omitempty"`
+	Files                  []glustershdapi.FileGfID `xml:"file"`
+}
+
+type xmlHealInfo struct {
+	XMLName xml.Name       `xml:"healInfo"`
+	Bricks  []xmlHealBrick `xml:"bricks>brick"`
+}
+
+func newXMLHealInfo(bricks []glustershdapi.BrickHealInfo) *xmlHealInfo {
+	info := &xmlHealInfo{}
+	for _, b := range bricks {
+		info.Bricks = append(info.Bricks, xmlHealBrick{
+			HostUUID:               b.HostID,
+			Name:                   b.Name,
+			Status:                 b.Status,
+			TotalEntries:           b.TotalEntries,
+			EntriesInHealPending:   b.EntriesInHealPending,
+			EntriesInSplitBrain:    b.EntriesInSplitBrain,
+			EntriesPossiblyHealing: b.EntriesPossiblyHealing,
+			Entries:                b.Entries,
+			Files:                  b.Files,
+		})
+	}
+	return info
+}
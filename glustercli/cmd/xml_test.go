@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/pborman/uuid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrintXML checks the cliOutput document written for the volume info
+// output and for a failed command
+func TestPrintXML(t *testing.T) {
+	var buf bytes.Buffer
+	xmlWriter = &buf
+	defer func() {
+		xmlWriter = os.Stdout
+		xmlWritten = false
+	}()
+
+	peerID := uuid.NewRandom()
+	vols := api.VolumeListResp{
+		{
+			ID:           uuid.NewRandom(),
+			Name:         "vol1",
+			Type:         api.Replicate,
+			Transport:    "tcp",
+			ReplicaCount: 2,
+			State:        api.VolStarted,
+			Options:      map[string]string{"b.opt": "off", "a.opt": "on"},
+			Subvols: []api.Subvol{{
+				Bricks: []api.BrickInfo{
+					{PeerID: peerID, Hostname: "host1", Path: "/b1"},
+					{PeerID: peerID, Hostname: "host1", Path: "/b2"},
+				},
+			}},
+		},
+	}
+	printXML(newXMLVolInfo(vols))
+	assert.True(t, xmlWritten)
+
+	var out struct {
+		OpRet   int `xml:"opRet"`
+		Volumes []struct {
+			Name       string   `xml:"name"`
+			Type       int      `xml:"type"`
+			StatusStr  string   `xml:"statusStr"`
+			BrickCount int      `xml:"brickCount"`
+			Bricks     []string `xml:"bricks>brick>name"`
+			OptNames   []string `xml:"options>option>name"`
+		} `xml:"volInfo>volumes>volume"`
+		Count int `xml:"volInfo>volumes>count"`
+	}
+	require.Nil(t, xml.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, 0, out.OpRet)
+	assert.Equal(t, 1, out.Count)
+	require.Len(t, out.Volumes, 1)
+	assert.Equal(t, "vol1", out.Volumes[0].Name)
+	assert.Equal(t, 2, out.Volumes[0].Type)
+	assert.Equal(t, "Started", out.Volumes[0].StatusStr)
+	assert.Equal(t, 2, out.Volumes[0].BrickCount)
+	assert.Equal(t, []string{"host1:/b1", "host1:/b2"}, out.Volumes[0].Bricks)
+	assert.Equal(t, []string{"a.opt", "b.opt"}, out.Volumes[0].OptNames)
+
+	buf.Reset()
+	printXMLFailure("Error getting volumes list", errors.New("volume not found"), 1)
+
+	var failed struct {
+		OpRet    int    `xml:"opRet"`
+		OpErrno  int    `xml:"opErrno"`
+		OpErrstr string `xml:"opErrstr"`
+	}
+	require.Nil(t, xml.Unmarshal(buf.Bytes(), &failed))
+	assert.Equal(t, -1, failed.OpRet)
+	assert.Equal(t, 1, failed.OpErrno)
+	assert.Equal(t, "Error getting volumes list: volume not found", failed.OpErrstr)
+}
+
+// TestXMLSupported checks that commands whose text output carries data fail
+// with --xml unless they write it as XML
+func TestXMLSupported(t *testing.T) {
+	assert.True(t, xmlSupported("glustercli volume info", nil))
+	assert.True(t, xmlSupported("glustercli volume status", []string{"vol1"}))
+	assert.True(t, xmlSupported("glustercli volume start", []string{"vol1"}))
+	assert.False(t, xmlSupported("glustercli volume status", []string{"vol1", "detail"}))
+	assert.False(t, xmlSupported("glustercli volume top", []string{"vol1", "open"}))
+	assert.False(t, xmlSupported("glustercli volume quota list", []string{"vol1"}))
+	assert.False(t, xmlSupported("glustercli user create", []string{"user1"}))
+	assert.False(t, xmlSupported("glustercli job list", nil))
+
+	// Every command listed must exist
+	paths := make(map[string]bool)
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		paths[cmd.CommandPath()] = true
+		for _, c := range cmd.Commands() {
+			walk(c)
+		}
+	}
+	walk(NewGlustercliCmd())
+	for path := range xmlCommands {
+		assert.True(t, paths[path], path)
+	}
+}
@@ -0,0 +1,177 @@
+// Package audit records the REST requests which modify the cluster to a
+// rotating local file, so that it is known who changed what.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	log "github.com/sirupsen/logrus"
+	flag "github.com/spf13/pflag"
+	config "github.com/spf13/viper"
+)
+
+const (
+	fileOpt       = "audit-logfile"
+	maxSizeOpt    = "audit-log-max-size"
+	maxBackupsOpt = "audit-log-max-backups"
+
+	defaultFile       = "audit.log"
+	defaultMaxSize    = 100
+	defaultMaxBackups = 5
+)
+
+var (
+	auditLog *rotatingFile
+	lock     sync.RWMutex
+)
+
+// Filter selects the audit entries to be returned by Query. Zero values match
+// all entries. Offset matching entries are skipped, and at most Limit entries
+// are returned when Limit is set.
+type Filter struct {
+	User   string
+	Since  time.Time
+	Until  time.Time
+	Offset int
+	Limit  int
+}
+
+func (f *Filter) match(e *api.AuditEntry) bool {
+	if f.User != "" && e.User != f.User {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// InitFlags sets up the audit log flags
+func InitFlags() {
+	flag.String(fileOpt, defaultFile, "Name of the audit log file in the log directory.")
+	flag.Int(maxSizeOpt, defaultMaxSize, "Size in MB after which the audit log is rotated.")
+	flag.Int(maxBackupsOpt, defaultMaxBackups, "Number of rotated audit logs to keep.")
+}
+
+// Init opens the audit log
+func Init() error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if auditLog != nil {
+		auditLog.Close()
+	}
+
+	maxSize := int64(config.GetInt(maxSizeOpt)) * 1024 * 1024
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize * 1024 * 1024
+	}
+	maxBackups := config.GetInt(maxBackupsOpt)
+	if maxBackups < 0 {
+		maxBackups = 0
+	}
+
+	filepath := path.Join(config.GetString("logdir"), config.GetString(fileOpt))
+	rf, err := openRotatingFile(filepath, maxSize, maxBackups)
+	if err != nil {
+		return err
+	}
+	auditLog = rf
+	return nil
+}
+
+// Close closes the audit log
+func Close() {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if auditLog != nil {
+		auditLog.Close()
+		auditLog = nil
+	}
+}
+
+// Record appends the entry to the audit log
+func Record(e *api.AuditEntry) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	if auditLog == nil {
+		return
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		log.WithError(err).Error("failed to marshal audit entry")
+		return
+	}
+
+	if _, err := auditLog.Write(append(data, '\n')); err != nil {
+		log.WithError(err).WithField("reqid", e.RequestID).Error("failed to write audit entry")
+	}
+}
+
+// Query returns the entries of the audit log, including rotated ones, which
+// match the filter, oldest first. The log is read only up to the last entry
+// returned.
+func Query(f Filter) ([]api.AuditEntry, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	entries := []api.AuditEntry{}
+	if auditLog == nil {
+		return entries, nil
+	}
+
+	skip := f.Offset
+	for _, filepath := range auditLog.files() {
+		if f.Limit > 0 && len(entries) >= f.Limit {
+			break
+		}
+
+		file, err := os.Open(filepath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Rotated away since listing
+				continue
+			}
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var e api.AuditEntry
+			if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+				continue
+			}
+			if !f.match(&e) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			entries = append(entries, e)
+			if f.Limit > 0 && len(entries) >= f.Limit {
+				break
+			}
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	config "github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndQuery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	config.Set("logdir", dir)
+	config.Set(fileOpt, defaultFile)
+	config.Set(maxSizeOpt, defaultMaxSize)
+	config.Set(maxBackupsOpt, defaultMaxBackups)
+	assert.Nil(t, Init())
+	defer Close()
+
+	now := time.Now()
+	Record(&api.AuditEntry{Time: now.Add(-time.Hour), User: "admin", Route: "VolumeCreate"})
+	Record(&api.AuditEntry{Time: now, User: "monitoring", Route: "VolumeStart"})
+
+	entries, err := Query(Filter{})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+
+	entries, err = Query(Filter{User: "admin"})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "VolumeCreate", entries[0].Route)
+
+	entries, err = Query(Filter{Since: now.Add(-time.Minute)})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "VolumeStart", entries[0].Route)
+
+	entries, err = Query(Filter{Until: now.Add(-time.Minute)})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestQueryPagination(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	config.Set("logdir", dir)
+	config.Set(fileOpt, defaultFile)
+	config.Set(maxSizeOpt, defaultMaxSize)
+	config.Set(maxBackupsOpt, defaultMaxBackups)
+	assert.Nil(t, Init())
+	defer Close()
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		user := "admin"
+		if i%2 == 1 {
+			user = "monitoring"
+		}
+		Record(&api.AuditEntry{Time: now.Add(time.Duration(i) * time.Second), User: user, RequestID: strconv.Itoa(i)})
+	}
+
+	requestIDs := func(entries []api.AuditEntry) []string {
+		var ids []string
+		for _, e := range entries {
+			ids = append(ids, e.RequestID)
+		}
+		return ids
+	}
+
+	entries, err := Query(Filter{Limit: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0", "1", "2"}, requestIDs(entries))
+
+	entries, err = Query(Filter{Offset: 3, Limit: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3", "4", "5"}, requestIDs(entries))
+
+	entries, err = Query(Filter{Offset: 9, Limit: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"9"}, requestIDs(entries))
+
+	entries, err = Query(Filter{Offset: 10, Limit: 3})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 0)
+
+	// The offset counts the entries matching the filter
+	entries, err = Query(Filter{User: "monitoring", Offset: 1, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"3", "5"}, requestIDs(entries))
+}
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	rf, err := openRotatingFile(dir+"/audit.log", 10, 2)
+	assert.Nil(t, err)
+	defer rf.Close()
+
+	for i := 0; i < 4; i++ {
+		_, err := rf.Write([]byte("0123456789"))
+		assert.Nil(t, err)
+	}
+
+	// Only the current file and two backups are kept
+	files := rf.files()
+	assert.Equal(t, []string{dir + "/audit.log.2", dir + "/audit.log.1", dir + "/audit.log"}, files)
+	_, err = os.Stat(dir + "/audit.log.3")
+	assert.True(t, os.IsNotExist(err))
+}
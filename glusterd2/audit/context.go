@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+)
+
+type ctxKeyType int
+
+const objectsKey ctxKeyType = iota
+
+// objects are the objects affected by a request. The handlers of the routes
+// which do not name the objects in the URL, like the routes creating them,
+// set them from the request or the response.
+type objects struct {
+	volume   string
+	peer     string
+	snapshot string
+}
+
+// NewContext returns a context in which the handler of the request can set
+// the objects affected by the request
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, objectsKey, &objects{})
+}
+
+func fromContext(ctx context.Context) *objects {
+	o, _ := ctx.Value(objectsKey).(*objects)
+	return o
+}
+
+// SetVolume records the volume affected by the request being handled with ctx
+func SetVolume(ctx context.Context, volname string) {
+	if o := fromContext(ctx); o != nil {
+		o.volume = volname
+	}
+}
+
+// SetPeer records the peer affected by the request being handled with ctx
+func SetPeer(ctx context.Context, peerID string) {
+	if o := fromContext(ctx); o != nil {
+		o.peer = peerID
+	}
+}
+
+// SetSnapshot records the snapshot affected by the request being handled with
+// ctx
+func SetSnapshot(ctx context.Context, snapname string) {
+	if o := fromContext(ctx); o != nil {
+		o.snapshot = snapname
+	}
+}
+
+// Objects returns the volume, peer and snapshot set by the handler of the
+// request as affected by it
+func Objects(ctx context.Context) (volume, peer, snapshot string) {
+	if o := fromContext(ctx); o != nil {
+		return o.volume, o.peer, o.snapshot
+	}
+	return "", "", ""
+}
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// rotatingFile is an append only file which is rotated once it grows beyond
+// maxSize bytes. Up to maxBackups rotated files are kept, with the oldest
+// having the highest numeric suffix.
+type rotatingFile struct {
+	sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	rf := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	f, err := os.OpenFile(rf.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	rf.f = f
+	rf.size = info.Size()
+	return nil
+}
+
+func (rf *rotatingFile) backupName(idx int) string {
+	return fmt.Sprintf("%s.%d", rf.path, idx)
+}
+
+// rotate closes the current file, shifts the backups by one, dropping the
+// oldest, and opens a new file
+func (rf *rotatingFile) rotate() error {
+	if err := rf.f.Close(); err != nil {
+		return err
+	}
+
+	os.Remove(rf.backupName(rf.maxBackups))
+	for idx := rf.maxBackups - 1; idx > 0; idx-- {
+		os.Rename(rf.backupName(idx), rf.backupName(idx+1))
+	}
+	if rf.maxBackups > 0 {
+		if err := os.Rename(rf.path, rf.backupName(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(rf.path); err != nil {
+		return err
+	}
+
+	return rf.open()
+}
+
+// Write implements io.Writer
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.Lock()
+	defer rf.Unlock()
+
+	if rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.f.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Close implements io.Closer
+func (rf *rotatingFile) Close() error {
+	rf.Lock()
+	defer rf.Unlock()
+	return rf.f.Close()
+}
+
+// files returns the paths of the existing files, oldest first
+func (rf *rotatingFile) files() []string {
+	var files []string
+	for idx := rf.maxBackups; idx > 0; idx-- {
+		if _, err := os.Stat(rf.backupName(idx)); err == nil {
+			files = append(files, rf.backupName(idx))
+		}
+	}
+	return append(files, rf.path)
+}
@@ -0,0 +1,327 @@
+// Package backup implements the backup of the cluster store and volfiles into
+// an archive, and the restore of a node from such an archive
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/store"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/version"
+
+	"github.com/pborman/uuid"
+	log "github.com/sirupsen/logrus"
+	flag "github.com/spf13/pflag"
+	config "github.com/spf13/viper"
+)
+
+const (
+	restoreOpt = "restore"
+
+	// Names of the entries in the archive
+	infoFile     = "backup.json"
+	snapshotFile = "etcd.db"
+	volfilesDir  = "volfiles"
+)
+
+var (
+	// ErrInvalidArchive is returned when restoring from an archive which
+	// is not a glusterd2 backup
+	ErrInvalidArchive = errors.New("invalid backup archive")
+)
+
+// Info describes the contents of a backup archive
+type Info struct {
+	ClusterID string    `json:"cluster-id"`
+	PeerID    string    `json:"peer-id"`
+	Version   string    `json:"version"`
+	CreatedAt time.Time `json:"created-at"`
+}
+
+// InitFlags sets up the restore flag
+func InitFlags() {
+	flag.String(restoreOpt, "", "Restore the store and volfiles from the given backup archive before starting.")
+}
+
+// RestoreArchive returns the path of the archive given with --restore
+func RestoreArchive() string {
+	return config.GetString(restoreOpt)
+}
+
+func volfilesPath() string {
+	return path.Join(config.GetString("localstatedir"), volfilesDir)
+}
+
+// Write writes a gzipped tar archive holding a snapshot of the store and the
+// volfiles of this node to w
+func Write(ctx context.Context, w io.Writer) error {
+	// The size of a file has to be known before adding it to the archive,
+	// so the snapshot is saved to a temporary file first
+	snapPath, err := saveSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(snapPath)
+
+	info, err := json.MarshalIndent(Info{
+		ClusterID: gdctx.MyClusterID.String(),
+		PeerID:    gdctx.MyUUID.String(),
+		Version:   version.GlusterdVersion,
+		CreatedAt: time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	if err := addData(tw, infoFile, info); err != nil {
+		return err
+	}
+
+	if err := addFile(tw, snapshotFile, snapPath); err != nil {
+		return err
+	}
+
+	volfiles, err := ioutil.ReadDir(volfilesPath())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, f := range volfiles {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		name := path.Join(volfilesDir, f.Name())
+		if err := addFile(tw, name, path.Join(volfilesPath(), f.Name())); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func saveSnapshot(ctx context.Context) (string, error) {
+	rc, err := store.Snapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	f, err := ioutil.TempFile("", "gd2-snapshot-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, rc); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func addData(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func addFile(tw *tar.Writer, name, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// extract extracts the regular files in the archive into dir
+func extract(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return ErrInvalidArchive
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || strings.HasPrefix(name, "..") {
+			return ErrInvalidArchive
+		}
+
+		target := path.Join(dir, name)
+		if err := os.MkdirAll(path.Dir(target), 0755); err != nil {
+			return err
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, tr)
+		out.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func readInfo(dir string) (*Info, error) {
+	b, err := ioutil.ReadFile(path.Join(dir, infoFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrInvalidArchive
+		}
+		return nil, err
+	}
+
+	var info Info
+	if err := json.Unmarshal(b, &info); err != nil {
+		return nil, ErrInvalidArchive
+	}
+	if uuid.Parse(info.ClusterID) == nil {
+		return nil, ErrInvalidArchive
+	}
+	return &info, nil
+}
+
+// Restore reseeds the embedded store of this node from the backup archive
+// and restores the volfiles saved in it. The node takes the cluster ID of
+// the backup. It must be called before the store is initialized.
+func Restore(archive string) error {
+	dir, err := ioutil.TempDir(config.GetString("localstatedir"), "restore-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := extract(archive, dir); err != nil {
+		return err
+	}
+
+	info, err := readInfo(dir)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"archive":    archive,
+		"cluster-id": info.ClusterID,
+		"peer-id":    info.PeerID,
+		"created-at": info.CreatedAt,
+	}).Info("restoring from backup")
+
+	// The keys in the store are namespaced with the cluster ID
+	if err := gdctx.UpdateClusterID(info.ClusterID); err != nil {
+		return err
+	}
+
+	if err := store.Restore(path.Join(dir, snapshotFile)); err != nil {
+		return err
+	}
+
+	return restoreVolfiles(path.Join(dir, volfilesDir))
+}
+
+func restoreVolfiles(dir string) error {
+	volfiles, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := os.MkdirAll(volfilesPath(), 0755); err != nil {
+		return err
+	}
+
+	for _, f := range volfiles {
+		b, err := ioutil.ReadFile(path.Join(dir, f.Name()))
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path.Join(volfilesPath(), f.Name()), b, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReconcileVolfiles regenerates the volfiles of the local bricks of the
+// started volumes from the restored store. The archive could have been
+// created on a different node, and it does not have the brick volfiles of
+// this node then.
+func ReconcileVolfiles() error {
+	volumes, err := volume.GetVolumes(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	for _, v := range volumes {
+		if v.State != volume.VolStarted {
+			continue
+		}
+		if err := volgen.GenerateBricksVolfiles(v, v.GetLocalBricks()); err != nil {
+			log.WithError(err).WithField("volume", v.Name).Error("failed to generate brick volfiles")
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/pborman/uuid"
+	config "github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeArchive(t *testing.T, filename string, entries map[string][]byte) {
+	f, err := os.Create(filename)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for name, data := range entries {
+		assert.Nil(t, addData(tw, name, data))
+	}
+	assert.Nil(t, tw.Close())
+	assert.Nil(t, gw.Close())
+}
+
+func TestExtractAndRestoreVolfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	config.Set("localstatedir", path.Join(dir, "localstate"))
+
+	clusterID := uuid.NewRandom().String()
+	info, err := json.Marshal(Info{ClusterID: clusterID})
+	assert.Nil(t, err)
+
+	archive := path.Join(dir, "backup.tar.gz")
+	writeArchive(t, archive, map[string][]byte{
+		infoFile:                        info,
+		snapshotFile:                    []byte("snapshot"),
+		path.Join(volfilesDir, "v.vol"): []byte("volume v-client-0\nend-volume\n"),
+	})
+
+	out := path.Join(dir, "out")
+	assert.Nil(t, extract(archive, out))
+
+	got, err := readInfo(out)
+	assert.Nil(t, err)
+	assert.Equal(t, clusterID, got.ClusterID)
+
+	assert.Nil(t, restoreVolfiles(path.Join(out, volfilesDir)))
+	b, err := ioutil.ReadFile(path.Join(volfilesPath(), "v.vol"))
+	assert.Nil(t, err)
+	assert.Equal(t, "volume v-client-0\nend-volume\n", string(b))
+}
+
+func TestExtractInvalidArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// Entries outside the extraction dir are rejected
+	archive := path.Join(dir, "evil.tar.gz")
+	writeArchive(t, archive, map[string][]byte{
+		"../evil": []byte("evil"),
+	})
+	assert.Equal(t, ErrInvalidArchive, extract(archive, path.Join(dir, "out")))
+	_, err = os.Stat(path.Join(dir, "evil"))
+	assert.True(t, os.IsNotExist(err))
+
+	// An archive without backup info is not a backup
+	archive = path.Join(dir, "noinfo.tar.gz")
+	writeArchive(t, archive, map[string][]byte{
+		snapshotFile: []byte("snapshot"),
+	})
+	out := path.Join(dir, "noinfo")
+	assert.Nil(t, extract(archive, out))
+	_, err = readInfo(out)
+	assert.Equal(t, ErrInvalidArchive, err)
+
+	// Not a gzipped archive at all
+	notgz := path.Join(dir, "plain")
+	assert.Nil(t, ioutil.WriteFile(notgz, []byte("plain"), 0644))
+	assert.Equal(t, ErrInvalidArchive, extract(notgz, path.Join(dir, "plain-out")))
+}
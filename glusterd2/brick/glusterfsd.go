@@ -22,6 +22,9 @@ import (
 
 const (
 	glusterfsdBin = "glusterfsd"
+
+	// DaemonName is the name of the brick processes
+	DaemonName = "glusterfsd"
 )
 
 func brickPathWithoutSlashes(brickPath string) string {
@@ -30,7 +33,13 @@ func brickPathWithoutSlashes(brickPath string) string {
 
 // GetVolfileID returns Volfile ID of glusterfsd process
 func GetVolfileID(volname string, brickPath string) string {
-	return volname + "." + gdctx.MyUUID.String() + "." + brickPathWithoutSlashes(brickPath)
+	return GetPeerVolfileID(volname, gdctx.MyUUID.String(), brickPath)
+}
+
+// GetPeerVolfileID returns Volfile ID of the glusterfsd process of a brick
+// on the given peer
+func GetPeerVolfileID(volname string, peerID string, brickPath string) string {
+	return volname + "." + peerID + "." + brickPathWithoutSlashes(brickPath)
 }
 
 // Glusterfsd type represents information about the brick daemon
@@ -47,7 +56,7 @@ type Glusterfsd struct {
 
 // Name returns human-friendly name of the brick process. This is used for logging.
 func (b *Glusterfsd) Name() string {
-	return "glusterfsd"
+	return DaemonName
 }
 
 // Path returns absolute path to the binary of brick process
@@ -211,6 +220,15 @@ func (b Brickinfo) TerminateBrick() error {
 		return err
 	}
 
+	// The brick is removed from the store before it is terminated, so that
+	// the daemon supervisor does not restart it
+	if err := daemon.DelDaemon(brickDaemon); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"name": brickDaemon.Name(),
+			"id":   brickDaemon.ID(),
+		}).Warn("failed to delete brick entry from store, it may be restarted")
+	}
+
 	req := &GfBrickOpReq{
 		Name: b.Path,
 		Op:   int(OpBrickTerminate),
@@ -229,12 +247,6 @@ func (b Brickinfo) TerminateBrick() error {
 		return errors.New("RPC request failed")
 	}
 
-	if err := daemon.DelDaemon(brickDaemon); err != nil {
-		log.WithError(err).WithFields(log.Fields{
-			"name": brickDaemon.Name(),
-			"id":   brickDaemon.ID(),
-		}).Warn("failed to delete brick entry from store, it may be restarted on GlusterD restart")
-	}
 	return nil
 }
 
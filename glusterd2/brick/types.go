@@ -136,6 +136,9 @@ func (b *Brickinfo) Validate(check InitChecks, allLocalBricks []Brickinfo) error
 
 	if _, err = os.Stat(b.Path); os.IsNotExist(err) {
 		if check.CreateBrickDir {
+			if check.DryRun {
+				return isBrickInActiveUse(b.Path, allLocalBricks)
+			}
 			if err = os.MkdirAll(b.Path, 0775); err != nil {
 				return err
 			}
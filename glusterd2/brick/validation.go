@@ -27,6 +27,10 @@ type InitChecks struct {
 	IsMount        bool
 	IsOnRoot       bool
 	CreateBrickDir bool
+	// DryRun validates the brick without creating the brick directory.
+	// The checks which need the directory are skipped if it would have
+	// been created.
+	DryRun bool
 }
 
 // PrepareChecks initializes InitChecks based on req
@@ -81,3 +81,21 @@ func Reconcile() error {
 
 	return nil
 }
+
+// Restart restarts a brick process which has exited. When brick multiplexing
+// is enabled, the bricks which were multiplexed into the brick process are
+// multiplexed again, or started in a process of their own, as done by
+// Reconcile.
+func Restart(d daemon.Daemon, logger log.FieldLogger) error {
+	bmuxEnabled, err := Enabled()
+	if err != nil {
+		return err
+	}
+
+	if !bmuxEnabled {
+		return daemon.Start(d, true, logger)
+	}
+
+	logger.WithField("name", d.Name()).Info("reconciling multiplexed bricks")
+	return Reconcile()
+}
@@ -37,6 +37,18 @@ type Vg struct {
 	Used          bool
 }
 
+// deviceUsable tells if bricks of the request can be provisioned on the device.
+// Disabled devices, like the devices being evacuated, are not used.
+func deviceUsable(d deviceapi.Info, req *api.VolCreateReq) bool {
+	// If Device is not enabled to be used for provisioning
+	if d.State == deviceapi.DeviceDisabled {
+		return false
+	}
+
+	// If Provisioner type does not match the requested provisioner type
+	return d.ProvisionerType == req.ProvisionerType
+}
+
 // GetAvailableVgs returns VG list that can be used to create bricks
 func GetAvailableVgs(req *api.VolCreateReq) ([]Vg, error) {
 	var vgs []Vg
@@ -87,13 +99,7 @@ func GetAvailableVgs(req *api.VolCreateReq) ([]Vg, error) {
 		}
 
 		for _, d := range deviceInfo {
-			// If Device is not enabled to be used for provisioning
-			if d.State == deviceapi.DeviceDisabled {
-				continue
-			}
-
-			// If Provisioner type does not match the requested provisioner type
-			if d.ProvisionerType != req.ProvisionerType {
+			if !deviceUsable(d, req) {
 				continue
 			}
 
@@ -0,0 +1,55 @@
+package bricksplanner
+
+import (
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	deviceapi "github.com/gluster/glusterd2/plugins/device/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDeviceUsable checks that bricks are not provisioned on disabled
+// devices, like the devices being evacuated
+func TestDeviceUsable(t *testing.T) {
+	req := &api.VolCreateReq{ProvisionerType: api.ProvisionerTypeLvm}
+
+	d := deviceapi.Info{State: deviceapi.DeviceEnabled, ProvisionerType: api.ProvisionerTypeLvm}
+	assert.True(t, deviceUsable(d, req))
+
+	d.State = deviceapi.DeviceDisabled
+	assert.False(t, deviceUsable(d, req))
+
+	d = deviceapi.Info{State: deviceapi.DeviceEnabled, ProvisionerType: api.ProvisionerTypeLoop}
+	assert.False(t, deviceUsable(d, req))
+}
+
+// TestGetNewBrick checks that the replacement brick is planned on the first
+// volume group with enough free space
+func TestGetNewBrick(t *testing.T) {
+	vol := &volume.Volinfo{Name: "vol", SnapshotReserveFactor: 1}
+	brickInfo := brick.Brickstatus{
+		Info: brick.Brickinfo{Path: "/bricks/vol/s0/b1"},
+		Size: brick.SizeInfo{Capacity: 10240},
+	}
+	vgs := []Vg{
+		{Name: "vg_small", Device: "/dev/sdb", PeerID: "peer1", AvailableSize: 5120},
+		{Name: "vg_large", Device: "/dev/sdc", PeerID: "peer2", AvailableSize: 20480},
+		{Name: "vg_larger", Device: "/dev/sdd", PeerID: "peer3", AvailableSize: 40960},
+	}
+
+	b := GetNewBrick(vgs, brickInfo, vol, 0, 1)
+	assert.Equal(t, "peer2", b.PeerID)
+	assert.Equal(t, "vg_large", b.VgName)
+	assert.Equal(t, "/dev/sdc", b.RootDevice)
+	assert.Equal(t, "/bricks/vol/s0/b1", b.Path)
+	assert.Equal(t, "brick_vol_s0_b1", b.LvName)
+	assert.Equal(t, "/dev/vg_large/brick_vol_s0_b1", b.DevicePath)
+	assert.Equal(t, uint64(10240), b.Size)
+
+	// No brick is planned when no volume group has enough free space
+	b = GetNewBrick(vgs[:1], brickInfo, vol, 0, 1)
+	assert.Equal(t, "", b.PeerID)
+}
@@ -0,0 +1,84 @@
+package auditcommands
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/audit"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+const (
+	// defaultAuditLimit is the number of entries returned when the request
+	// does not set a limit
+	defaultAuditLimit = 100
+	// maxAuditLimit is the maximum number of entries returned for a request
+	maxAuditLimit = 1000
+)
+
+// parseAuditFilter returns the filter set by the user, since, until, offset
+// and limit query parameters of the request
+func parseAuditFilter(query url.Values) (audit.Filter, error) {
+	filter := audit.Filter{
+		User:  query.Get("user"),
+		Limit: defaultAuditLimit,
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			return filter, fmt.Errorf("limit must be between 1 and %d", maxAuditLimit)
+		}
+		filter.Limit = limit
+	}
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return filter, fmt.Errorf("invalid offset: %s", value)
+		}
+		filter.Offset = offset
+	}
+
+	for _, p := range []struct {
+		name string
+		t    *time.Time
+	}{
+		{"since", &filter.Since},
+		{"until", &filter.Until},
+	} {
+		value := query.Get(p.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s time, expected RFC3339 format: %s", p.name, value)
+		}
+		*p.t = t
+	}
+
+	return filter, nil
+}
+
+func auditListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := parseAuditFilter(r.URL.Query())
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	entries, err := audit.Query(filter)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, api.AuditListResp(entries))
+}
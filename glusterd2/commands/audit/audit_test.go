@@ -0,0 +1,45 @@
+package auditcommands
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAuditFilter(t *testing.T) {
+	f, err := parseAuditFilter(url.Values{})
+	assert.Nil(t, err)
+	assert.Empty(t, f.User)
+	assert.True(t, f.Since.IsZero())
+	assert.True(t, f.Until.IsZero())
+	assert.Equal(t, 0, f.Offset)
+	assert.Equal(t, defaultAuditLimit, f.Limit)
+
+	f, err = parseAuditFilter(url.Values{
+		"user":   {"admin"},
+		"since":  {"2018-10-01T00:00:00Z"},
+		"offset": {"200"},
+		"limit":  {"50"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "admin", f.User)
+	assert.Equal(t, time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC), f.Since)
+	assert.True(t, f.Until.IsZero())
+	assert.Equal(t, 200, f.Offset)
+	assert.Equal(t, 50, f.Limit)
+
+	_, err = parseAuditFilter(url.Values{"until": {"yesterday"}})
+	assert.NotNil(t, err)
+
+	for _, query := range []url.Values{
+		{"limit": {"0"}},
+		{"limit": {"1001"}},
+		{"limit": {"all"}},
+		{"offset": {"-1"}},
+	} {
+		_, err = parseAuditFilter(query)
+		assert.NotNil(t, err, query.Encode())
+	}
+}
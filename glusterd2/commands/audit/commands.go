@@ -0,0 +1,31 @@
+// Package auditcommands implements the command to query the audit log
+package auditcommands
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+	"github.com/gluster/glusterd2/pkg/api"
+	"github.com/gluster/glusterd2/pkg/utils"
+)
+
+// Command is a holding struct used to implement the GlusterD Command interface
+type Command struct {
+}
+
+// Routes returns command routes. Required for the Command interface.
+func (c *Command) Routes() route.Routes {
+	return route.Routes{
+		route.Route{
+			Name:         "AuditList",
+			Method:       "GET",
+			Pattern:      "/audit",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.AuditListResp)(nil)),
+			HandlerFunc:  auditListHandler,
+		},
+	}
+}
+
+// RegisterStepFuncs implements a required function for the Command interface
+func (c *Command) RegisterStepFuncs() {
+	return
+}
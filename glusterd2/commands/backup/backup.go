@@ -0,0 +1,60 @@
+package backupcommands
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/backup"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+)
+
+// clusterBackupHandler sends a gzipped tar archive holding a snapshot of the
+// store and the volfiles of this node. The archive is written to a temporary
+// file first so that failures can be reported with an error response.
+func clusterBackupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+
+	f, err := ioutil.TempFile("", "gd2-backup-")
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := backup.Write(ctx, f); err != nil {
+		logger.WithError(err).Error("failed to create backup archive")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	filename := fmt.Sprintf("glusterd2-backup-%s-%s.tar.gz",
+		gdctx.MyClusterID.String(), time.Now().UTC().Format("20060102150405"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, f); err != nil {
+		logger.WithError(err).Error("failed to send backup archive")
+		return
+	}
+	logger.WithField("size", size).Info("cluster backup created")
+}
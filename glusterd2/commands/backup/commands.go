@@ -0,0 +1,28 @@
+// Package backupcommands implements the command to back up the cluster store
+package backupcommands
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+)
+
+// Command is a holding struct used to implement the GlusterD Command interface
+type Command struct {
+}
+
+// Routes returns command routes. Required for the Command interface.
+func (c *Command) Routes() route.Routes {
+	return route.Routes{
+		route.Route{
+			Name:        "ClusterBackup",
+			Method:      "POST",
+			Pattern:     "/cluster/backup",
+			Version:     1,
+			HandlerFunc: clusterBackupHandler,
+		},
+	}
+}
+
+// RegisterStepFuncs implements a required function for the Command interface
+func (c *Command) RegisterStepFuncs() {
+	return
+}
@@ -2,9 +2,15 @@
 package commands
 
 import (
+	"github.com/gluster/glusterd2/glusterd2/commands/audit"
+	"github.com/gluster/glusterd2/glusterd2/commands/backup"
+	"github.com/gluster/glusterd2/glusterd2/commands/jobs"
 	"github.com/gluster/glusterd2/glusterd2/commands/options"
 	"github.com/gluster/glusterd2/glusterd2/commands/peers"
 	"github.com/gluster/glusterd2/glusterd2/commands/snapshot"
+	"github.com/gluster/glusterd2/glusterd2/commands/templates"
+	"github.com/gluster/glusterd2/glusterd2/commands/transactions"
+	"github.com/gluster/glusterd2/glusterd2/commands/users"
 	"github.com/gluster/glusterd2/glusterd2/commands/version"
 	"github.com/gluster/glusterd2/glusterd2/commands/volumes"
 	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
@@ -25,4 +31,10 @@ var Commands = []Command{
 	&snapshotcommands.Command{},
 	&peercommands.Command{},
 	&optionscommands.Command{},
+	&usercommands.Command{},
+	&auditcommands.Command{},
+	&backupcommands.Command{},
+	&jobcommands.Command{},
+	&txncommands.Command{},
+	&templatecommands.Command{},
 }
@@ -0,0 +1,48 @@
+// Package jobcommands implements the commands to query and cancel the jobs
+// of asynchronously processed requests
+package jobcommands
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+	"github.com/gluster/glusterd2/pkg/api"
+	"github.com/gluster/glusterd2/pkg/utils"
+)
+
+// Command is a holding struct used to implement the GlusterD Command interface
+type Command struct {
+}
+
+// Routes returns command routes. Required for the Command interface.
+func (c *Command) Routes() route.Routes {
+	return route.Routes{
+		route.Route{
+			Name:         "JobList",
+			Method:       "GET",
+			Pattern:      "/jobs",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.JobListResp)(nil)),
+			HandlerFunc:  jobListHandler,
+		},
+		route.Route{
+			Name:         "JobGet",
+			Method:       "GET",
+			Pattern:      "/jobs/{jobid}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.JobGetResp)(nil)),
+			HandlerFunc:  jobGetHandler,
+		},
+		route.Route{
+			Name:         "JobCancel",
+			Method:       "DELETE",
+			Pattern:      "/jobs/{jobid}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.JobGetResp)(nil)),
+			HandlerFunc:  jobCancelHandler,
+		},
+	}
+}
+
+// RegisterStepFuncs implements a required function for the Command interface
+func (c *Command) RegisterStepFuncs() {
+	return
+}
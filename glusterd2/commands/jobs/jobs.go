@@ -0,0 +1,100 @@
+package jobcommands
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/jobs"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/user"
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/gorilla/mux"
+)
+
+func jobErrToStatusCode(err error) int {
+	switch err {
+	case jobs.ErrJobNotFound:
+		return http.StatusNotFound
+	case jobs.ErrJobNotRunning, jobs.ErrJobNotLocal:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// canAccess returns true if the user of the request is permitted to see and
+// cancel the job, ie. the user started the job or is a cluster admin. The
+// responses of jobs may hold data which only the user who started the job is
+// permitted to see.
+func canAccess(ctx context.Context, job *api.Job) bool {
+	if !gdctx.RESTAPIAuthEnabled {
+		return true
+	}
+	name := gdctx.GetUser(ctx)
+	return name == job.User || user.IsClusterAdmin(name)
+}
+
+func jobListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	list, err := jobs.List()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	visible := make([]api.Job, 0, len(list))
+	for i := range list {
+		if canAccess(ctx, &list[i]) {
+			visible = append(visible, list[i])
+		}
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, api.JobListResp(visible))
+}
+
+func jobGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobid := mux.Vars(r)["jobid"]
+
+	job, err := jobs.Get(jobid)
+	if err == nil && !canAccess(ctx, job) {
+		err = jobs.ErrJobNotFound
+	}
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, jobErrToStatusCode(err), err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, (*api.JobGetResp)(job))
+}
+
+// jobCancelHandler cancels a running job. The job has to be cancelled on the
+// peer which is running it, by the user who started it or a cluster admin.
+// The transaction of the job is rolled back.
+func jobCancelHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	jobid := mux.Vars(r)["jobid"]
+
+	// Jobs of other users are not revealed
+	job, err := jobs.Get(jobid)
+	if err == nil && !canAccess(ctx, job) {
+		err = jobs.ErrJobNotFound
+	}
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, jobErrToStatusCode(err), err)
+		return
+	}
+
+	job, err = jobs.Cancel(jobid)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, jobErrToStatusCode(err), err)
+		return
+	}
+
+	logger.WithField("job", jobid).Info("job cancelled")
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, (*api.JobGetResp)(job))
+}
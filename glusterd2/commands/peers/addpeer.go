@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/gluster/glusterd2/glusterd2/audit"
 	"github.com/gluster/glusterd2/glusterd2/events"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
 	"github.com/gluster/glusterd2/glusterd2/peer"
@@ -100,6 +101,7 @@ func addPeerHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	logger = logger.WithField("peerid", rsp.PeerID)
 	logger.Info("new peer joined our cluster")
+	audit.SetPeer(ctx, rsp.PeerID)
 
 	// Get the new peer information to reply back with
 	newpeer, err := peer.GetPeer(rsp.PeerID)
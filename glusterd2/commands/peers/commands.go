@@ -3,6 +3,7 @@ package peercommands
 
 import (
 	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
 	"github.com/gluster/glusterd2/pkg/api"
 	"github.com/gluster/glusterd2/pkg/utils"
 )
@@ -55,10 +56,19 @@ func (c *Command) Routes() route.Routes {
 			ResponseType: utils.GetTypeString((*api.PeerEditResp)(nil)),
 			HandlerFunc:  editPeer,
 		},
+		route.Route{
+			Name:         "PeerTLSReload",
+			Method:       "POST",
+			Pattern:      "/peers/tls/reload",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.PeerTLSReloadResp)(nil)),
+			HandlerFunc:  reloadPeerTLSHandler,
+		},
 	}
 }
 
 // RegisterStepFuncs implements a required function for the Command interface
 func (c *Command) RegisterStepFuncs() {
 	registerPeerEditStepFuncs()
+	transaction.RegisterStepFunc(txnPeerTLSReload, "peer-tls.Reload")
 }
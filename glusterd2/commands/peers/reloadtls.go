@@ -0,0 +1,79 @@
+package peercommands
+
+import (
+	"net/http"
+
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/peer"
+	"github.com/gluster/glusterd2/glusterd2/peertls"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/pborman/uuid"
+)
+
+const peerTLSRestartRequiredTxnKey = "restart-required"
+
+// reloadPeerTLSHandler reloads the peer TLS certificates on all the peers of
+// the cluster, so that rotated certificates are used without restarting
+// glusterd2. Connections which are already established are not affected.
+// The peers on which the embedded store needs a restart to use a new CA
+// certificate are listed in the response.
+func reloadPeerTLSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !peertls.Enabled() {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, peertls.ErrPeerTLSDisabled)
+		return
+	}
+
+	allNodes, err := peer.GetPeerIDs()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	txn := transaction.NewTxn(ctx)
+	defer txn.Done()
+
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "peer-tls.Reload",
+			Nodes:  allNodes,
+		},
+	}
+
+	if err := txn.Do(); err != nil {
+		txn.Ctx.Logger().WithError(err).Error("failed to reload peer TLS certificates")
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	resp := api.PeerTLSReloadResp{RestartRequired: []uuid.UUID{}}
+	for _, node := range allNodes {
+		var restartRequired bool
+		if err := txn.Ctx.GetNodeResult(node, peerTLSRestartRequiredTxnKey, &restartRequired); err != nil {
+			continue
+		}
+		if restartRequired {
+			resp.RestartRequired = append(resp.RestartRequired, node)
+		}
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func txnPeerTLSReload(c transaction.TxnCtx) error {
+	if err := peertls.Reload(); err != nil {
+		c.Logger().WithError(err).Error("failed to reload peer TLS certificates")
+		return err
+	}
+
+	restartRequired := peertls.StoreRestartRequired()
+	if restartRequired {
+		c.Logger().Warn("the CA certificate has changed, restart glusterd2 for the embedded store to use it")
+	}
+	return c.SetNodeResult(gdctx.MyUUID, peerTLSRestartRequiredTxnKey, restartRequired)
+}
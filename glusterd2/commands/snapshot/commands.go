@@ -1,10 +1,7 @@
 package snapshotcommands
 
 import (
-	"net/http"
-
 	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
-	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
 	"github.com/gluster/glusterd2/pkg/api"
 	"github.com/gluster/glusterd2/pkg/utils"
 )
@@ -24,6 +21,68 @@ func (c *Command) Routes() route.Routes {
 			RequestType:  utils.GetTypeString((*api.SnapCreateReq)(nil)),
 			ResponseType: utils.GetTypeString((*api.SnapCreateResp)(nil)),
 			HandlerFunc:  snapshotCreateHandler},
+		// Config and schedule routes need to be registered before the
+		// routes having {snapname} so that "config" and "schedules" are not
+		// matched as snapshot names
+		route.Route{
+			Name:         "SnapshotConfigGet",
+			Method:       "GET",
+			Pattern:      "/snapshots/config",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.SnapConfigResp)(nil)),
+			HandlerFunc:  snapshotConfigGetHandler},
+		route.Route{
+			Name:         "SnapshotConfigSet",
+			Method:       "POST",
+			Pattern:      "/snapshots/config",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.SnapConfigReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.SnapConfigResp)(nil)),
+			HandlerFunc:  snapshotConfigSetHandler},
+		route.Route{
+			Name:         "SnapshotConfigReset",
+			Method:       "DELETE",
+			Pattern:      "/snapshots/config",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.SnapConfigResetReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.SnapConfigResp)(nil)),
+			HandlerFunc:  snapshotConfigResetHandler},
+		route.Route{
+			Name:         "SnapshotScheduleCreate",
+			Method:       "POST",
+			Pattern:      "/snapshots/schedules",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.SnapScheduleReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.SnapScheduleResp)(nil)),
+			HandlerFunc:  snapshotScheduleCreateHandler},
+		route.Route{
+			Name:         "SnapshotScheduleList",
+			Method:       "GET",
+			Pattern:      "/snapshots/schedules",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.SnapScheduleListResp)(nil)),
+			HandlerFunc:  snapshotScheduleListHandler},
+		route.Route{
+			Name:         "SnapshotScheduleInfo",
+			Method:       "GET",
+			Pattern:      "/snapshots/schedules/{schedulename}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.SnapScheduleResp)(nil)),
+			HandlerFunc:  snapshotScheduleInfoHandler},
+		route.Route{
+			Name:         "SnapshotScheduleEdit",
+			Method:       "POST",
+			Pattern:      "/snapshots/schedules/{schedulename}/edit",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.SnapScheduleEditReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.SnapScheduleResp)(nil)),
+			HandlerFunc:  snapshotScheduleEditHandler},
+		route.Route{
+			Name:        "SnapshotScheduleDelete",
+			Method:      "DELETE",
+			Pattern:     "/snapshots/schedules/{schedulename}",
+			Version:     1,
+			HandlerFunc: snapshotScheduleDeleteHandler},
 		route.Route{
 			Name:         "SnapshotActivate",
 			Method:       "POST",
@@ -80,42 +139,9 @@ func (c *Command) Routes() route.Routes {
 			Pattern:     "/snapshots/{snapname}",
 			Version:     1,
 			HandlerFunc: snapshotDeleteHandler},
-		route.Route{
-			Name:        "SnapshotConfigGet",
-			Method:      "GET",
-			Pattern:     "/snapshots/config",
-			Version:     1,
-			HandlerFunc: snapshotConfigGetHandler},
-		route.Route{
-			Name:        "SnapshotConfigSet",
-			Method:      "POST",
-			Pattern:     "/snapshots/config",
-			Version:     1,
-			HandlerFunc: snapshotConfigSetHandler},
-		route.Route{
-			Name:        "SnapshotConfigReset",
-			Method:      "DELETE",
-			Pattern:     "/snapshots/config",
-			Version:     1,
-			HandlerFunc: snapshotConfigResetHandler},
 	}
 }
 
-func snapshotConfigGetHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	restutils.SendHTTPResponse(ctx, w, http.StatusNotImplemented, "Snapshot Config Get")
-}
-
-func snapshotConfigSetHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	restutils.SendHTTPResponse(ctx, w, http.StatusNotImplemented, "Snapshot Config Set")
-}
-
-func snapshotConfigResetHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	restutils.SendHTTPResponse(ctx, w, http.StatusNotImplemented, "Snapshot Config Reset")
-}
-
 // RegisterStepFuncs registers transaction step functions with
 // Glusterd Transaction framework
 func (c *Command) RegisterStepFuncs() {
@@ -3,6 +3,8 @@ package snapshotcommands
 import (
 	"github.com/gluster/glusterd2/glusterd2/snapshot"
 	"github.com/gluster/glusterd2/glusterd2/transaction"
+
+	"github.com/pborman/uuid"
 )
 
 // undoStoreSnapshot revert back snapinfo and to generate client volfile
@@ -39,3 +41,16 @@ func storeSnapinfo(c transaction.TxnCtx, key string) error {
 
 	return nil
 }
+
+// uniqueNodes returns the list of nodes with duplicates removed
+func uniqueNodes(nodes []uuid.UUID) []uuid.UUID {
+	var unique []uuid.UUID
+	seen := make(map[string]bool)
+	for _, n := range nodes {
+		if !seen[n.String()] {
+			seen[n.String()] = true
+			unique = append(unique, n)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,287 @@
+package snapshotcommands
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/events"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/snapshot"
+	"github.com/gluster/glusterd2/glusterd2/store"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/coreos/etcd/clientv3/concurrency"
+	"github.com/pborman/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	schedulerLeaderKey = "snapshot-scheduler-leader"
+	// schedulerInterval is how often the leader looks for due schedules.
+	// Cron expressions have a resolution of a minute.
+	schedulerInterval = 30 * time.Second
+	// schedulerSessionTTL is the TTL in seconds of the session used to
+	// campaign for the leadership
+	schedulerSessionTTL = 60
+)
+
+// snapScheduler runs the snapshot schedules. A leader is elected among the
+// peers in the cluster so that each schedule is run only once.
+type snapScheduler struct {
+	sync.Mutex
+	isLeader bool
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stopChan chan struct{}
+	stopOnce sync.Once
+	session  *concurrency.Session
+	election *concurrency.Election
+}
+
+var scheduler *snapScheduler
+
+// StartScheduler starts contesting for the snapshot scheduler leadership and
+// runs due snapshot schedules once elected
+func StartScheduler() {
+	session, err := concurrency.NewSession(store.Store.NamespaceClient, concurrency.WithTTL(schedulerSessionTTL))
+	if err != nil {
+		log.WithError(err).Error("failed in starting snapshot scheduler")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler = &snapScheduler{
+		ctx:      ctx,
+		cancel:   cancel,
+		stopChan: make(chan struct{}),
+		session:  session,
+		election: concurrency.NewElection(session, schedulerLeaderKey),
+	}
+
+	go scheduler.startElecting()
+	go scheduler.run()
+}
+
+// StopScheduler stops the snapshot scheduler
+func StopScheduler() {
+	if scheduler != nil {
+		scheduler.stop()
+	}
+}
+
+// startElecting campaigns for the leadership until the scheduler is stopped.
+// The leadership is lost when the session expires, for instance when the
+// connection to etcd is lost for longer than its TTL, in which case a new
+// session is created to campaign again.
+func (s *snapScheduler) startElecting() {
+	for {
+		s.Lock()
+		session, election := s.session, s.election
+		s.Unlock()
+
+		// The campaign, and the leadership, end when the scheduler is
+		// stopped or the session expires
+		ctx, cancel := context.WithCancel(s.ctx)
+		go func() {
+			select {
+			case <-session.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		err := election.Campaign(ctx, gdctx.MyUUID.String())
+		if err == nil {
+			log.Info("node got elected as snapshot scheduler leader")
+			s.setLeader(true)
+			<-ctx.Done()
+			s.setLeader(false)
+		}
+		cancel()
+
+		if s.ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			log.WithError(err).Error("failed in campaign for snapshot scheduler leader election")
+		} else {
+			log.Warn("snapshot scheduler leader session expired, campaigning again")
+		}
+
+		if !s.renewSession() {
+			return
+		}
+	}
+}
+
+func (s *snapScheduler) setLeader(isLeader bool) {
+	s.Lock()
+	s.isLeader = isLeader
+	s.Unlock()
+}
+
+// renewSession replaces the session and the election of the scheduler,
+// retrying until it succeeds or the scheduler is stopped. It returns false
+// if the scheduler was stopped.
+func (s *snapScheduler) renewSession() bool {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return false
+		case <-time.After(time.Second):
+		}
+
+		session, err := concurrency.NewSession(store.Store.NamespaceClient, concurrency.WithTTL(schedulerSessionTTL))
+		if err != nil {
+			log.WithError(err).Warn("failed to create session for snapshot scheduler leader election")
+			continue
+		}
+
+		s.Lock()
+		if s.ctx.Err() != nil {
+			s.Unlock()
+			session.Close()
+			return false
+		}
+		old := s.session
+		s.session = session
+		s.election = concurrency.NewElection(session, schedulerLeaderKey)
+		s.Unlock()
+
+		old.Close()
+		return true
+	}
+}
+
+func (s *snapScheduler) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+
+		s.Lock()
+		s.cancel()
+		session, election := s.session, s.election
+		s.Unlock()
+
+		election.Resign(context.Background())
+		session.Close()
+	})
+}
+
+func (s *snapScheduler) run() {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.Lock()
+			isLeader := s.isLeader
+			s.Unlock()
+
+			if isLeader {
+				runDueSchedules(time.Now().UTC())
+			}
+		}
+	}
+}
+
+func runDueSchedules(now time.Time) {
+	schedules, err := snapshot.GetSchedules()
+	if err != nil {
+		log.WithError(err).Error("failed to get snapshot schedules")
+		return
+	}
+
+	for _, sched := range schedules {
+		next := sched.NextRun()
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		runSchedule(sched.Name, now)
+	}
+}
+
+// runSchedule creates a snapshot as per the schedule and prunes the
+// snapshots created by it earlier as per its retention rules
+func runSchedule(name string, now time.Time) {
+	logger := log.WithField("schedule", name)
+	ctx := gdctx.WithReqLogger(context.Background(), logger)
+	ctx = gdctx.WithReqID(ctx, uuid.NewRandom())
+
+	txn, err := transaction.NewTxnWithLocks(ctx, snapshot.ScheduleLockID(name))
+	if err != nil {
+		logger.WithError(err).Error("failed to lock snapshot schedule")
+		return
+	}
+	defer txn.Done()
+
+	// The schedule may have been modified while waiting for the lock
+	sched, err := snapshot.GetSchedule(name)
+	if err != nil {
+		if err != gderrors.ErrSnapScheduleNotFound {
+			logger.WithError(err).Error("failed to get snapshot schedule")
+		}
+		return
+	}
+	if next := sched.NextRun(); next.IsZero() || next.After(now) {
+		return
+	}
+
+	req := api.SnapCreateReq{
+		VolName:     sched.VolName,
+		SnapName:    sched.Name,
+		TimeStamp:   true,
+		Description: fmt.Sprintf("Created by snapshot schedule %s", sched.Name),
+	}
+
+	sched.LastRun = now
+	snapinfo, _, err := createSnapshot(ctx, &req, sched.Name)
+	if err != nil {
+		logger.WithError(err).WithField("volume", sched.VolName).Error("scheduled snapshot creation failed")
+		sched.LastError = err.Error()
+		events.Broadcast(snapshot.NewScheduleEvent(snapshot.EventScheduleRunFailed, sched, "", err))
+	} else {
+		logger.WithField("snapshot", snapinfo.SnapVolinfo.Name).Info("scheduled snapshot created")
+		sched.LastSnapshot = snapinfo.SnapVolinfo.Name
+		sched.LastError = ""
+		events.Broadcast(snapshot.NewScheduleEvent(snapshot.EventScheduleRunSucceeded, sched, sched.LastSnapshot, nil))
+		pruneScheduledSnapshots(ctx, sched)
+	}
+
+	if err := snapshot.AddOrUpdateSchedule(sched); err != nil {
+		logger.WithError(err).Error("failed to store snapshot schedule")
+	}
+}
+
+func pruneScheduledSnapshots(ctx context.Context, sched *snapshot.Schedule) {
+	logger := gdctx.GetReqLogger(ctx)
+
+	snaps, err := snapshot.GetSnapshots()
+	if err != nil {
+		logger.WithError(err).Error("failed to get snapshots to prune")
+		return
+	}
+
+	var scheduled []*snapshot.Snapinfo
+	for _, snap := range snaps {
+		if snap != nil && sched.IsScheduledSnap(snap) {
+			scheduled = append(scheduled, snap)
+		}
+	}
+
+	for _, snap := range snapshot.SnapsToPrune(scheduled, sched.Retention) {
+		snapname := snap.SnapVolinfo.Name
+		if _, err := DeleteSnapshot(ctx, snapname); err != nil {
+			logger.WithError(err).WithField("snapshot", snapname).Error("failed to prune scheduled snapshot")
+			continue
+		}
+		events.Broadcast(snapshot.NewScheduleEvent(snapshot.EventScheduledSnapPruned, sched, snapname, nil))
+	}
+}
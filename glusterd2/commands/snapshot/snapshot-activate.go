@@ -20,10 +20,20 @@ import (
 )
 
 func activateSnapshot(c transaction.TxnCtx) error {
+	return activateSnapinfo(c, "oldsnapinfo")
+}
+
+func rollbackActivateSnapshot(c transaction.TxnCtx) error {
+	return rollbackActivateSnapinfo(c, "oldsnapinfo")
+}
+
+// activateSnapinfo starts the local bricks of the snapshot stored in the
+// transaction context under the given key
+func activateSnapinfo(c transaction.TxnCtx, key string) error {
 	var snapinfo snapshot.Snapinfo
 	activate := true
 
-	if err := c.Get("oldsnapinfo", &snapinfo); err != nil {
+	if err := c.Get(key, &snapinfo); err != nil {
 		return err
 	}
 	vol := &snapinfo.SnapVolinfo
@@ -53,12 +63,13 @@ func activateSnapshot(c transaction.TxnCtx) error {
 	return err
 }
 
-func rollbackActivateSnapshot(c transaction.TxnCtx) error {
+// rollbackActivateSnapinfo stops the local bricks started by activateSnapinfo
+func rollbackActivateSnapinfo(c transaction.TxnCtx, key string) error {
 	activate := false
 	var snapinfo snapshot.Snapinfo
 	var brickinfos []brick.Brickinfo
 
-	if err := c.Get("oldsnapinfo", &snapinfo); err != nil {
+	if err := c.Get(key, &snapinfo); err != nil {
 		return err
 	}
 	vol := &snapinfo.SnapVolinfo
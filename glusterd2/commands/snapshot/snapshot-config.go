@@ -0,0 +1,237 @@
+package snapshotcommands
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/snapshot"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+)
+
+const (
+	snapConfigLockKey = "snapshot-config"
+)
+
+func snapConfigLocks(volname string) []string {
+	if volname == "" {
+		return []string{snapConfigLockKey}
+	}
+	return []string{snapConfigLockKey, volname}
+}
+
+// createSnapConfigResp returns the configuration in effect for the volume, or
+// the cluster-wide configuration if volinfo is nil
+func createSnapConfigResp(volinfo *volume.Volinfo) (*api.SnapConfigResp, error) {
+	if volinfo == nil {
+		cfg, err := snapshot.GetClusterConfig()
+		if err != nil {
+			return nil, err
+		}
+		return &api.SnapConfigResp{SnapConfig: cfg.ToAPI()}, nil
+	}
+
+	cfg, err := snapshot.GetEffectiveConfig(volinfo.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	return &api.SnapConfigResp{
+		VolName:    volinfo.Name,
+		SnapConfig: cfg.ToAPI(),
+	}, nil
+}
+
+func snapshotConfigGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var volinfo *volume.Volinfo
+	if volname := r.URL.Query().Get("volume"); volname != "" {
+		var err error
+		volinfo, err = volume.GetVolume(volname)
+		if err != nil {
+			status, err := restutils.ErrToStatusCode(err)
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+	}
+
+	resp, err := createSnapConfigResp(volinfo)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func snapshotConfigSetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+
+	var req api.SnapConfigReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	if len(req.Options) == 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrInvalidSnapConfig)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, snapConfigLocks(req.VolName)...)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	var volinfo *volume.Volinfo
+	if req.VolName == "" {
+		cfg, err := snapshot.GetClusterConfig()
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		for k, v := range req.Options {
+			if err := cfg.Set(k, v); err != nil {
+				restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		if err := snapshot.SetClusterConfig(cfg); err != nil {
+			logger.WithError(err).Error("failed to store snapshot config")
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	} else {
+		volinfo, err = volume.GetVolume(req.VolName)
+		if err != nil {
+			status, err := restutils.ErrToStatusCode(err)
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+
+		cfg, err := snapshot.GetVolConfig(volinfo.ID.String())
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+		if cfg == nil {
+			cfg = new(snapshot.VolConfig)
+		}
+
+		for k, v := range req.Options {
+			if err := cfg.Set(k, v); err != nil {
+				restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		if err := snapshot.SetVolConfig(volinfo.ID.String(), cfg); err != nil {
+			logger.WithError(err).WithField(
+				"volume", volinfo.Name).Error("failed to store snapshot config")
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	resp, err := createSnapConfigResp(volinfo)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func snapshotConfigResetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+
+	var req api.SnapConfigResetReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil && err != io.EOF {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, snapConfigLocks(req.VolName)...)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	var volinfo *volume.Volinfo
+	if req.VolName == "" {
+		cfg, err := snapshot.GetClusterConfig()
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if len(req.Options) == 0 {
+			cfg = snapshot.DefaultConfig()
+		}
+		for _, k := range req.Options {
+			if err := cfg.Reset(k); err != nil {
+				restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		if err := snapshot.SetClusterConfig(cfg); err != nil {
+			logger.WithError(err).Error("failed to store snapshot config")
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	} else {
+		volinfo, err = volume.GetVolume(req.VolName)
+		if err != nil {
+			status, err := restutils.ErrToStatusCode(err)
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+
+		cfg, err := snapshot.GetVolConfig(volinfo.ID.String())
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+		if cfg == nil || len(req.Options) == 0 {
+			cfg = new(snapshot.VolConfig)
+		}
+		for _, k := range req.Options {
+			if err := cfg.Reset(k); err != nil {
+				restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		// A volume without any overrides follows the cluster config
+		if *cfg == (snapshot.VolConfig{}) {
+			err = snapshot.DeleteVolConfig(volinfo.ID.String())
+		} else {
+			err = snapshot.SetVolConfig(volinfo.ID.String(), cfg)
+		}
+		if err != nil {
+			logger.WithError(err).WithField(
+				"volume", volinfo.Name).Error("failed to store snapshot config")
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	resp, err := createSnapConfigResp(volinfo)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
@@ -3,21 +3,20 @@ package snapshotcommands
 /*
 TODO
 *setactiveonskip flag
-*snap max limit
-*snap soft limit
-*snap auto-delete
-*activate-on-create
 */
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/gluster/glusterd2/glusterd2/audit"
 	"github.com/gluster/glusterd2/glusterd2/brick"
 	"github.com/gluster/glusterd2/glusterd2/daemon"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
@@ -39,9 +38,18 @@ import (
 	"go.opencensus.io/trace"
 )
 
+// reservedSnapNames can not be used as snapshot names as they clash with the
+// snapshot REST endpoints
+var reservedSnapNames = map[string]bool{
+	"config":    true,
+	"schedules": true,
+}
+
 type txnData struct {
 	Req       api.SnapCreateReq
 	CreatedAt time.Time
+	// Schedule is the name of the snapshot schedule creating the snapshot
+	Schedule string
 }
 
 func barrierActivateDeactivateFunc(volinfo *volume.Volinfo, option string, originUUID uuid.UUID) error {
@@ -575,7 +583,15 @@ func createSnapinfo(c transaction.TxnCtx) error {
 		snapVolinfo.Options[key] = value
 	}
 
+	var activate bool
+	if err := c.Get("activate-on-create", &activate); err != nil {
+		return err
+	}
+
 	snapVolinfo.State = volume.VolCreated
+	if activate {
+		snapVolinfo.State = volume.VolStarted
+	}
 	snapVolinfo.GraphMap = volinfo.GraphMap
 	if snapVolinfo.GraphMap == nil {
 		snapVolinfo.GraphMap = make(map[string]string)
@@ -599,6 +615,7 @@ func createSnapinfo(c transaction.TxnCtx) error {
 
 	snapInfo.Description = req.Description
 	snapInfo.ParentVolume = req.VolName
+	snapInfo.Schedule = data.Schedule
 	/*
 		Snapshot time would be a good addition ?
 	*/
@@ -675,18 +692,138 @@ func validateOriginNodeSnapCreate(c transaction.TxnCtx) error {
 		return err
 	}
 
+	cfg, err := snapshot.GetEffectiveConfig(volinfo.ID.String())
+	if err != nil {
+		return err
+	}
+
+	evictSnaps, err := snapshotsToEvict(volinfo, cfg)
+	if err != nil {
+		return err
+	}
+	if err := c.Set("evict-snapinfos", evictSnaps); err != nil {
+		return err
+	}
+	if err := c.Set("activate-on-create", cfg.ActivateOnCreate); err != nil {
+		return err
+	}
+
 	/*
 		TODO
 		*Geo-replication,
 		*rebalance
 		*tier daemon run check
-		*check for hard-limit and soft-limit
-		*auto-delete
 	*/
 
 	return nil
 }
 
+// snapshotsToEvict enforces the snapshot limits of the volume. It returns
+// the oldest snapshots which need to be deleted to make room for a new
+// snapshot when auto-delete is enabled, and fails if the hard limit has been
+// reached otherwise.
+func snapshotsToEvict(volinfo *volume.Volinfo, cfg *snapshot.Config) ([]snapshot.Snapinfo, error) {
+	count := uint64(len(volinfo.SnapList))
+
+	evict, err := evictCount(count, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.AutoDelete && count >= cfg.SoftLimitCount() {
+		log.WithFields(log.Fields{
+			"volume":     volinfo.Name,
+			"snapshots":  count,
+			"hard-limit": cfg.HardLimit,
+		}).Warn("soft limit of snapshots reached for the volume")
+	}
+	if evict == 0 {
+		return nil, nil
+	}
+
+	snaps := make([]snapshot.Snapinfo, 0, count)
+	for _, name := range volinfo.SnapList {
+		snapinfo, err := snapshot.GetSnapshot(name)
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, *snapinfo)
+	}
+	return oldestSnapshots(snaps, evict), nil
+}
+
+// evictCount returns the number of snapshots to delete before a new snapshot
+// is taken of a volume having count snapshots
+func evictCount(count uint64, cfg *snapshot.Config) (int, error) {
+	if !cfg.AutoDelete {
+		if count >= cfg.HardLimit {
+			return 0, gderrors.ErrSnapHardLimitReached
+		}
+		return 0, nil
+	}
+
+	if count == 0 || count < cfg.SoftLimitCount() {
+		return 0, nil
+	}
+
+	// Delete the oldest snapshot, or more of them if the hard limit has
+	// been lowered below the number of existing snapshots
+	if count >= cfg.HardLimit {
+		return int(count-cfg.HardLimit) + 1, nil
+	}
+	return 1, nil
+}
+
+// oldestSnapshots returns the n oldest snapshots, sorted by creation time
+func oldestSnapshots(snaps []snapshot.Snapinfo, n int) []snapshot.Snapinfo {
+	sort.Slice(snaps, func(i, j int) bool {
+		return snaps[i].CreatedAt.Before(snaps[j].CreatedAt)
+	})
+	if n > len(snaps) {
+		n = len(snaps)
+	}
+	return snaps[:n]
+}
+
+// activateSnapshotOnCreate starts the local bricks of the new snapshot
+func activateSnapshotOnCreate(c transaction.TxnCtx) error {
+	return activateSnapinfo(c, "snapinfo")
+}
+
+func undoActivateSnapshotOnCreate(c transaction.TxnCtx) error {
+	return rollbackActivateSnapinfo(c, "snapinfo")
+}
+
+// autoDeleteSnapshots removes the local bricks of the snapshots evicted to
+// make room for the new snapshot
+func autoDeleteSnapshots(c transaction.TxnCtx) error {
+	var snaps []snapshot.Snapinfo
+	if err := c.Get("evict-snapinfos", &snaps); err != nil {
+		return err
+	}
+
+	for i := range snaps {
+		c.Logger().WithField("snapshot", snaps[i].SnapVolinfo.Name).Info("auto-deleting snapshot")
+		if err := deleteSnapshotBricks(&snaps[i], c.Logger()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func autoDeleteSnapshotsStore(c transaction.TxnCtx) error {
+	var snaps []snapshot.Snapinfo
+	if err := c.Get("evict-snapinfos", &snaps); err != nil {
+		return err
+	}
+
+	for i := range snaps {
+		if err := snapshot.DeleteSnapshot(&snaps[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func registerSnapCreateStepFuncs() {
 	var sfs = []struct {
 		name string
@@ -700,6 +837,10 @@ func registerSnapCreateStepFuncs() {
 		{"snap-create.DeactivateBarrier", deactivateBarrier},
 		{"snap-create.StoreSnapshot", storeSnapshotCreate},
 		{"snap-create.UndoStoreSnapshotOnCreate", undoStoreSnapshotOnCreate},
+		{"snap-create.ActivateSnapshot", activateSnapshotOnCreate},
+		{"snap-create.UndoActivateSnapshot", undoActivateSnapshotOnCreate},
+		{"snap-create.AutoDelete", autoDeleteSnapshots},
+		{"snap-create.AutoDeleteStore", autoDeleteSnapshotsStore},
 	}
 	for _, sf := range sfs {
 		transaction.RegisterStepFunc(sf.sf, sf.name)
@@ -708,58 +849,94 @@ func registerSnapCreateStepFuncs() {
 
 func snapshotCreateHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+
+	var req api.SnapCreateReq
+	if err := unmarshalSnapCreateRequest(&req, r); err != nil {
+		logger.WithError(err).Error("Failed to unmarshal snaphot create request")
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+	audit.SetVolume(ctx, req.VolName)
+	audit.SetSnapshot(ctx, req.SnapName)
+
+	snapInfo, status, err := CreateSnapshot(ctx, &req)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	// The name of the snapshot has the time appended if requested
+	audit.SetSnapshot(ctx, snapInfo.SnapVolinfo.Name)
+
+	resp := createSnapCreateResp(snapInfo)
+	restutils.SetLocationHeader(r, w, snapInfo.SnapVolinfo.Name)
+	restutils.SendHTTPResponse(ctx, w, http.StatusCreated, resp)
+}
+
+// CreateSnapshot creates a snapshot of a volume as described by the request
+func CreateSnapshot(ctx context.Context, snapReq *api.SnapCreateReq) (*snapshot.Snapinfo, int, error) {
+	return createSnapshot(ctx, snapReq, "")
+}
+
+// createSnapshot creates a snapshot of a volume as described by the request,
+// marking it with the name of the snapshot schedule creating it if any
+func createSnapshot(ctx context.Context, snapReq *api.SnapCreateReq, schedule string) (*snapshot.Snapinfo, int, error) {
 	ctx, span := trace.StartSpan(ctx, "/snapshotCreateHandler")
 	defer span.End()
 
 	logger := gdctx.GetReqLogger(ctx)
 	var snapInfo snapshot.Snapinfo
-	var data txnData
+	data := txnData{Req: *snapReq, Schedule: schedule}
 	req := &data.Req
 
-	err := unmarshalSnapCreateRequest(req, r)
-	if err != nil {
-		logger.WithError(err).Error("Failed to unmarshal snaphot create request")
-		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
-		return
-	}
 	data.CreatedAt = time.Now().UTC()
 	if req.TimeStamp == true {
 		req.SnapName = req.SnapName + (data.CreatedAt).Format("_GMT_2006_01_02_15_04_05")
 	}
 
-	if !volume.IsValidName(req.SnapName) {
-		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrInvalidSnapName)
-		return
+	if !volume.IsValidName(req.SnapName) || reservedSnapNames[req.SnapName] {
+		return nil, http.StatusBadRequest, gderrors.ErrInvalidSnapName
 	}
 
 	txn, err := transaction.NewTxnWithLocks(ctx, req.VolName, req.SnapName)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return nil, status, err
 	}
 	defer txn.Done()
 
 	if err = txn.Ctx.Set("data", data); err != nil {
 		logger.WithError(err).Error("failed to set request in transaction context")
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+		return nil, http.StatusInternalServerError, err
 	}
 
 	if err := validateOriginNodeSnapCreate(txn.Ctx); err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusUnprocessableEntity, err)
-		return
+		return nil, http.StatusUnprocessableEntity, err
+	}
+
+	// The snapshots to be auto-deleted are locked so that they are not
+	// activated, cloned or restored meanwhile
+	var evictSnaps []snapshot.Snapinfo
+	if err := txn.Ctx.Get("evict-snapinfos", &evictSnaps); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	evictLocks := transaction.Locks{}
+	defer evictLocks.UnLock(context.Background())
+	for i := range evictSnaps {
+		if err := evictLocks.Lock(evictSnaps[i].SnapVolinfo.Name); err != nil {
+			status, err := restutils.ErrToStatusCode(err)
+			return nil, status, err
+		}
 	}
+
 	vol, e := volume.GetVolume(req.VolName)
 	if e != nil {
 		status, err := restutils.ErrToStatusCode(e)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return nil, status, err
 	}
 
 	if vol.ProvisionerType != api.ProvisionerTypeLvm && vol.ProvisionerType != "" {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, gderrors.ErrSnapNotSupported)
-		return
+		return nil, http.StatusInternalServerError, gderrors.ErrSnapNotSupported
 	}
 
 	txn.Nodes = vol.Nodes()
@@ -790,13 +967,43 @@ func snapshotCreateHandler(w http.ResponseWriter, r *http.Request) {
 			DoFunc: "snap-create.DeactivateBarrier",
 			Nodes:  txn.Nodes,
 		},
+	}
 
-		{
-			DoFunc:   "snap-create.StoreSnapshot",
-			UndoFunc: "snap-create.UndoStoreSnapshotOnCreate",
-			Nodes:    []uuid.UUID{gdctx.MyUUID},
-			Sync:     true,
-		},
+	var activate bool
+	if err := txn.Ctx.Get("activate-on-create", &activate); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if activate {
+		txn.Steps = append(txn.Steps, &transaction.Step{
+			DoFunc:   "snap-create.ActivateSnapshot",
+			UndoFunc: "snap-create.UndoActivateSnapshot",
+			Nodes:    txn.Nodes,
+		})
+	}
+
+	txn.Steps = append(txn.Steps, &transaction.Step{
+		DoFunc:   "snap-create.StoreSnapshot",
+		UndoFunc: "snap-create.UndoStoreSnapshotOnCreate",
+		Nodes:    []uuid.UUID{gdctx.MyUUID},
+		Sync:     true,
+	})
+
+	if len(evictSnaps) > 0 {
+		var evictNodes []uuid.UUID
+		for i := range evictSnaps {
+			evictNodes = append(evictNodes, evictSnaps[i].SnapVolinfo.Nodes()...)
+		}
+		txn.Steps = append(txn.Steps,
+			&transaction.Step{
+				DoFunc: "snap-create.AutoDelete",
+				Nodes:  uniqueNodes(evictNodes),
+			},
+			&transaction.Step{
+				DoFunc: "snap-create.AutoDeleteStore",
+				Nodes:  []uuid.UUID{gdctx.MyUUID},
+				Sync:   true,
+			},
+		)
 	}
 
 	span.AddAttributes(
@@ -808,21 +1015,17 @@ func snapshotCreateHandler(w http.ResponseWriter, r *http.Request) {
 	if err = txn.Do(); err != nil {
 		logger.WithError(err).Error("snapshot create transaction failed")
 		status, err := restutils.ErrToStatusCode(err)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return nil, status, err
 	}
 
 	txn.Ctx.Logger().WithField("SnapName", req.SnapName).Info("new snapshot created")
 
 	if err = txn.Ctx.Get("snapinfo", &snapInfo); err != nil {
 		logger.WithError(err).Error("failed to get snap volinfo in transaction context")
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+		return nil, http.StatusInternalServerError, err
 	}
 
-	resp := createSnapCreateResp(&snapInfo)
-	restutils.SetLocationHeader(r, w, snapInfo.SnapVolinfo.Name)
-	restutils.SendHTTPResponse(ctx, w, http.StatusCreated, resp)
+	return &snapInfo, http.StatusCreated, nil
 }
 
 // createSnapCreateResp functions create resnse for rest utils
@@ -838,5 +1041,6 @@ func createSnapInfoResp(snap *snapshot.Snapinfo) *api.SnapInfo {
 		ParentVolName: snap.ParentVolume,
 		Description:   snap.Description,
 		CreatedAt:     snap.CreatedAt,
+		Schedule:      snap.Schedule,
 	}
 }
@@ -0,0 +1,86 @@
+package snapshotcommands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/snapshot"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEvictCount validates evictCount()
+func TestEvictCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   uint64
+		cfg     snapshot.Config
+		want    int
+		wantErr error
+	}{
+		{
+			name:  "below the hard limit",
+			count: 9,
+			cfg:   snapshot.Config{HardLimit: 10, SoftLimit: 50},
+		},
+		{
+			name:    "hard limit reached",
+			count:   10,
+			cfg:     snapshot.Config{HardLimit: 10, SoftLimit: 50},
+			wantErr: gderrors.ErrSnapHardLimitReached,
+		},
+		{
+			name:  "auto-delete below the soft limit",
+			count: 4,
+			cfg:   snapshot.Config{HardLimit: 10, SoftLimit: 50, AutoDelete: true},
+		},
+		{
+			name:  "auto-delete at the soft limit",
+			count: 5,
+			cfg:   snapshot.Config{HardLimit: 10, SoftLimit: 50, AutoDelete: true},
+			want:  1,
+		},
+		{
+			name:  "auto-delete at the hard limit",
+			count: 10,
+			cfg:   snapshot.Config{HardLimit: 10, SoftLimit: 100, AutoDelete: true},
+			want:  1,
+		},
+		{
+			name:  "auto-delete after the hard limit was lowered",
+			count: 12,
+			cfg:   snapshot.Config{HardLimit: 10, SoftLimit: 90, AutoDelete: true},
+			want:  3,
+		},
+		{
+			name: "auto-delete without snapshots",
+			cfg:  snapshot.Config{HardLimit: 1, SoftLimit: 50, AutoDelete: true},
+		},
+	}
+
+	for _, tt := range tests {
+		evict, err := evictCount(tt.count, &tt.cfg)
+		assert.Equal(t, tt.wantErr, err, tt.name)
+		assert.Equal(t, tt.want, evict, tt.name)
+	}
+}
+
+// TestOldestSnapshots validates oldestSnapshots()
+func TestOldestSnapshots(t *testing.T) {
+	base := time.Date(2019, time.January, 10, 12, 0, 0, 0, time.UTC)
+	snaps := make([]snapshot.Snapinfo, 3)
+	for i, name := range []string{"snap-b", "snap-c", "snap-a"} {
+		snaps[i].SnapVolinfo.Name = name
+	}
+	snaps[0].CreatedAt = base.Add(time.Hour)
+	snaps[1].CreatedAt = base.Add(2 * time.Hour)
+	snaps[2].CreatedAt = base
+
+	oldest := oldestSnapshots(snaps, 2)
+	assert.Equal(t, 2, len(oldest))
+	assert.Equal(t, "snap-a", oldest[0].SnapVolinfo.Name)
+	assert.Equal(t, "snap-b", oldest[1].SnapVolinfo.Name)
+
+	assert.Equal(t, 3, len(oldestSnapshots(snaps, 5)))
+}
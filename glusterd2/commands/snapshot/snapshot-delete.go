@@ -1,6 +1,7 @@
 package snapshotcommands
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"strings"
@@ -55,6 +56,11 @@ func snapshotDelete(c transaction.TxnCtx) error {
 		return err
 	}
 
+	return deleteSnapshotBricks(&snapinfo, c.Logger())
+}
+
+// deleteSnapshotBricks stops and removes the local bricks of a snapshot
+func deleteSnapshotBricks(snapinfo *snapshot.Snapinfo, logger log.FieldLogger) error {
 	snapVol := snapinfo.SnapVolinfo
 	var wg sync.WaitGroup
 	numBricks := len(snapVol.GetBricks())
@@ -62,7 +68,7 @@ func snapshotDelete(c transaction.TxnCtx) error {
 
 	for _, b := range snapVol.GetLocalBricks() {
 		wg.Add(1)
-		go snapshotBrickDelete(errCh, &wg, snapVol, b, c.Logger())
+		go snapshotBrickDelete(errCh, &wg, snapVol, b, logger)
 	}
 
 	err := error(nil)
@@ -121,26 +127,34 @@ func registerSnapDeleteStepFuncs() {
 }
 
 func snapshotDeleteHandler(w http.ResponseWriter, r *http.Request) {
-
 	ctx := r.Context()
+	snapname := mux.Vars(r)["snapname"]
+
+	if status, err := DeleteSnapshot(ctx, snapname); err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusNoContent, nil)
+}
+
+// DeleteSnapshot deletes the snapshot and removes its bricks
+func DeleteSnapshot(ctx context.Context, snapname string) (int, error) {
 	ctx, span := trace.StartSpan(ctx, "/snapshotDeleteHandler")
 	defer span.End()
 
 	logger := gdctx.GetReqLogger(ctx)
-	snapname := mux.Vars(r)["snapname"]
 	//Fetching snapinfo to get the parent volume name. Parent volume has to be locked
 	snapinfo, err := snapshot.GetSnapshot(snapname)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return status, err
 	}
 
 	txn, err := transaction.NewTxnWithLocks(ctx, snapname, snapinfo.ParentVolume)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return status, err
 	}
 	defer txn.Done()
 
@@ -148,8 +162,7 @@ func snapshotDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	snapinfo, err = snapshot.GetSnapshot(snapname)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return status, err
 	}
 
 	volinfo := &snapinfo.SnapVolinfo
@@ -166,8 +179,7 @@ func snapshotDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := txn.Ctx.Set("snapinfo", snapinfo); err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+		return http.StatusInternalServerError, err
 	}
 
 	span.AddAttributes(
@@ -179,11 +191,9 @@ func snapshotDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	if err := txn.Do(); err != nil {
 		logger.WithError(err).WithField(
 			"snapname", snapname).Error("transaction to delete snapshot failed")
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+		return http.StatusInternalServerError, err
 	}
 
 	logger.WithField("Snapshot-name", snapname).Info("snapshot deleted")
-
-	restutils.SendHTTPResponse(ctx, w, http.StatusNoContent, nil)
+	return http.StatusNoContent, nil
 }
@@ -0,0 +1,223 @@
+package snapshotcommands
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/snapshot"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	"github.com/gluster/glusterd2/pkg/cron"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/gorilla/mux"
+)
+
+func validateSchedule(s *snapshot.Schedule) error {
+	if _, err := cron.Parse(s.Schedule); err != nil {
+		return err
+	}
+
+	r := s.Retention
+	if r.Last < 0 || r.Hourly < 0 || r.Daily < 0 || r.Weekly < 0 || r.Monthly < 0 {
+		return fmt.Errorf("retention counts can not be negative")
+	}
+	return nil
+}
+
+func snapshotScheduleCreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+
+	var req api.SnapScheduleReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	if req.VolName == "" {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrEmptyVolName)
+		return
+	}
+	if !volume.IsValidName(req.Name) {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "invalid schedule name")
+		return
+	}
+
+	s := &snapshot.Schedule{
+		Name:        req.Name,
+		VolName:     req.VolName,
+		Schedule:    req.Schedule,
+		Retention:   req.Retention,
+		Description: req.Description,
+		Disabled:    req.Disabled,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := validateSchedule(s); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, snapshot.ScheduleLockID(req.Name), req.VolName)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	if _, err := volume.GetVolume(req.VolName); err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if _, err := snapshot.GetSchedule(req.Name); err == nil {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, gderrors.ErrSnapScheduleExists)
+		return
+	} else if err != gderrors.ErrSnapScheduleNotFound {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := snapshot.AddOrUpdateSchedule(s); err != nil {
+		logger.WithError(err).WithField("schedule", s.Name).Error("failed to store snapshot schedule")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := api.SnapScheduleResp(s.ToAPI())
+	restutils.SetLocationHeader(r, w, s.Name)
+	restutils.SendHTTPResponse(ctx, w, http.StatusCreated, resp)
+}
+
+func snapshotScheduleListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	volname := r.URL.Query().Get("volume")
+
+	schedules, err := snapshot.GetSchedules()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make(api.SnapScheduleListResp, 0, len(schedules))
+	for _, s := range schedules {
+		if volname != "" && s.VolName != volname {
+			continue
+		}
+		resp = append(resp, s.ToAPI())
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Name < resp[j].Name
+	})
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func snapshotScheduleInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := mux.Vars(r)["schedulename"]
+
+	s, err := snapshot.GetSchedule(name)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	resp := api.SnapScheduleResp(s.ToAPI())
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func snapshotScheduleEditHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	name := mux.Vars(r)["schedulename"]
+
+	var req api.SnapScheduleEditReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, snapshot.ScheduleLockID(name))
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	s, err := snapshot.GetSchedule(name)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if req.Schedule != "" {
+		s.Schedule = req.Schedule
+	}
+	if req.Retention != nil {
+		s.Retention = *req.Retention
+	}
+	if req.Description != nil {
+		s.Description = *req.Description
+	}
+	if req.Disabled != nil {
+		// Do not try to catch up with runs missed while disabled
+		if s.Disabled && !*req.Disabled {
+			s.LastRun = time.Now().UTC()
+		}
+		s.Disabled = *req.Disabled
+	}
+
+	if err := validateSchedule(s); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := snapshot.AddOrUpdateSchedule(s); err != nil {
+		logger.WithError(err).WithField("schedule", s.Name).Error("failed to store snapshot schedule")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := api.SnapScheduleResp(s.ToAPI())
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func snapshotScheduleDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	name := mux.Vars(r)["schedulename"]
+
+	txn, err := transaction.NewTxnWithLocks(ctx, snapshot.ScheduleLockID(name))
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	if _, err := snapshot.GetSchedule(name); err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	// Snapshots already created by the schedule are left untouched
+	if err := snapshot.DeleteSchedule(name); err != nil {
+		logger.WithError(err).WithField("schedule", name).Error("failed to delete snapshot schedule")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusNoContent, nil)
+}
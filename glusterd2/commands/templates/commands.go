@@ -0,0 +1,79 @@
+// Package templatecommands implements the commands to manage the volfile
+// templates of the cluster
+package templatecommands
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/pkg/api"
+	"github.com/gluster/glusterd2/pkg/utils"
+)
+
+// Command is a holding struct used to implement the GlusterD Command interface
+type Command struct {
+}
+
+// Routes returns command routes. Required for the Command interface.
+func (c *Command) Routes() route.Routes {
+	return route.Routes{
+		route.Route{
+			Name:         "TemplateCreate",
+			Method:       "POST",
+			Pattern:      "/templates",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.TemplateCreateReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.TemplateCreateResp)(nil)),
+			HandlerFunc:  templateCreateHandler,
+		},
+		route.Route{
+			Name:         "TemplateList",
+			Method:       "GET",
+			Pattern:      "/templates",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.TemplateListResp)(nil)),
+			HandlerFunc:  templateListHandler,
+		},
+		route.Route{
+			Name:         "TemplateGet",
+			Method:       "GET",
+			Pattern:      "/templates/{templatename}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.TemplateGetResp)(nil)),
+			HandlerFunc:  templateGetHandler,
+		},
+		route.Route{
+			Name:         "TemplateEdit",
+			Method:       "POST",
+			Pattern:      "/templates/{templatename}",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.TemplateEditReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.TemplateEditResp)(nil)),
+			HandlerFunc:  templateEditHandler,
+		},
+		route.Route{
+			Name:        "TemplateDelete",
+			Method:      "DELETE",
+			Pattern:     "/templates/{templatename}",
+			Version:     1,
+			HandlerFunc: templateDeleteHandler,
+		},
+	}
+}
+
+// RegisterStepFuncs implements a required function for the Command interface
+func (c *Command) RegisterStepFuncs() {
+	var sfs = []struct {
+		name string
+		sf   transaction.StepFunc
+	}{
+		{"template.Store", txnStoreTemplates},
+		{"template.UndoStore", txnUndoStoreTemplates},
+		{"template.Load", txnLoadTemplates},
+		{"template.UndoLoad", txnUndoLoadTemplates},
+		{"template.GenerateBrickVolfiles", txnGenerateBrickVolfiles},
+		{"template.NotifyVolfileChange", txnNotifyVolfileChange},
+	}
+	for _, sf := range sfs {
+		transaction.RegisterStepFunc(sf.sf, sf.name)
+	}
+}
@@ -0,0 +1,105 @@
+package templatecommands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gluster/glusterd2/glusterd2/volgen"
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+var volfileLevels = []volgen.VolfileLevel{
+	volgen.VolfileLevelBrick,
+	volgen.VolfileLevelVolume,
+	volgen.VolfileLevelCluster,
+}
+
+func parseVolfileLevel(level string) (volgen.VolfileLevel, error) {
+	for _, l := range volfileLevels {
+		if l.String() == level {
+			return l, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid volfile level %q, supported levels are brick, volume and cluster", level)
+}
+
+func xlatorsFromAPI(xlators []api.TemplateXlator) []volgen.Xlator {
+	var xls []volgen.Xlator
+	for _, xl := range xlators {
+		xls = append(xls, volgen.Xlator{
+			NameTmpl:        xl.NameTmpl,
+			Type:            xl.Type,
+			TypeTmpl:        xl.TypeTmpl,
+			OnlyLocalBricks: xl.OnlyLocalBricks,
+			Disabled:        xl.Disabled,
+			EnableByOption:  xl.EnableByOption,
+			Options:         xl.Options,
+			IgnoreOptions:   xl.IgnoreOptions,
+		})
+	}
+	return xls
+}
+
+func xlatorsToAPI(xlators []volgen.Xlator) []api.TemplateXlator {
+	var xls []api.TemplateXlator
+	for _, xl := range xlators {
+		xls = append(xls, api.TemplateXlator{
+			NameTmpl:        xl.NameTmpl,
+			Type:            xl.Type,
+			TypeTmpl:        xl.TypeTmpl,
+			OnlyLocalBricks: xl.OnlyLocalBricks,
+			Disabled:        xl.Disabled,
+			EnableByOption:  xl.EnableByOption,
+			Options:         xl.Options,
+			IgnoreOptions:   xl.IgnoreOptions,
+		})
+	}
+	return xls
+}
+
+// templatesFromAPI converts the volfile templates of a request and validates
+// them against the xlator registry
+func templatesFromAPI(volfiles map[string]api.VolfileTemplate) (volgen.Templates, error) {
+	if len(volfiles) == 0 {
+		return nil, errors.New("at least one volfile template is required")
+	}
+
+	tmpls := make(volgen.Templates)
+	for name, v := range volfiles {
+		level, err := parseVolfileLevel(v.Level)
+		if err != nil {
+			return nil, err
+		}
+		tmpls[name] = volgen.Template{
+			Name:               name,
+			Level:              level,
+			Xlators:            xlatorsFromAPI(v.Xlators),
+			VolumeGraphXlators: xlatorsFromAPI(v.VolumeGraphXlators),
+			SubvolGraphXlators: xlatorsFromAPI(v.SubvolGraphXlators),
+			BrickGraphXlators:  xlatorsFromAPI(v.BrickGraphXlators),
+		}
+	}
+
+	if err := tmpls.Validate(); err != nil {
+		return nil, err
+	}
+	return tmpls, nil
+}
+
+func createTemplateResp(name string, tmpls volgen.Templates, volumes []string) *api.TemplateGetResp {
+	resp := &api.TemplateGetResp{
+		Name:     name,
+		Volfiles: make(map[string]api.VolfileTemplate),
+		Volumes:  volumes,
+	}
+	for tmplName, tmpl := range tmpls {
+		resp.Volfiles[tmplName] = api.VolfileTemplate{
+			Level:              tmpl.Level.String(),
+			Xlators:            xlatorsToAPI(tmpl.Xlators),
+			VolumeGraphXlators: xlatorsToAPI(tmpl.VolumeGraphXlators),
+			SubvolGraphXlators: xlatorsToAPI(tmpl.SubvolGraphXlators),
+			BrickGraphXlators:  xlatorsToAPI(tmpl.BrickGraphXlators),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,55 @@
+package templatecommands
+
+import (
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/volgen"
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplatesFromAPI(t *testing.T) {
+	_, err := templatesFromAPI(nil)
+	assert.NotNil(t, err)
+
+	_, err = templatesFromAPI(map[string]api.VolfileTemplate{
+		"client": {Level: "peer"},
+	})
+	assert.NotNil(t, err)
+
+	// Xlator types are looked up in the xlator registry
+	_, err = templatesFromAPI(map[string]api.VolfileTemplate{
+		"client": {
+			Level:   "volume",
+			Xlators: []api.TemplateXlator{{Type: "features/unknown-xlator"}},
+		},
+	})
+	assert.NotNil(t, err)
+
+	_, err = templatesFromAPI(map[string]api.VolfileTemplate{
+		"client": {
+			Level:   "volume",
+			Xlators: []api.TemplateXlator{{NameTmpl: "{{ volume.name }}"}},
+		},
+	})
+	assert.NotNil(t, err)
+
+	// Types with template variables are only resolved during volfile
+	// generation
+	volfiles := map[string]api.VolfileTemplate{
+		"client": {
+			Level:              "volume",
+			SubvolGraphXlators: []api.TemplateXlator{{TypeTmpl: "cluster/{{ subvol.type }}"}},
+		},
+	}
+	tmpls, err := templatesFromAPI(volfiles)
+	assert.Nil(t, err)
+	assert.Equal(t, "client", tmpls["client"].Name)
+	assert.Equal(t, volgen.VolfileLevelVolume, tmpls["client"].Level)
+
+	resp := createTemplateResp("custom", tmpls, []string{"vol1"})
+	assert.Equal(t, "custom", resp.Name)
+	assert.Equal(t, volfiles, resp.Volfiles)
+	assert.Equal(t, []string{"vol1"}, resp.Volumes)
+}
@@ -0,0 +1,278 @@
+package templatecommands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/peer"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+)
+
+// templateChange is the change of the templates of a namespace made by a
+// transaction. New is nil when the templates are deleted, and Old is nil
+// when they are created. Stored is true if Old was saved in the store.
+type templateChange struct {
+	Namespace string           `json:"namespace"`
+	New       volgen.Templates `json:"new"`
+	Old       volgen.Templates `json:"old"`
+	Stored    bool             `json:"stored"`
+}
+
+func validateTemplateName(name string) error {
+	if name == "" {
+		return errors.New("template name is required")
+	}
+	if strings.ContainsAny(name, "/ ") {
+		return errors.New("template name can not contain '/' or spaces")
+	}
+	return nil
+}
+
+// templateVolumes returns the names of the volumes using each template
+func templateVolumes(ctx context.Context) (map[string][]string, error) {
+	volumes, err := volume.GetVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tmplVolumes := make(map[string][]string)
+	for _, v := range volumes {
+		namespace := volgen.TemplateNamespace(v)
+		tmplVolumes[namespace] = append(tmplVolumes[namespace], v.Name)
+	}
+	return tmplVolumes, nil
+}
+
+// usesTemplates returns true if the volfiles of the volume are generated
+// from the templates of the namespace. All volumes use the default
+// templates for the volfiles missing in their own templates.
+func usesTemplates(volinfo *volume.Volinfo, namespace string) bool {
+	return namespace == volgen.DefaultTemplateNamespace || volgen.TemplateNamespace(volinfo) == namespace
+}
+
+// runTemplateChange applies the change to the store and to the templates
+// loaded by all the peers. The volfiles of the volumes using the templates
+// are regenerated if regenerate is true.
+func runTemplateChange(ctx context.Context, change *templateChange, regenerate bool) (int, error) {
+	allNodes, err := peer.GetPeerIDs()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volgen.TemplateLockID(change.Namespace))
+	if err != nil {
+		return restutils.ErrToStatusCode(err)
+	}
+	defer txn.Done()
+
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc:   "template.Store",
+			UndoFunc: "template.UndoStore",
+			Nodes:    []uuid.UUID{gdctx.MyUUID},
+			Sync:     true,
+		},
+		{
+			DoFunc:   "template.Load",
+			UndoFunc: "template.UndoLoad",
+			Nodes:    allNodes,
+		},
+		{
+			DoFunc: "template.GenerateBrickVolfiles",
+			Nodes:  allNodes,
+			Skip:   !regenerate,
+		},
+		{
+			DoFunc: "template.NotifyVolfileChange",
+			Nodes:  allNodes,
+			Skip:   !regenerate,
+		},
+	}
+
+	if err := txn.Ctx.Set("change", change); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if err := txn.Do(); err != nil {
+		txn.Ctx.Logger().WithError(err).WithField("template", change.Namespace).Error("transaction to change volfile templates failed")
+		return restutils.ErrToStatusCode(err)
+	}
+	return http.StatusOK, nil
+}
+
+func templateCreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req api.TemplateCreateReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	if err := validateTemplateName(req.Name); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	tmpls, err := templatesFromAPI(req.Volfiles)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, exists := volgen.GetTemplates(req.Name); exists {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, gderrors.ErrTemplateExists)
+		return
+	}
+
+	change := &templateChange{
+		Namespace: req.Name,
+		New:       tmpls,
+	}
+	if status, err := runTemplateChange(ctx, change, false); err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	resp := createTemplateResp(req.Name, tmpls, nil)
+	restutils.SendHTTPResponse(ctx, w, http.StatusCreated, (*api.TemplateCreateResp)(resp))
+}
+
+func templateListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tmplVolumes, err := templateVolumes(ctx)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make(api.TemplateListResp, 0)
+	for _, name := range volgen.TemplateNamespaces() {
+		tmpls, exists := volgen.GetTemplates(name)
+		if !exists {
+			continue
+		}
+		resp = append(resp, *createTemplateResp(name, tmpls, tmplVolumes[name]))
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func templateGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := mux.Vars(r)["templatename"]
+
+	tmpls, exists := volgen.GetTemplates(name)
+	if !exists {
+		restutils.SendHTTPError(ctx, w, http.StatusNotFound, gderrors.ErrTemplateNotFound)
+		return
+	}
+
+	tmplVolumes, err := templateVolumes(ctx)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, createTemplateResp(name, tmpls, tmplVolumes[name]))
+}
+
+func templateEditHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := mux.Vars(r)["templatename"]
+
+	var req api.TemplateEditReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	tmpls, err := templatesFromAPI(req.Volfiles)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	old, exists := volgen.GetTemplates(name)
+	if !exists {
+		restutils.SendHTTPError(ctx, w, http.StatusNotFound, gderrors.ErrTemplateNotFound)
+		return
+	}
+
+	// The default templates are not in the store until they are edited
+	_, err = volgen.GetStoredTemplates(name)
+	if err != nil && err != gderrors.ErrTemplateNotFound {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	change := &templateChange{
+		Namespace: name,
+		New:       tmpls,
+		Old:       old,
+		Stored:    err == nil,
+	}
+	if status, err := runTemplateChange(ctx, change, true); err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	tmplVolumes, err := templateVolumes(ctx)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := createTemplateResp(name, tmpls, tmplVolumes[name])
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, (*api.TemplateEditResp)(resp))
+}
+
+func templateDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := mux.Vars(r)["templatename"]
+
+	if name == volgen.DefaultTemplateNamespace {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, errors.New("the default template can not be deleted"))
+		return
+	}
+
+	old, err := volgen.GetStoredTemplates(name)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	tmplVolumes, err := templateVolumes(ctx)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(tmplVolumes[name]) > 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, gderrors.ErrTemplateInUse)
+		return
+	}
+
+	change := &templateChange{
+		Namespace: name,
+		Old:       old,
+		Stored:    true,
+	}
+	if status, err := runTemplateChange(ctx, change, false); err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusNoContent, nil)
+}
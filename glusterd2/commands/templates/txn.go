@@ -0,0 +1,167 @@
+package templatecommands
+
+import (
+	"context"
+
+	"github.com/gluster/glusterd2/glusterd2/servers/sunrpc"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func getTemplateChange(c transaction.TxnCtx) (*templateChange, error) {
+	var change templateChange
+	if err := c.Get("change", &change); err != nil {
+		c.Logger().WithError(err).WithField("key", "change").Error("failed to get key from transaction context")
+		return nil, err
+	}
+	return &change, nil
+}
+
+func storeTemplates(namespace string, tmpls volgen.Templates) error {
+	if tmpls == nil {
+		err := volgen.DeleteStoredTemplates(namespace)
+		if err == gderrors.ErrTemplateNotFound {
+			return nil
+		}
+		return err
+	}
+	return volgen.AddOrUpdateStoredTemplates(namespace, tmpls)
+}
+
+func loadTemplates(namespace string, tmpls volgen.Templates) {
+	if tmpls == nil {
+		volgen.RemoveTemplates(namespace)
+		return
+	}
+	volgen.SetTemplates(namespace, tmpls)
+}
+
+func txnStoreTemplates(c transaction.TxnCtx) error {
+	change, err := getTemplateChange(c)
+	if err != nil {
+		return err
+	}
+
+	// The checks made by the handler are repeated now that the templates
+	// are locked
+	switch {
+	case change.Old == nil:
+		if _, exists := volgen.GetTemplates(change.Namespace); exists {
+			return gderrors.ErrTemplateExists
+		}
+	case change.New == nil:
+		tmplVolumes, err := templateVolumes(context.TODO())
+		if err != nil {
+			return err
+		}
+		if len(tmplVolumes[change.Namespace]) > 0 {
+			return gderrors.ErrTemplateInUse
+		}
+	}
+
+	if err := storeTemplates(change.Namespace, change.New); err != nil {
+		c.Logger().WithError(err).WithField("template", change.Namespace).Error("failed to save volfile templates in the store")
+		return err
+	}
+	return nil
+}
+
+func txnUndoStoreTemplates(c transaction.TxnCtx) error {
+	change, err := getTemplateChange(c)
+	if err != nil {
+		return err
+	}
+
+	old := change.Old
+	if !change.Stored {
+		old = nil
+	}
+	if err := storeTemplates(change.Namespace, old); err != nil {
+		c.Logger().WithError(err).WithField("template", change.Namespace).Error("failed to restore volfile templates in the store")
+		return err
+	}
+	return nil
+}
+
+func txnLoadTemplates(c transaction.TxnCtx) error {
+	change, err := getTemplateChange(c)
+	if err != nil {
+		return err
+	}
+
+	loadTemplates(change.Namespace, change.New)
+	return nil
+}
+
+// txnUndoLoadTemplates restores the templates in use before the change, and
+// the volfiles generated from them
+func txnUndoLoadTemplates(c transaction.TxnCtx) error {
+	change, err := getTemplateChange(c)
+	if err != nil {
+		return err
+	}
+
+	loadTemplates(change.Namespace, change.Old)
+	if change.New == nil || change.Old == nil {
+		return nil
+	}
+	return generateBrickVolfiles(c, change.Namespace)
+}
+
+// generateBrickVolfiles regenerates the volfiles of the local bricks of the
+// volumes using the templates of the namespace
+func generateBrickVolfiles(c transaction.TxnCtx, namespace string) error {
+	volumes, err := volume.GetVolumes(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	for _, v := range volumes {
+		if !usesTemplates(v, namespace) {
+			continue
+		}
+		if err := volgen.GenerateBricksVolfiles(v, v.GetLocalBricks()); err != nil {
+			c.Logger().WithError(err).WithFields(log.Fields{
+				"template": namespace,
+				"volume":   v.Name,
+			}).Error("failed to generate brick volfiles")
+			return err
+		}
+	}
+	return nil
+}
+
+func txnGenerateBrickVolfiles(c transaction.TxnCtx) error {
+	change, err := getTemplateChange(c)
+	if err != nil {
+		return err
+	}
+
+	return generateBrickVolfiles(c, change.Namespace)
+}
+
+// txnNotifyVolfileChange notifies the clients to fetch their volfiles again
+// if any started volume uses the changed templates
+func txnNotifyVolfileChange(c transaction.TxnCtx) error {
+	change, err := getTemplateChange(c)
+	if err != nil {
+		return err
+	}
+
+	volumes, err := volume.GetVolumes(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	for _, v := range volumes {
+		if v.State == volume.VolStarted && usesTemplates(v, change.Namespace) {
+			sunrpc.FetchSpecNotify(c)
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Package txncommands implements the commands to inspect the pending
+// transactions and the transaction history
+package txncommands
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+	"github.com/gluster/glusterd2/pkg/api"
+	"github.com/gluster/glusterd2/pkg/utils"
+)
+
+// Command is a holding struct used to implement the GlusterD Command interface
+type Command struct {
+}
+
+// Routes returns command routes. Required for the Command interface.
+func (c *Command) Routes() route.Routes {
+	return route.Routes{
+		route.Route{
+			Name:         "TransactionList",
+			Method:       "GET",
+			Pattern:      "/transactions",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.TxnListResp)(nil)),
+			HandlerFunc:  txnListHandler,
+		},
+		route.Route{
+			Name:         "TransactionGet",
+			Method:       "GET",
+			Pattern:      "/transactions/{txnid}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.TxnGetResp)(nil)),
+			HandlerFunc:  txnGetHandler,
+		},
+	}
+}
+
+// RegisterStepFuncs implements a required function for the Command interface
+func (c *Command) RegisterStepFuncs() {
+	return
+}
@@ -0,0 +1,45 @@
+package txncommands
+
+import (
+	"net/http"
+
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/transactionv2"
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+)
+
+func txnListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	txns, err := transaction.ListTxnInfos()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, api.TxnListResp(txns))
+}
+
+func txnGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	txnid := uuid.Parse(mux.Vars(r)["txnid"])
+	if txnid == nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	txn, err := transaction.GetTxnInfo(txnid)
+	if err == transaction.ErrTxnNotFound {
+		restutils.SendHTTPError(ctx, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, (*api.TxnGetResp)(txn))
+}
@@ -0,0 +1,64 @@
+// Package usercommands implements the commands to manage REST API users
+package usercommands
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+	"github.com/gluster/glusterd2/pkg/api"
+	"github.com/gluster/glusterd2/pkg/utils"
+)
+
+// Command is a holding struct used to implement the GlusterD Command interface
+type Command struct {
+}
+
+// Routes returns command routes. Required for the Command interface.
+func (c *Command) Routes() route.Routes {
+	return route.Routes{
+		route.Route{
+			Name:         "UserCreate",
+			Method:       "POST",
+			Pattern:      "/users",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.UserCreateReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.UserCreateResp)(nil)),
+			HandlerFunc:  userCreateHandler,
+		},
+		route.Route{
+			Name:         "UserList",
+			Method:       "GET",
+			Pattern:      "/users",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.UserListResp)(nil)),
+			HandlerFunc:  userListHandler,
+		},
+		route.Route{
+			Name:         "UserGet",
+			Method:       "GET",
+			Pattern:      "/users/{username}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.UserGetResp)(nil)),
+			HandlerFunc:  userGetHandler,
+		},
+		route.Route{
+			Name:         "UserEdit",
+			Method:       "POST",
+			Pattern:      "/users/{username}",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.UserEditReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.UserEditResp)(nil)),
+			HandlerFunc:  userEditHandler,
+		},
+		route.Route{
+			Name:        "UserDelete",
+			Method:      "DELETE",
+			Pattern:     "/users/{username}",
+			Version:     1,
+			HandlerFunc: userDeleteHandler,
+		},
+	}
+}
+
+// RegisterStepFuncs implements a required function for the Command interface
+func (c *Command) RegisterStepFuncs() {
+	return
+}
@@ -0,0 +1,210 @@
+package usercommands
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/user"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	lockKey = "users"
+)
+
+func validateUserName(name string) error {
+	if name == "" {
+		return errors.New("user name is required")
+	}
+	if strings.ContainsAny(name, "/ ") {
+		return errors.New("user name can not contain '/' or spaces")
+	}
+	if name == user.InternalUser {
+		return fmt.Errorf("%s is a reserved user name", name)
+	}
+	return nil
+}
+
+// generateSecret returns a random secret in the same format as the local
+// auth token
+func generateSecret() (string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", data), nil
+}
+
+func createUserResp(u *user.User) *api.UserGetResp {
+	return &api.UserGetResp{
+		Name: u.Name,
+		Role: string(u.Role),
+	}
+}
+
+func userCreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req api.UserCreateReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	if err := validateUserName(req.Name); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	role := user.Role(req.Role)
+	if !role.IsValid() {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrInvalidRole)
+		return
+	}
+
+	if req.CertOnly && req.Secret != "" {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, errors.New("a secret can not be set for a user which only authenticates with a client certificate"))
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, lockKey)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	if user.Exists(req.Name) {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, gderrors.ErrUserExists)
+		return
+	}
+
+	u := &user.User{
+		Name:   req.Name,
+		Secret: req.Secret,
+		Role:   role,
+	}
+	if u.Secret == "" && !req.CertOnly {
+		if u.Secret, err = generateSecret(); err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	if err := user.AddOrUpdateUser(u); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := &api.UserCreateResp{
+		Name:   u.Name,
+		Secret: u.Secret,
+		Role:   string(u.Role),
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusCreated, resp)
+}
+
+func userListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	users, err := user.GetUsers()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make(api.UserListResp, 0, len(users))
+	for _, u := range users {
+		resp = append(resp, *createUserResp(u))
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func userGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := mux.Vars(r)["username"]
+
+	u, err := user.GetUser(username)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, createUserResp(u))
+}
+
+func userEditHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := mux.Vars(r)["username"]
+
+	var req api.UserEditReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	if req.Role != "" && !user.Role(req.Role).IsValid() {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrInvalidRole)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, lockKey)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	u, err := user.GetUser(username)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if req.Role != "" {
+		u.Role = user.Role(req.Role)
+	}
+	if req.Secret != "" {
+		u.Secret = req.Secret
+	}
+
+	if err := user.AddOrUpdateUser(u); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, (*api.UserEditResp)(createUserResp(u)))
+}
+
+func userDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := mux.Vars(r)["username"]
+
+	txn, err := transaction.NewTxnWithLocks(ctx, lockKey)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	if err := user.DeleteUser(username); err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusNoContent, nil)
+}
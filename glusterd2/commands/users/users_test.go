@@ -0,0 +1,24 @@
+package usercommands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUserName(t *testing.T) {
+	assert.Nil(t, validateUserName("monitoring"))
+	assert.NotNil(t, validateUserName(""))
+	assert.NotNil(t, validateUserName("a/b"))
+	assert.NotNil(t, validateUserName("glustercli"))
+}
+
+func TestGenerateSecret(t *testing.T) {
+	s1, err := generateSecret()
+	assert.Nil(t, err)
+	assert.Len(t, s1, 64)
+
+	s2, err := generateSecret()
+	assert.Nil(t, err)
+	assert.NotEqual(t, s1, s2)
+}
@@ -1,6 +1,8 @@
 package volumecommands
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gluster/glusterd2/glusterd2/brick"
@@ -33,14 +35,8 @@ func registerReplaceBrickStepFuncs() {
 
 func replaceBrickHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	logger := gdctx.GetReqLogger(ctx)
 	volname := mux.Vars(r)["volname"]
 
-	if !volume.IsValidName(volname) {
-		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrInvalidVolName)
-		return
-	}
-
 	// Unmarshal ReplaceBrickReq
 	var req api.ReplaceBrickReq
 	if err := restutils.UnmarshalRequest(r, &req); err != nil {
@@ -48,22 +44,79 @@ func replaceBrickHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if uuid.Parse(req.SrcPeerID) == nil {
-		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "invalid peerID passed in url")
+	dryRun, err := isDryRun(r)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
 		return
 	}
 
-	if err := validateVolumeFlags(req.Flags); err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+	if dryRun {
+		resp, status, err := replaceBrickDryRun(ctx, volname, req)
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+		restutils.SendHTTPResponse(ctx, w, status, resp)
 		return
 	}
 
+	vol, status, err := ReplaceBrick(ctx, volname, req)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	resp := createReplaceBrickResp(vol)
+	restutils.SendHTTPResponse(ctx, w, status, resp)
+}
+
+// replaceBrickPlan has the source brick of a replace brick request and the
+// new brick which the bricks planner chose to replace it
+type replaceBrickPlan struct {
+	vol          *volume.Volinfo
+	srcBrickInfo brick.Brickinfo
+	subVolIndex  int
+	brickIndex   int
+	newBrick     api.BrickReq
+}
+
+// setTxnCtx sets the plan in the txn context for the replace brick steps
+func (p *replaceBrickPlan) setTxnCtx(c transaction.TxnCtx) error {
+	if err := c.Set("newBrick", &p.newBrick); err != nil {
+		return err
+	}
+	if err := c.Set("srcBrickInfo", &p.srcBrickInfo); err != nil {
+		return err
+	}
+	if err := c.Set("subVolIndex", &p.subVolIndex); err != nil {
+		return err
+	}
+	if err := c.Set("brickIndex", &p.brickIndex); err != nil {
+		return err
+	}
+	return c.Set("volinfo", p.vol)
+}
+
+// planReplaceBrick validates the replace brick request and plans the new
+// brick of the volume
+func planReplaceBrick(volname string, req api.ReplaceBrickReq) (*replaceBrickPlan, int, error) {
+	if !volume.IsValidName(volname) {
+		return nil, http.StatusBadRequest, gderrors.ErrInvalidVolName
+	}
+
+	if uuid.Parse(req.SrcPeerID) == nil {
+		return nil, http.StatusBadRequest, errors.New("invalid peerID passed in url")
+	}
+
+	if err := validateVolumeFlags(req.Flags); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
 	// Get Volume Info
 	vol, err := volume.GetVolume(volname)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return nil, status, err
 	}
 
 	subVols := vol.Subvols
@@ -94,8 +147,7 @@ LOOP:
 		for _, b := range sv.Bricks {
 			p, err := peer.GetPeer(b.PeerID.String())
 			if err != nil {
-				restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-				return
+				return nil, http.StatusInternalServerError, err
 			}
 			excludeZones = append(excludeZones, p.Metadata["_zone"])
 		}
@@ -114,43 +166,56 @@ LOOP:
 	}
 	availableVgs, err := bricksplanner.GetAvailableVgs(&volreq)
 	if err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+		return nil, http.StatusInternalServerError, err
 	}
 	// TODO: check for available vgs in zones already being used in volume.
 	if len(availableVgs) == 0 {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, "No volume groups are available")
-		return
+		return nil, http.StatusInternalServerError, errors.New("No volume groups are available")
 	}
 
 	mtabEntries, err := volume.GetMounts()
 	if err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+		return nil, http.StatusInternalServerError, err
 	}
 
 	// Get source brick information like size etc
 	brickInfo, err := volume.BrickStatus(srcBrickInfo, mtabEntries)
 	if err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+		return nil, http.StatusInternalServerError, err
 	}
 
 	// Get new brick from the available vgs
 	newBrick := bricksplanner.GetNewBrick(availableVgs, brickInfo, vol, subVolIndex, brickIndex)
 
-	peerID := uuid.Parse(newBrick.PeerID)
-	if peerID == nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, "peer id of new brick could not be parsed")
-		return
+	if uuid.Parse(newBrick.PeerID) == nil {
+		return nil, http.StatusInternalServerError, errors.New("peer id of new brick could not be parsed")
 	}
-	allPeerIDs := vol.Nodes()
-	nodes := []uuid.UUID{peerID}
+
+	return &replaceBrickPlan{
+		vol:          vol,
+		srcBrickInfo: srcBrickInfo,
+		subVolIndex:  subVolIndex,
+		brickIndex:   brickIndex,
+		newBrick:     newBrick,
+	}, http.StatusOK, nil
+}
+
+// ReplaceBrick replaces a brick of the volume with a new brick provisioned
+// by the bricks planner
+func ReplaceBrick(ctx context.Context, volname string, req api.ReplaceBrickReq) (*volume.Volinfo, int, error) {
+	logger := gdctx.GetReqLogger(ctx)
+
+	plan, status, err := planReplaceBrick(volname, req)
+	if err != nil {
+		return nil, status, err
+	}
+
+	allPeerIDs := plan.vol.Nodes()
+	nodes := []uuid.UUID{uuid.Parse(plan.newBrick.PeerID)}
 	txn, err := transaction.NewTxnWithLocks(ctx, volname)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return nil, status, err
 	}
 	defer txn.Done()
 
@@ -185,37 +250,66 @@ LOOP:
 		},
 	}
 
-	if err = txn.Ctx.Set("newBrick", &newBrick); err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+	if err = plan.setTxnCtx(txn.Ctx); err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
-	if err = txn.Ctx.Set("srcBrickInfo", &srcBrickInfo); err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+
+	if err = txn.Do(); err != nil {
+		logger.WithError(err).WithField("volume-name", volname).Error("replace brick transaction failed")
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
 	}
-	if err = txn.Ctx.Set("subVolIndex", &subVolIndex); err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+
+	return plan.vol, http.StatusOK, nil
+}
+
+// replaceBrickDryRun plans the new brick of a replace brick request, without
+// provisioning the brick or replacing it in the volume
+func replaceBrickDryRun(ctx context.Context, volname string, req api.ReplaceBrickReq) (*api.VolumeDryRunResp, int, error) {
+	plan, status, err := planReplaceBrick(volname, req)
+	if err != nil {
+		return nil, status, err
 	}
-	if err = txn.Ctx.Set("brickIndex", &brickIndex); err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
 	}
-	if err = txn.Ctx.Set("volinfo", &vol); err != nil {
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
-		return
+	defer txn.Done()
+
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "brick-replace.ReplaceVolinfo",
+			Nodes:  []uuid.UUID{gdctx.MyUUID},
+		},
+	}
+
+	if err = plan.setTxnCtx(txn.Ctx); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err = txn.Ctx.Set(dryRunKey, true); err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
 
 	if err = txn.Do(); err != nil {
-		logger.WithError(err).WithField("volume-name", volname).Error("replace brick transaction failed")
 		status, err := restutils.ErrToStatusCode(err)
-		restutils.SendHTTPError(ctx, w, status, err)
-		return
+		return nil, status, err
+	}
+
+	var newVolinfo volume.Volinfo
+	if err = txn.Ctx.Get("volinfo", &newVolinfo); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	resp := &api.VolumeDryRunResp{
+		Bricks: []api.PlannedBrick{plannedBrickFromReq(plan.newBrick)},
+	}
+	if resp.Volfiles, err = volfileDiffs(plan.vol, &newVolinfo); err != nil {
+		return nil, http.StatusInternalServerError, err
 	}
-	resp := createReplaceBrickResp(vol)
-	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
 
-	return
+	return resp, http.StatusOK, nil
 }
 
 // Replace brick resp
@@ -30,6 +30,52 @@ func (c *Command) Routes() route.Routes {
 			RequestType:  utils.GetTypeString((*api.VolExpandReq)(nil)),
 			ResponseType: utils.GetTypeString((*api.VolumeExpandResp)(nil)),
 			HandlerFunc:  volumeExpandHandler},
+		route.Route{
+			Name:         "VolumeShrink",
+			Method:       "POST",
+			Pattern:      "/volumes/{volname}/shrink",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.VolShrinkReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.VolumeShrinkStatusResp)(nil)),
+			HandlerFunc:  volumeShrinkHandler},
+		route.Route{
+			Name:         "VolumeShrinkStatus",
+			Method:       "GET",
+			Pattern:      "/volumes/{volname}/shrink",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.VolumeShrinkStatusResp)(nil)),
+			HandlerFunc:  volumeShrinkStatusHandler},
+		route.Route{
+			Name:         "VolumeShrinkCommit",
+			Method:       "POST",
+			Pattern:      "/volumes/{volname}/shrink/commit",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.VolShrinkCommitReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.VolumeShrinkResp)(nil)),
+			HandlerFunc:  volumeShrinkCommitHandler},
+		route.Route{
+			Name:         "VolumeShrinkStop",
+			Method:       "POST",
+			Pattern:      "/volumes/{volname}/shrink/stop",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.VolumeShrinkResp)(nil)),
+			HandlerFunc:  volumeShrinkStopHandler},
+		route.Route{
+			Name:         "VolumeReshape",
+			Method:       "POST",
+			Pattern:      "/volumes/{volname}/reshape",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.VolReshapeReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.VolumeReshapeResp)(nil)),
+			HandlerFunc:  volumeReshapeHandler},
+		route.Route{
+			Name:         "VolumeRename",
+			Method:       "POST",
+			Pattern:      "/volumes/{volname}/rename",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.VolRenameReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.VolumeRenameResp)(nil)),
+			HandlerFunc:  volumeRenameHandler},
 		route.Route{
 			Name:         "VolumeOptionGet",
 			Method:       "GET",
@@ -107,6 +153,13 @@ func (c *Command) Routes() route.Routes {
 			Version:      1,
 			ResponseType: utils.GetTypeString((*api.VolumeStatusResp)(nil)),
 			HandlerFunc:  volumeStatusHandler},
+		route.Route{
+			Name:         "VolumeStatusDetail",
+			Method:       "GET",
+			Pattern:      "/volumes/{volname}/status/{option}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.VolumeStatusDetailResp)(nil)),
+			HandlerFunc:  volumeStatusDetailHandler},
 		route.Route{
 			Name:         "VolumeList",
 			Method:       "GET",
@@ -159,6 +212,35 @@ func (c *Command) Routes() route.Routes {
 			Version:      1,
 			ResponseType: utils.GetTypeString((*api.BrickProfileInfo)(nil)),
 			HandlerFunc:  volumeProfileHandler},
+		route.Route{
+			Name:         "VolumeTop",
+			Method:       "GET",
+			Pattern:      "/volumes/{volname}/top/{metric}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.VolumeTopResp)(nil)),
+			HandlerFunc:  volumeTopHandler},
+		route.Route{
+			Name:         "VolumeTopThroughput",
+			Method:       "POST",
+			Pattern:      "/volumes/{volname}/top/{metric}/throughput",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.VolumeTopThroughputReq)(nil)),
+			ResponseType: utils.GetTypeString((*api.VolumeTopResp)(nil)),
+			HandlerFunc:  volumeTopThroughputHandler},
+		route.Route{
+			Name:         "VolumeVolfileList",
+			Method:       "GET",
+			Pattern:      "/volumes/{volname}/volfiles",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.VolfileListResp)(nil)),
+			HandlerFunc:  volumeVolfileListHandler},
+		route.Route{
+			Name:         "VolumeVolfileGet",
+			Method:       "GET",
+			Pattern:      "/volumes/{volname}/volfiles/{volfileid}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.VolfileGetResp)(nil)),
+			HandlerFunc:  volumeVolfileGetHandler},
 	}
 }
 
@@ -169,10 +251,14 @@ func (c *Command) RegisterStepFuncs() {
 	registerVolStartStepFuncs()
 	registerVolStopStepFuncs()
 	registerBricksStatusStepFuncs()
+	registerVolStatusDetailStepFuncs()
 	registerVolExpandStepFuncs()
+	registerVolShrinkStepFuncs()
 	registerVolOptionStepFuncs()
 	registerVolOptionResetStepFuncs()
 	registerVolStatedumpFuncs()
 	registerReplaceBrickStepFuncs()
 	registerVolProfileStepFuncs()
+	registerVolTopStepFuncs()
+	registerVolRenameStepFuncs()
 }
@@ -200,6 +200,12 @@ func validateBricks(c transaction.TxnCtx) error {
 		return err
 	}
 
+	// Brick directories are not created for a dry run of the request
+	var dryRun bool
+	if err = c.Get(dryRunKey, &dryRun); err == nil {
+		checks.DryRun = dryRun
+	}
+
 	var allBricks []brick.Brickinfo
 	if err = c.Get("all-bricks-in-cluster", &allBricks); err != nil {
 		return err
@@ -0,0 +1,93 @@
+package volumecommands
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+// dryRunKey is set in the txn context when a request is a dry run
+const dryRunKey = "dry-run"
+
+// isDryRun returns true if the request was made with the dry-run=true query
+// parameter. A dry run of a request runs its planning and validations
+// cluster-wide, but commits nothing.
+func isDryRun(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("dry-run")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func plannedBrickFromReq(b api.BrickReq) api.PlannedBrick {
+	device := b.RootDevice
+	if device == "" {
+		device = b.DevicePath
+	}
+
+	return api.PlannedBrick{
+		PeerID:         b.PeerID,
+		Path:           b.Path,
+		Type:           b.Type,
+		Device:         device,
+		VgName:         b.VgName,
+		TpName:         b.TpName,
+		LvName:         b.LvName,
+		Size:           b.Size,
+		TpSize:         b.TpSize,
+		TpMetadataSize: b.TpMetadataSize,
+	}
+}
+
+func plannedBrickFromInfo(b brick.Brickinfo) api.PlannedBrick {
+	return api.PlannedBrick{
+		PeerID: b.PeerID.String(),
+		Path:   b.Path,
+		Type:   b.BrickTypeToString(),
+		Device: b.RootDevice,
+		VgName: b.VgName,
+		TpName: b.TpName,
+		LvName: b.LvName,
+	}
+}
+
+// volfileDiffs returns the changes to the volfiles of a volume from oldVol
+// to newVol. oldVol is nil for a volume which is yet to be created.
+func volfileDiffs(oldVol, newVol *volume.Volinfo) ([]api.VolfileDiff, error) {
+	var (
+		oldVolfiles map[string]string
+		err         error
+	)
+
+	if oldVol != nil {
+		if oldVolfiles, err = generateVolfiles(oldVol); err != nil {
+			return nil, err
+		}
+	}
+
+	newVolfiles, err := generateVolfiles(newVol)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := volgen.DiffVolfiles(oldVolfiles, newVolfiles)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]api.VolfileDiff, 0, len(diffs))
+	for id, diff := range diffs {
+		resp = append(resp, api.VolfileDiff{VolfileID: id, Diff: diff})
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].VolfileID < resp[j].VolfileID
+	})
+
+	return resp, nil
+}
@@ -0,0 +1,53 @@
+package volumecommands
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsDryRun validates isDryRun()
+func TestIsDryRun(t *testing.T) {
+	r := httptest.NewRequest("POST", "/v1/volumes", nil)
+	dryRun, err := isDryRun(r)
+	assert.Nil(t, err)
+	assert.False(t, dryRun)
+
+	r = httptest.NewRequest("POST", "/v1/volumes?dry-run=true", nil)
+	dryRun, err = isDryRun(r)
+	assert.Nil(t, err)
+	assert.True(t, dryRun)
+
+	r = httptest.NewRequest("POST", "/v1/volumes?dry-run=false", nil)
+	dryRun, err = isDryRun(r)
+	assert.Nil(t, err)
+	assert.False(t, dryRun)
+
+	r = httptest.NewRequest("POST", "/v1/volumes?dry-run=maybe", nil)
+	_, err = isDryRun(r)
+	assert.NotNil(t, err)
+}
+
+// TestPlannedBrickFromReq validates plannedBrickFromReq()
+func TestPlannedBrickFromReq(t *testing.T) {
+	b := api.BrickReq{
+		PeerID:     "peer",
+		Path:       "/bricks/b1",
+		VgName:     "vg1",
+		RootDevice: "/dev/vg1/lv1",
+		DevicePath: "/dev/sdb",
+		Size:       100,
+	}
+	planned := plannedBrickFromReq(b)
+	assert.Equal(t, "peer", planned.PeerID)
+	assert.Equal(t, "/dev/vg1/lv1", planned.Device)
+	assert.Equal(t, uint64(100), planned.Size)
+
+	// The device path is used for bricks yet to be provisioned
+	b.RootDevice = ""
+	planned = plannedBrickFromReq(b)
+	assert.Equal(t, "/dev/sdb", planned.Device)
+}
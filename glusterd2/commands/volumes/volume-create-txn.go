@@ -7,6 +7,7 @@ import (
 
 	"github.com/gluster/glusterd2/glusterd2/brick"
 	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
 	"github.com/gluster/glusterd2/glusterd2/volume"
 	"github.com/gluster/glusterd2/pkg/api"
 
@@ -164,6 +165,10 @@ func newVolinfo(req *api.VolCreateReq) (*volume.Volinfo, error) {
 		volinfo.Metadata = make(map[string]string)
 	}
 
+	if req.Template != "" {
+		volinfo.Metadata[volgen.TemplateMetadataKey] = req.Template
+	}
+
 	if req.Size > 0 {
 		//AutoProvisioned volume
 		volinfo.Metadata[brick.ProvisionKey] = string(brick.AutoProvisioned)
@@ -189,7 +194,7 @@ func createVolinfo(c transaction.TxnCtx) error {
 		if req.Options == nil {
 			req.Options = make(map[string]string)
 		}
-		req.Options["replicate.arbiter-count"] = fmt.Sprintf("%d", req.Subvols[0].ArbiterCount)
+		req.Options[arbiterCountOption] = fmt.Sprintf("%d", req.Subvols[0].ArbiterCount)
 	}
 
 	volinfo, err := newVolinfo(&req)
@@ -7,12 +7,14 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/gluster/glusterd2/glusterd2/audit"
 	"github.com/gluster/glusterd2/glusterd2/bricksplanner"
 	"github.com/gluster/glusterd2/glusterd2/events"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
 	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
 	"github.com/gluster/glusterd2/glusterd2/transaction"
 	transactionv2 "github.com/gluster/glusterd2/glusterd2/transactionv2"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
 	"github.com/gluster/glusterd2/glusterd2/volume"
 	"github.com/gluster/glusterd2/pkg/api"
 	gderrors "github.com/gluster/glusterd2/pkg/errors"
@@ -64,6 +66,12 @@ func validateVolCreateReq(req *api.VolCreateReq) error {
 		return gderrors.ErrMetadataSizeOutOfBounds
 	}
 
+	if req.Template != "" {
+		if _, exists := volgen.GetTemplates(req.Template); !exists {
+			return gderrors.ErrTemplateNotFound
+		}
+	}
+
 	return validateVolumeFlags(req.Flags)
 }
 
@@ -111,6 +119,23 @@ func volumeCreateHandler(w http.ResponseWriter, r *http.Request) {
 		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
 		return
 	}
+	audit.SetVolume(ctx, req.Name)
+
+	dryRun, err := isDryRun(r)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if dryRun {
+		resp, status, err := createVolumeDryRun(ctx, req)
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+		restutils.SendHTTPResponse(ctx, w, status, resp)
+		return
+	}
 
 	if status, err := CreateVolume(ctx, req); err != nil {
 		restutils.SendHTTPError(ctx, w, status, err)
@@ -138,17 +163,16 @@ func createVolumeCreateResp(v *volume.Volinfo) *api.VolumeCreateResp {
 	return (*api.VolumeCreateResp)(volume.CreateVolumeInfoResp(v))
 }
 
-// CreateVolume creates a volume
-func CreateVolume(ctx context.Context, req api.VolCreateReq) (status int, err error) {
-	ctx, span := trace.StartSpan(ctx, "/volumeCreateHandler")
-	defer span.End()
-
-	if err := validateVolCreateReq(&req); err != nil {
-		return http.StatusBadRequest, err
+// planVolumeCreate validates the volume create request, plans the bricks of
+// a smart volume and applies the default options. It returns the nodes of
+// the bricks of the volume.
+func planVolumeCreate(req *api.VolCreateReq) (nodes []uuid.UUID, status int, err error) {
+	if err := validateVolCreateReq(req); err != nil {
+		return nil, http.StatusBadRequest, err
 	}
 
 	if containsReservedGroupProfile(req.Options) {
-		return http.StatusBadRequest, gderrors.ErrReservedGroupProfile
+		return nil, http.StatusBadRequest, gderrors.ErrReservedGroupProfile
 	}
 
 	if req.ProvisionerType == "" {
@@ -156,28 +180,28 @@ func CreateVolume(ctx context.Context, req api.VolCreateReq) (status int, err er
 	}
 
 	if req.Size > 0 {
-		applyDefaults(&req)
+		applyDefaults(req)
 
 		if req.SnapshotReserveFactor < 1 {
-			return http.StatusBadRequest, errors.New("invalid snapshot reserve factor")
+			return nil, http.StatusBadRequest, errors.New("invalid snapshot reserve factor")
 		}
 
-		if err := bricksplanner.PlanBricks(&req); err != nil {
-			return http.StatusInternalServerError, err
+		if err := bricksplanner.PlanBricks(req); err != nil {
+			return nil, http.StatusInternalServerError, err
 		}
 	} else {
-		if err := checkDupBrickEntryVolCreate(req); err != nil {
-			return http.StatusBadRequest, err
+		if err := checkDupBrickEntryVolCreate(*req); err != nil {
+			return nil, http.StatusBadRequest, err
 		}
 	}
 
 	req.Options, err = expandGroupOptions(req.Options)
 	if err != nil {
-		return http.StatusInternalServerError, err
+		return nil, http.StatusInternalServerError, err
 	}
 
 	if err := validateOptions(req.Options, req.VolOptionFlags); err != nil {
-		return http.StatusBadRequest, err
+		return nil, http.StatusBadRequest, err
 	}
 
 	// Include default Volume Options profile
@@ -194,12 +218,32 @@ func CreateVolume(ctx context.Context, req api.VolCreateReq) (status int, err er
 		}
 	}
 
-	nodes, err := req.Nodes()
+	nodes, err = req.Nodes()
 	if err != nil {
-		return http.StatusBadRequest, err
+		return nil, http.StatusBadRequest, err
 	}
 
-	txn, err := transactionv2.NewTxnWithLocks(ctx, req.Name)
+	return nodes, http.StatusOK, nil
+}
+
+// CreateVolume creates a volume
+func CreateVolume(ctx context.Context, req api.VolCreateReq) (status int, err error) {
+	ctx, span := trace.StartSpan(ctx, "/volumeCreateHandler")
+	defer span.End()
+
+	nodes, status, err := planVolumeCreate(&req)
+	if err != nil {
+		return status, err
+	}
+
+	// The template of the volume is locked so that it is not deleted
+	// meanwhile
+	lockIDs := []string{req.Name}
+	if req.Template != "" {
+		lockIDs = append(lockIDs, volgen.TemplateLockID(req.Template))
+	}
+
+	txn, err := transactionv2.NewTxnWithLocks(ctx, lockIDs...)
 	if err != nil {
 		return restutils.ErrToStatusCode(err)
 	}
@@ -209,6 +253,12 @@ func CreateVolume(ctx context.Context, req api.VolCreateReq) (status int, err er
 		return http.StatusBadRequest, gderrors.ErrVolExists
 	}
 
+	if req.Template != "" {
+		if _, exists := volgen.GetTemplates(req.Template); !exists {
+			return http.StatusBadRequest, gderrors.ErrTemplateNotFound
+		}
+	}
+
 	txn.Steps = []*transaction.Step{
 		{
 			DoFunc:   "vol-create.PrepareBricks",
@@ -256,3 +306,76 @@ func CreateVolume(ctx context.Context, req api.VolCreateReq) (status int, err er
 
 	return http.StatusCreated, nil
 }
+
+// createVolumeDryRun runs the planning and the validations of a volume
+// create request cluster-wide, without creating the volume
+func createVolumeDryRun(ctx context.Context, req api.VolCreateReq) (*api.VolumeDryRunResp, int, error) {
+	ctx, span := trace.StartSpan(ctx, "/volumeCreateHandler")
+	defer span.End()
+
+	nodes, status, err := planVolumeCreate(&req)
+	if err != nil {
+		return nil, status, err
+	}
+
+	txn, err := transactionv2.NewTxnWithLocks(ctx, req.Name)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
+	}
+	defer txn.Done()
+
+	if volume.Exists(req.Name) {
+		return nil, http.StatusBadRequest, gderrors.ErrVolExists
+	}
+
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "vol-create.CreateVolinfo",
+			Nodes:  []uuid.UUID{gdctx.MyUUID},
+		},
+		{
+			DoFunc: "vol-create.ValidateBricks",
+			Nodes:  nodes,
+			// The bricks of a smart volume are yet to be provisioned
+			Skip: (req.Size > 0),
+			Sync: true,
+		},
+	}
+
+	if err := txn.Ctx.Set("req", &req); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := txn.Ctx.Set(dryRunKey, true); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	span.AddAttributes(
+		trace.StringAttribute("reqID", txn.Ctx.GetTxnReqID()),
+		trace.StringAttribute("volName", req.Name),
+	)
+
+	if err := txn.Do(); err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
+	}
+
+	var volinfo volume.Volinfo
+	if err := txn.Ctx.Get("volinfo", &volinfo); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	resp := new(api.VolumeDryRunResp)
+	for _, subvol := range req.Subvols {
+		for _, b := range subvol.Bricks {
+			resp.Bricks = append(resp.Bricks, plannedBrickFromReq(b))
+		}
+	}
+
+	if resp.Volfiles, err = volfileDiffs(nil, &volinfo); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return resp, http.StatusOK, nil
+}
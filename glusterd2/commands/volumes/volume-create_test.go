@@ -6,8 +6,10 @@ import (
 
 	"github.com/gluster/glusterd2/glusterd2/brick"
 	"github.com/gluster/glusterd2/glusterd2/peer"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
 	"github.com/gluster/glusterd2/glusterd2/volume"
 	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
 	"github.com/gluster/glusterd2/pkg/testutils"
 
 	"github.com/pborman/uuid"
@@ -43,3 +45,30 @@ func TestCreateVolinfo(t *testing.T) {
 	_, e = newVolinfo(msg)
 	assert.Equal(t, errBad, e)
 }
+
+// TestCreateVolinfoTemplate validates that the template selected in the
+// request is set in the volinfo
+func TestCreateVolinfoTemplate(t *testing.T) {
+	defer testutils.Patch(&peer.GetPeerIDByAddrF, peer.GetPeerIDByAddrMockGood).Restore()
+	defer testutils.Patch(&peer.GetPeerF, peer.GetPeerFMockGood).Restore()
+
+	msg := new(api.VolCreateReq)
+	u := uuid.NewRandom()
+	msg.Name = "vol"
+	msg.Subvols = []api.SubvolReq{{Bricks: []api.BrickReq{
+		{PeerID: u.String(), Path: "/tmp/b1"},
+	}}}
+
+	// Templates have to exist to be selected
+	msg.Template = "custom"
+	assert.Equal(t, gderrors.ErrTemplateNotFound, validateVolCreateReq(msg))
+
+	volgen.SetTemplates("custom", volgen.Templates{})
+	defer volgen.RemoveTemplates("custom")
+	assert.Nil(t, validateVolCreateReq(msg))
+
+	vol, e := newVolinfo(msg)
+	assert.Nil(t, e)
+	assert.Equal(t, "custom", vol.Metadata[volgen.TemplateMetadataKey])
+	assert.Equal(t, "custom", volgen.TemplateNamespace(vol))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gluster/glusterd2/glusterd2/events"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
 	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/snapshot"
 	"github.com/gluster/glusterd2/glusterd2/transaction"
 	transactionv2 "github.com/gluster/glusterd2/glusterd2/transactionv2"
 	"github.com/gluster/glusterd2/glusterd2/volume"
@@ -29,8 +30,20 @@ func deleteVolume(c transaction.TxnCtx) error {
 		return err
 	}
 
-	err = volume.DeleteVolume(volinfo.Name)
-	return err
+	if err = volume.DeleteVolume(volinfo.Name); err != nil {
+		return err
+	}
+
+	if err = snapshot.DeleteVolConfig(volinfo.ID.String()); err != nil {
+		c.Logger().WithError(err).WithField(
+			"volume", volinfo.Name).Warn("failed to delete snapshot config of volume")
+	}
+
+	if err = snapshot.DeleteVolSchedules(volinfo.Name); err != nil {
+		c.Logger().WithError(err).WithField(
+			"volume", volinfo.Name).Warn("failed to delete snapshot schedules of volume")
+	}
+	return nil
 }
 
 func registerVolDeleteStepFuncs() {
@@ -59,7 +72,18 @@ func DeleteVolume(ctx context.Context, volname string) (*volume.Volinfo, int, er
 	ctx, span := trace.StartSpan(ctx, "/volumeDeleteHandler")
 	defer span.End()
 
-	txn, err := transactionv2.NewTxnWithLocks(ctx, volname)
+	// The snapshot schedules of the volume are deleted along with it, they
+	// are locked so that they are not run meanwhile
+	schedules, err := snapshot.GetVolSchedules(volname)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	lockIDs := []string{volname}
+	for _, s := range schedules {
+		lockIDs = append(lockIDs, snapshot.ScheduleLockID(s.Name))
+	}
+
+	txn, err := transactionv2.NewTxnWithLocks(ctx, lockIDs...)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
 		return nil, status, err
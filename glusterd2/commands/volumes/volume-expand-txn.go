@@ -213,6 +213,26 @@ func updateVolinfoOnExpand(c transaction.TxnCtx) error {
 		return err
 	}
 
+	expandVolinfo(&volinfo, newBricks, newReplicaCount)
+
+	// update new volinfo in txn ctx
+	if err := c.Set("volinfo", volinfo); err != nil {
+		return err
+	}
+
+	// update new volinfo in etcd store and generate client volfile
+	if err := storeVolume(c); err != nil {
+		c.Logger().WithError(err).WithField(
+			"volume", volinfo.Name).Debug("storeVolume: failed to store volume info")
+		return err
+	}
+
+	return nil
+}
+
+// expandVolinfo adds the new bricks to the subvols of the volume, or as new
+// subvols of the volume
+func expandVolinfo(volinfo *volume.Volinfo, newBricks []brick.Brickinfo, newReplicaCount int) {
 	// TODO: Assumption, all subvols are same
 	// If New Replica count is different than existing then add one brick to each subvolume
 	// Or if the Volume consists of only one subvolume.
@@ -278,20 +298,6 @@ func updateVolinfoOnExpand(c transaction.TxnCtx) error {
 	}
 
 	volinfo.DistCount = len(volinfo.Subvols)
-
-	// update new volinfo in txn ctx
-	if err := c.Set("volinfo", volinfo); err != nil {
-		return err
-	}
-
-	// update new volinfo in etcd store and generate client volfile
-	if err := storeVolume(c); err != nil {
-		c.Logger().WithError(err).WithField(
-			"volume", volinfo.Name).Debug("storeVolume: failed to store volume info")
-		return err
-	}
-
-	return nil
 }
 
 func resizeLVM(c transaction.TxnCtx) error {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"path/filepath"
 
+	"github.com/gluster/glusterd2/glusterd2/brick"
 	"github.com/gluster/glusterd2/glusterd2/events"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
 	"github.com/gluster/glusterd2/glusterd2/peer"
@@ -84,6 +85,12 @@ func volumeExpandHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dryRun, err := isDryRun(r)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
 	txn, err := transaction.NewTxnWithLocks(ctx, volname)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
@@ -163,63 +170,80 @@ func volumeExpandHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	txn.Nodes = allNodes
-	txn.Steps = []*transaction.Step{
-		// TODO: This is a lot of steps. We can combine a few if we
-		// do not re-use the same step functions across multiple
-		// volume operations.
-		{
-			DoFunc: "vol-expand.ValidateAndPrepare",
-			Nodes:  []uuid.UUID{gdctx.MyUUID},
-			Skip:   lvmResizeOp,
-		},
-		{
-			DoFunc: "vol-expand.ValidateBricks",
-			Nodes:  nodes,
-			Skip:   lvmResizeOp,
-			// Need to wait for newly selected bricks to be set by the previous step
-			Sync: true,
-		},
-		{
-			DoFunc:   "vol-expand.InitBricks",
-			UndoFunc: "vol-expand.UndoInitBricks",
-			Nodes:    nodes,
-			Skip:     lvmResizeOp,
-		},
-		{
-			DoFunc: "vol-expand.LvmResize",
-			Nodes:  volinfo.Nodes(),
-			Skip:   !lvmResizeOp,
-		},
-		{
-			DoFunc:   "vol-create.StoreVolume",
-			UndoFunc: "vol-create.UndoStoreVolume",
-			Nodes:    []uuid.UUID{gdctx.MyUUID},
-			Skip:     !lvmResizeOp,
-			Sync:     true,
-		},
-		{
-			DoFunc: "vol-expand.UpdateVolinfo",
-			Nodes:  []uuid.UUID{gdctx.MyUUID},
-			Skip:   lvmResizeOp,
-			Sync:   true,
-		},
-		{
-			DoFunc:   "vol-expand.GenerateBrickVolfiles",
-			UndoFunc: "vol-expand.GenerateBrickVolfiles.Undo",
-			Nodes:    nodes,
-			Skip:     (volinfo.State != volume.VolStarted),
-		},
-		{
-			DoFunc:   "vol-expand.StartBrick",
-			Nodes:    nodes,
-			UndoFunc: "vol-expand.UndoStartBrick",
-			Skip:     lvmResizeOp,
-		},
-		{
-			DoFunc: "vol-expand.NotifyClients",
-			Nodes:  allNodes,
-			Skip:   lvmResizeOp,
-		},
+	if dryRun {
+		// Only the validations are run for a dry run of the request
+		txn.Steps = []*transaction.Step{
+			{
+				DoFunc: "vol-expand.ValidateAndPrepare",
+				Nodes:  []uuid.UUID{gdctx.MyUUID},
+				Skip:   lvmResizeOp,
+			},
+			{
+				DoFunc: "vol-expand.ValidateBricks",
+				Nodes:  nodes,
+				Skip:   lvmResizeOp,
+				Sync:   true,
+			},
+		}
+	} else {
+		txn.Steps = []*transaction.Step{
+			// TODO: This is a lot of steps. We can combine a few if we
+			// do not re-use the same step functions across multiple
+			// volume operations.
+			{
+				DoFunc: "vol-expand.ValidateAndPrepare",
+				Nodes:  []uuid.UUID{gdctx.MyUUID},
+				Skip:   lvmResizeOp,
+			},
+			{
+				DoFunc: "vol-expand.ValidateBricks",
+				Nodes:  nodes,
+				Skip:   lvmResizeOp,
+				// Need to wait for newly selected bricks to be set by the previous step
+				Sync: true,
+			},
+			{
+				DoFunc:   "vol-expand.InitBricks",
+				UndoFunc: "vol-expand.UndoInitBricks",
+				Nodes:    nodes,
+				Skip:     lvmResizeOp,
+			},
+			{
+				DoFunc: "vol-expand.LvmResize",
+				Nodes:  volinfo.Nodes(),
+				Skip:   !lvmResizeOp,
+			},
+			{
+				DoFunc:   "vol-create.StoreVolume",
+				UndoFunc: "vol-create.UndoStoreVolume",
+				Nodes:    []uuid.UUID{gdctx.MyUUID},
+				Skip:     !lvmResizeOp,
+				Sync:     true,
+			},
+			{
+				DoFunc: "vol-expand.UpdateVolinfo",
+				Nodes:  []uuid.UUID{gdctx.MyUUID},
+				Skip:   lvmResizeOp,
+				Sync:   true,
+			},
+			{
+				DoFunc:   "vol-expand.GenerateBrickVolfiles",
+				UndoFunc: "vol-expand.GenerateBrickVolfiles.Undo",
+				Nodes:    nodes,
+				Skip:     (volinfo.State != volume.VolStarted),
+			},
+			{
+				DoFunc:   "vol-expand.StartBrick",
+				Nodes:    nodes,
+				UndoFunc: "vol-expand.UndoStartBrick",
+				Skip:     lvmResizeOp,
+			},
+			{
+				DoFunc: "vol-expand.NotifyClients",
+				Nodes:  allNodes,
+				Skip:   lvmResizeOp,
+			},
+		}
 	}
 
 	if err := txn.Ctx.Set("req", &req); err != nil {
@@ -247,6 +271,13 @@ func volumeExpandHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if dryRun {
+		if err := txn.Ctx.Set(dryRunKey, true); err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
 	// Add relevant attributes to the root span
 	var bricksToAdd string
 	for _, b := range req.Bricks {
@@ -266,6 +297,17 @@ func volumeExpandHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if dryRun {
+		resp, err := expandVolumeDryRunResp(txn.Ctx, volinfo, req, lvmResizeOp,
+			expansionTpSizePerBrick, expansionMetadataSizePerBrick)
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+		restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+		return
+	}
+
 	volinfo, err = volume.GetVolume(volname)
 	if err != nil {
 		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
@@ -279,6 +321,53 @@ func volumeExpandHandler(w http.ResponseWriter, r *http.Request) {
 	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
 }
 
+// expandVolumeDryRunResp returns the planned changes of a dry run of the
+// volume expand request. For an lvm resize, the sizes of the planned bricks
+// are the growth of each of the existing bricks.
+func expandVolumeDryRunResp(c transaction.TxnCtx, volinfo *volume.Volinfo, req api.VolExpandReq, lvmResizeOp bool,
+	expansionTpSizePerBrick, expansionMetadataSizePerBrick uint64) (*api.VolumeDryRunResp, error) {
+
+	resp := new(api.VolumeDryRunResp)
+	if lvmResizeOp {
+		for _, b := range volinfo.GetBricks() {
+			planned := plannedBrickFromInfo(b)
+			planned.TpSize = expansionTpSizePerBrick
+			planned.TpMetadataSize = expansionMetadataSizePerBrick
+			resp.Bricks = append(resp.Bricks, planned)
+		}
+		resp.Volfiles = []api.VolfileDiff{}
+		return resp, nil
+	}
+
+	for _, b := range req.Bricks {
+		resp.Bricks = append(resp.Bricks, plannedBrickFromReq(b))
+	}
+
+	var newBricks []brick.Brickinfo
+	if err := c.Get("bricks", &newBricks); err != nil {
+		return nil, err
+	}
+
+	var newVolinfo volume.Volinfo
+	if err := c.Get("volinfo", &newVolinfo); err != nil {
+		return nil, err
+	}
+
+	var newReplicaCount int
+	if err := c.Get("newreplicacount", &newReplicaCount); err != nil {
+		return nil, err
+	}
+
+	expandVolinfo(&newVolinfo, newBricks, newReplicaCount)
+
+	var err error
+	if resp.Volfiles, err = volfileDiffs(volinfo, &newVolinfo); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func createVolumeExpandResp(v *volume.Volinfo) *api.VolumeExpandResp {
 	return (*api.VolumeExpandResp)(volume.CreateVolumeInfoResp(v))
 }
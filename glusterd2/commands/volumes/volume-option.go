@@ -211,6 +211,12 @@ func volumeOptionsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dryRun, err := isDryRun(r)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
 	txn, err := transaction.NewTxnWithLocks(ctx, volname)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
@@ -264,6 +270,10 @@ func volumeOptionsHandler(w http.ResponseWriter, r *http.Request) {
 			Nodes:  allNodes,
 		},
 	}
+	if dryRun {
+		// Only the options are validated for a dry run of the request
+		txn.Steps = txn.Steps[:1]
+	}
 
 	if err := txn.Ctx.Set("req", &req); err != nil {
 		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
@@ -275,6 +285,13 @@ func volumeOptionsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if dryRun {
+		if err := txn.Ctx.Set(dryRunKey, true); err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
 	// Add relevant attributes to the span
 	var optionToSet string
 	for option, value := range req.Options {
@@ -294,6 +311,24 @@ func volumeOptionsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if dryRun {
+		var newVolinfo volume.Volinfo
+		if err := txn.Ctx.Get("volinfo", &newVolinfo); err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		diffs, err := volfileDiffs(volinfo, &newVolinfo)
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp := &api.VolumeDryRunResp{Bricks: []api.PlannedBrick{}, Volfiles: diffs}
+		restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+		return
+	}
+
 	volinfo, err = volume.GetVolume(volname)
 	if err != nil {
 		status, err := restutils.ErrToStatusCode(err)
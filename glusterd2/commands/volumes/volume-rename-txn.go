@@ -0,0 +1,133 @@
+package volumecommands
+
+import (
+	"strings"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/snapshot"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/plugins/georeplication"
+	"github.com/gluster/glusterd2/plugins/rebalance"
+)
+
+// renameVolinfo returns a copy of the volinfo with every reference to the
+// volume name replaced by newname
+func renameVolinfo(volinfo *volume.Volinfo, newname string) *volume.Volinfo {
+	renamed := *volinfo
+	renamed.Name = newname
+	if volinfo.VolfileID == volinfo.Name {
+		renamed.VolfileID = newname
+	}
+
+	renamed.Subvols = make([]volume.Subvol, len(volinfo.Subvols))
+	for sidx, sv := range volinfo.Subvols {
+		if strings.HasPrefix(sv.Name, volinfo.Name+"-") {
+			sv.Name = newname + strings.TrimPrefix(sv.Name, volinfo.Name)
+		}
+		sv.Bricks = append([]brick.Brickinfo(nil), sv.Bricks...)
+		for bidx := range sv.Bricks {
+			sv.Bricks[bidx].VolumeName = renamed.Name
+			sv.Bricks[bidx].VolfileID = renamed.VolfileID
+		}
+		renamed.Subvols[sidx] = sv
+	}
+
+	return &renamed
+}
+
+// renameVolumeInStore moves the volume to its new key in the store and
+// updates the objects which refer to the volume by name
+func renameVolumeInStore(volinfo, oldvolinfo *volume.Volinfo) error {
+	if err := volume.AddOrUpdateVolumeFunc(volinfo); err != nil {
+		return err
+	}
+
+	if err := volume.DeleteVolume(oldvolinfo.Name); err != nil {
+		return err
+	}
+
+	snaps, err := snapshot.GetSnapshots()
+	if err != nil {
+		return err
+	}
+	for _, snap := range snaps {
+		if snap.ParentVolume != oldvolinfo.Name {
+			continue
+		}
+		snap.ParentVolume = volinfo.Name
+		if err := snapshot.AddOrUpdateSnap(snap); err != nil {
+			return err
+		}
+	}
+
+	schedules, err := snapshot.GetSchedules()
+	if err != nil {
+		return err
+	}
+	for _, s := range schedules {
+		if s.VolName != oldvolinfo.Name {
+			continue
+		}
+		s.VolName = volinfo.Name
+		if err := snapshot.AddOrUpdateSchedule(s); err != nil {
+			return err
+		}
+	}
+
+	// Rebalance info is not found if rebalance was never run on the volume
+	if rinfo, err := rebalance.GetRebalanceInfo(oldvolinfo.Name); err == nil {
+		rinfo.Volname = volinfo.Name
+		if err := rebalance.StoreRebalanceInfo(rinfo); err != nil {
+			return err
+		}
+		if err := rebalance.DeleteRebalanceInfo(oldvolinfo.Name); err != nil {
+			return err
+		}
+	}
+
+	return georeplication.RenameMasterVolume(volinfo.ID, oldvolinfo.Name, volinfo.Name)
+}
+
+func storeRenamedVolume(c transaction.TxnCtx) error {
+	var volinfo, oldvolinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		return err
+	}
+	if err := c.Get("oldvolinfo", &oldvolinfo); err != nil {
+		return err
+	}
+
+	return renameVolumeInStore(&volinfo, &oldvolinfo)
+}
+
+func undoStoreRenamedVolume(c transaction.TxnCtx) error {
+	var volinfo, oldvolinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		return err
+	}
+	if err := c.Get("oldvolinfo", &oldvolinfo); err != nil {
+		return err
+	}
+
+	return renameVolumeInStore(&oldvolinfo, &volinfo)
+}
+
+// updateRenamedVolfiles removes the volfiles of the local bricks generated
+// under the old volume name and generates them under the new name
+func updateRenamedVolfiles(c transaction.TxnCtx) error {
+	var volinfo, oldvolinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		return err
+	}
+	if err := c.Get("oldvolinfo", &oldvolinfo); err != nil {
+		return err
+	}
+
+	if err := volgen.DeleteBricksVolfiles(oldvolinfo.GetLocalBricks()); err != nil {
+		return err
+	}
+
+	return volgen.GenerateBricksVolfiles(&volinfo, volinfo.GetLocalBricks())
+}
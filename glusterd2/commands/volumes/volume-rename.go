@@ -0,0 +1,154 @@
+package volumecommands
+
+import (
+	"net/http"
+
+	"github.com/gluster/glusterd2/glusterd2/events"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+	"github.com/gluster/glusterd2/plugins/georeplication"
+
+	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+)
+
+func registerVolRenameStepFuncs() {
+	var sfs = []struct {
+		name string
+		sf   transaction.StepFunc
+	}{
+		{"vol-rename.Store", storeRenamedVolume},
+		{"vol-rename.UndoStore", undoStoreRenamedVolume},
+		{"vol-rename.UpdateVolfiles", updateRenamedVolfiles},
+	}
+	for _, sf := range sfs {
+		transaction.RegisterStepFunc(sf.sf, sf.name)
+	}
+}
+
+// volumeRenameHandler renames a stopped volume. The volume is moved to its
+// new key in the store along with the snapshots, snapshot schedules,
+// rebalance info and geo-replication sessions referring to it by name.
+func volumeRenameHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	volname := mux.Vars(r)["volname"]
+
+	var req api.VolRenameReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	if !volume.IsValidName(req.NewName) {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrInvalidVolName)
+		return
+	}
+
+	if req.NewName == volname {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "new volume name is same as the current name")
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname, req.NewName)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if status, err := validateVolumeRename(volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if volume.Exists(req.NewName) {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, gderrors.ErrVolExists)
+		return
+	}
+
+	if err := georeplication.CheckMasterVolumeRename(volinfo.ID); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, err)
+		return
+	}
+
+	georepSessions, err := georeplication.MasterSessions(volinfo.ID)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	newvolinfo := renameVolinfo(volinfo, req.NewName)
+
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc:   "vol-rename.Store",
+			UndoFunc: "vol-rename.UndoStore",
+			Nodes:    []uuid.UUID{gdctx.MyUUID},
+			Sync:     true,
+		},
+		{
+			DoFunc: "vol-rename.UpdateVolfiles",
+			Nodes:  newvolinfo.Nodes(),
+		},
+		{
+			DoFunc: "georeplication-rename.Commit",
+			Nodes:  newvolinfo.Nodes(),
+			Skip:   len(georepSessions) == 0,
+		},
+	}
+
+	if err := txn.Ctx.Set("volinfo", newvolinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("oldvolinfo", volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("volume-name", volname).Error("volume rename transaction failed")
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	logger.WithField("volume-name", volname).WithField("new-name", req.NewName).Info("volume renamed")
+	events.Broadcast(volume.NewEvent(volume.EventVolumeRenamed, newvolinfo))
+
+	resp := createVolumeRenameResp(newvolinfo)
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+// validateVolumeRename checks if the volume can be renamed. Block volumes
+// and the mounts of the block provider refer to the hosting volume by name,
+// so a volume is not renamed while it hosts block volumes.
+func validateVolumeRename(volinfo *volume.Volinfo) (int, error) {
+	if volinfo.State == volume.VolStarted {
+		return http.StatusBadRequest, gderrors.ErrVolNotStopped
+	}
+
+	if volinfo.HostsBlockVolumes() {
+		return http.StatusConflict, gderrors.ErrVolHostsBlockVolumes
+	}
+
+	return http.StatusOK, nil
+}
+
+func createVolumeRenameResp(v *volume.Volinfo) *api.VolumeRenameResp {
+	return (*api.VolumeRenameResp)(volume.CreateVolumeInfoResp(v))
+}
@@ -0,0 +1,65 @@
+package volumecommands
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRenameVolinfo validates renameVolinfo()
+func TestRenameVolinfo(t *testing.T) {
+	v := newDistReplicateVolinfo(uuid.NewRandom(), 2)
+	v.VolfileID = v.Name
+	v.Subvols[1].Name = "custom-subvol"
+
+	renamed := renameVolinfo(v, "newvol")
+	assert.Equal(t, "newvol", renamed.Name)
+	assert.Equal(t, "newvol", renamed.VolfileID)
+	assert.Equal(t, "newvol-replicate-0", renamed.Subvols[0].Name)
+	assert.Equal(t, "custom-subvol", renamed.Subvols[1].Name)
+	for _, b := range renamed.GetBricks() {
+		assert.Equal(t, "newvol", b.VolumeName)
+		assert.Equal(t, "newvol", b.VolfileID)
+	}
+
+	// The original volinfo is left untouched
+	assert.Equal(t, "vol", v.Name)
+	assert.Equal(t, "vol-replicate-0", v.Subvols[0].Name)
+	assert.Equal(t, "", v.Subvols[0].Bricks[0].VolumeName)
+
+	// A custom volfile ID is retained
+	v.VolfileID = "custom-volfile"
+	renamed = renameVolinfo(v, "newvol")
+	assert.Equal(t, "custom-volfile", renamed.VolfileID)
+	assert.Equal(t, "custom-volfile", renamed.Subvols[0].Bricks[0].VolfileID)
+}
+
+// TestValidateVolumeRename checks that started volumes and volumes hosting
+// block volumes are not renamed
+func TestValidateVolumeRename(t *testing.T) {
+	v := newDistReplicateVolinfo(uuid.NewRandom(), 1)
+	v.State = volume.VolStopped
+	v.Metadata = map[string]string{volume.BlockHosting: "yes"}
+
+	status, err := validateVolumeRename(v)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	v.State = volume.VolStarted
+	status, err = validateVolumeRename(v)
+	assert.Equal(t, gderrors.ErrVolNotStopped, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	v.State = volume.VolStopped
+	for _, key := range []string{volume.BlockPrefix + "blk1", volume.BlockSnapPrefix + "blk1/snap1", volume.BlockClonePrefix + "clone1"} {
+		v.Metadata = map[string]string{volume.BlockHosting: "yes", key: "1024"}
+		status, err = validateVolumeRename(v)
+		assert.Equal(t, gderrors.ErrVolHostsBlockVolumes, err, key)
+		assert.Equal(t, http.StatusConflict, status, key)
+	}
+}
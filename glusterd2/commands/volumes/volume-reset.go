@@ -47,6 +47,12 @@ func volumeResetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	dryRun, err := isDryRun(r)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
 	volname := mux.Vars(r)["volname"]
 	volinfo, err := volume.GetVolume(volname)
 	if err != nil {
@@ -144,6 +150,27 @@ func volumeResetHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if dryRun {
+		// The options to reset have been validated, compare the volfiles
+		// of the volume with and without them
+		curVolinfo, err := volume.GetVolume(volname)
+		if err != nil {
+			status, err := restutils.ErrToStatusCode(err)
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+
+		diffs, err := volfileDiffs(curVolinfo, volinfo)
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp := &api.VolumeDryRunResp{Bricks: []api.PlannedBrick{}, Volfiles: diffs}
+		restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+		return
+	}
+
 	allNodes, err := peer.GetPeerIDs()
 	if err != nil {
 		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
@@ -0,0 +1,220 @@
+package volumecommands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/pborman/uuid"
+)
+
+// arbiterCountOption tells the replicate xlator how many of the bricks of a
+// subvolume are arbiter bricks
+const arbiterCountOption = "replicate.arbiter-count"
+
+// reshapeVolinfo returns the volinfo with the replica count changed to
+// replicaCount, with or without an arbiter brick, along with a volinfo
+// containing the bricks removed, if any, and the list of bricks added.
+// Exactly one brick is added to or removed from every subvolume, so bricks
+// has one entry per subvolume, listed in subvolume order.
+func reshapeVolinfo(volinfo *volume.Volinfo, replicaCount int, arbiter bool, bricks []brick.Brickinfo) (*volume.Volinfo, *volume.Volinfo, []brick.Brickinfo, error) {
+	if volinfo.Type != volume.Distribute && volinfo.Type != volume.Replicate && volinfo.Type != volume.DistReplicate {
+		return nil, nil, nil, errors.New("replica count can only be changed for distribute and replicate volumes")
+	}
+
+	if replicaCount < 1 {
+		return nil, nil, nil, errors.New("invalid replica count")
+	}
+
+	arbiterCount := 0
+	if arbiter {
+		if replicaCount != 2 {
+			return nil, nil, nil, errors.New("arbiter is supported only with replica count 2")
+		}
+		arbiterCount = 1
+	}
+
+	// TODO: Assumption, all subvols are same
+	curReplicaCount := volinfo.Subvols[0].ReplicaCount
+	if curReplicaCount == 0 {
+		curReplicaCount = 1
+	}
+	curArbiterCount := volinfo.Subvols[0].ArbiterCount
+
+	switch (replicaCount + arbiterCount) - (curReplicaCount + curArbiterCount) {
+	case 1:
+		if arbiterCount < curArbiterCount {
+			break
+		}
+		return addReplicaBricks(volinfo, replicaCount, arbiterCount > curArbiterCount, bricks)
+	case -1:
+		if arbiterCount > curArbiterCount {
+			break
+		}
+		return removeReplicaBricks(volinfo, replicaCount, arbiterCount < curArbiterCount, bricks)
+	case 0:
+		if arbiterCount == curArbiterCount {
+			return nil, nil, nil, errors.New("replica count is same")
+		}
+	}
+
+	return nil, nil, nil, errors.New("only one brick per subvolume can be added or removed")
+}
+
+// addReplicaBricks adds one brick to every subvolume of the volume. Each brick
+// of a plain distribute volume becomes a replicate subvolume of its own.
+func addReplicaBricks(volinfo *volume.Volinfo, replicaCount int, addArbiter bool, bricks []brick.Brickinfo) (*volume.Volinfo, *volume.Volinfo, []brick.Brickinfo, error) {
+	existing := make(map[string]bool)
+	for _, b := range volinfo.GetBricks() {
+		existing[b.PeerID.String()+":"+b.Path] = true
+	}
+	for _, b := range bricks {
+		if existing[b.PeerID.String()+":"+b.Path] {
+			return nil, nil, nil, gderrors.ErrDuplicateBrickPath
+		}
+	}
+
+	reshaped := *volinfo
+	reshaped.Subvols = nil
+
+	added := make([]brick.Brickinfo, len(bricks))
+	copy(added, bricks)
+
+	if volinfo.Subvols[0].Type == volume.SubvolDistribute {
+		oldBricks := volinfo.GetBricks()
+		if len(bricks) != len(oldBricks) {
+			return nil, nil, nil, fmt.Errorf("%d bricks are needed, one for every brick of the volume", len(oldBricks))
+		}
+		for idx, b := range oldBricks {
+			reshaped.Subvols = append(reshaped.Subvols, volume.Subvol{
+				ID:           uuid.NewRandom(),
+				Name:         fmt.Sprintf("%s-%s-%d", volinfo.Name, strings.ToLower(volume.SubvolReplicate.String()), idx),
+				Type:         volume.SubvolReplicate,
+				ReplicaCount: replicaCount,
+				Bricks:       []brick.Brickinfo{b, added[idx]},
+			})
+		}
+	} else {
+		if len(bricks) != len(volinfo.Subvols) {
+			return nil, nil, nil, fmt.Errorf("%d bricks are needed, one for every subvolume of the volume", len(volinfo.Subvols))
+		}
+		for idx, sv := range volinfo.Subvols {
+			if addArbiter {
+				added[idx].Type = brick.Arbiter
+				sv.ArbiterCount = 1
+			}
+			sv.ReplicaCount = replicaCount
+			sv.Bricks = append(append([]brick.Brickinfo(nil), sv.Bricks...), added[idx])
+			reshaped.Subvols = append(reshaped.Subvols, sv)
+		}
+	}
+
+	reshaped.DistCount = len(reshaped.Subvols)
+	if reshaped.DistCount == 1 {
+		reshaped.Type = volume.Replicate
+	} else {
+		reshaped.Type = volume.DistReplicate
+	}
+	setArbiterCountOption(&reshaped)
+
+	return &reshaped, nil, added, nil
+}
+
+// removeReplicaBricks removes one brick from every subvolume of the volume.
+// Going down to replica 1 turns the volume into a plain distribute volume.
+func removeReplicaBricks(volinfo *volume.Volinfo, replicaCount int, removeArbiter bool, bricks []brick.Brickinfo) (*volume.Volinfo, *volume.Volinfo, []brick.Brickinfo, error) {
+	if len(bricks) != len(volinfo.Subvols) {
+		return nil, nil, nil, fmt.Errorf("%d bricks are needed, one for every subvolume of the volume", len(volinfo.Subvols))
+	}
+
+	requested := make(map[string]bool)
+	for _, b := range bricks {
+		key := b.PeerID.String() + ":" + b.Path
+		if requested[key] {
+			return nil, nil, nil, gderrors.ErrDuplicateBrickPath
+		}
+		requested[key] = true
+	}
+
+	reshaped := *volinfo
+	removed := *volinfo
+	reshaped.Subvols = nil
+	removed.Subvols = nil
+
+	for _, sv := range volinfo.Subvols {
+		kept, gone := sv, sv
+		kept.Bricks, gone.Bricks = nil, nil
+		for _, b := range sv.Bricks {
+			key := b.PeerID.String() + ":" + b.Path
+			if !requested[key] {
+				kept.Bricks = append(kept.Bricks, b)
+				continue
+			}
+			if removeArbiter != (b.Type == brick.Arbiter) {
+				if removeArbiter {
+					return nil, nil, nil, fmt.Errorf("brick %s is not an arbiter brick", key)
+				}
+				return nil, nil, nil, fmt.Errorf("arbiter brick %s can only be removed along with the arbiter", key)
+			}
+			gone.Bricks = append(gone.Bricks, b)
+			delete(requested, key)
+		}
+
+		if len(gone.Bricks) != 1 {
+			return nil, nil, nil, fmt.Errorf("exactly one brick has to be removed from subvolume %s", sv.Name)
+		}
+
+		if removeArbiter {
+			kept.ArbiterCount = 0
+		}
+		kept.ReplicaCount = replicaCount
+		reshaped.Subvols = append(reshaped.Subvols, kept)
+		removed.Subvols = append(removed.Subvols, gone)
+	}
+
+	for key := range requested {
+		return nil, nil, nil, fmt.Errorf("brick %s is not part of volume %s", key, volinfo.Name)
+	}
+
+	if replicaCount == 1 {
+		reshaped.Subvols = []volume.Subvol{{
+			ID:           uuid.NewRandom(),
+			Name:         fmt.Sprintf("%s-%s-%d", volinfo.Name, strings.ToLower(volume.SubvolDistribute.String()), 0),
+			Type:         volume.SubvolDistribute,
+			ReplicaCount: 1,
+			Bricks:       reshaped.GetBricks(),
+		}}
+		reshaped.Type = volume.Distribute
+	}
+
+	reshaped.DistCount = len(reshaped.Subvols)
+	if reshaped.Type == volume.DistReplicate && reshaped.DistCount == 1 {
+		reshaped.Type = volume.Replicate
+	}
+	setArbiterCountOption(&reshaped)
+
+	return &reshaped, &removed, nil, nil
+}
+
+// setArbiterCountOption sets the arbiter count option of the replicate
+// xlator from the arbiter count of the subvolumes, as done when the volume
+// is created, or removes it if the volume has no arbiter. The options are
+// copied as they are shared with the volinfo which was reshaped.
+func setArbiterCountOption(v *volume.Volinfo) {
+	options := make(map[string]string, len(v.Options)+1)
+	for key, value := range v.Options {
+		options[key] = value
+	}
+
+	if v.Subvols[0].ArbiterCount > 0 {
+		options[arbiterCountOption] = strconv.Itoa(v.Subvols[0].ArbiterCount)
+	} else {
+		delete(options, arbiterCountOption)
+	}
+	v.Options = options
+}
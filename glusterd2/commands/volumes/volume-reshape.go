@@ -0,0 +1,253 @@
+package volumecommands
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/events"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/peer"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+	"github.com/gluster/glusterd2/plugins/glustershd"
+	glustershdapi "github.com/gluster/glusterd2/plugins/glustershd/api"
+
+	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+)
+
+// volumeReshapeHandler changes the replica count of a volume by adding or
+// removing one brick per subvolume. A full self-heal is started once bricks
+// have been added so that the new replicas get populated.
+func volumeReshapeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	volname := mux.Vars(r)["volname"]
+
+	var req api.VolReshapeReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	if err := validateVolumeFlags(req.Flags); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if len(decommissionedBricks(volinfo)) > 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, gderrors.ErrVolShrinkInProgress)
+		return
+	}
+
+	bricks, err := volume.NewBrickEntriesFunc(req.Bricks, volinfo.Name, volinfo.VolfileID, volinfo.ID, brick.ManuallyProvisioned)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	newvolinfo, removed, added, err := reshapeVolinfo(volinfo, req.ReplicaCount, req.Arbiter, bricks)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	var addedNodes []uuid.UUID
+	if len(added) > 0 {
+		addedNodes, err = req.Nodes()
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	var removedNodes []uuid.UUID
+	if removed != nil {
+		removedNodes = removed.Nodes()
+	}
+
+	allBricks, err := volume.GetAllBricksInCluster()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	allNodes, err := peer.GetPeerIDs()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	started := volinfo.State == volume.VolStarted
+	bricksAutoProvisioned := volinfo.IsAutoProvisioned() || volinfo.IsSnapshotProvisioned()
+
+	// Nodes hosting bricks of the volume before or after the reshape
+	volNodes := volinfo.Nodes()
+	seen := make(map[string]bool)
+	for _, n := range volNodes {
+		seen[n.String()] = true
+	}
+	for _, n := range addedNodes {
+		if !seen[n.String()] {
+			volNodes = append(volNodes, n)
+		}
+	}
+
+	txn.Nodes = allNodes
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "vol-expand.ValidateBricks",
+			Nodes:  addedNodes,
+			Skip:   len(added) == 0,
+		},
+		{
+			DoFunc:   "vol-expand.InitBricks",
+			UndoFunc: "vol-expand.UndoInitBricks",
+			Nodes:    addedNodes,
+			Skip:     len(added) == 0,
+		},
+		{
+			DoFunc:   "vol-create.StoreVolume",
+			UndoFunc: "vol-create.UndoStoreVolume",
+			Nodes:    []uuid.UUID{gdctx.MyUUID},
+			Sync:     true,
+		},
+		{
+			DoFunc: "vol-expand.GenerateBrickVolfiles",
+			Nodes:  newvolinfo.Nodes(),
+			Skip:   !started,
+		},
+		{
+			DoFunc:   "vol-expand.StartBrick",
+			UndoFunc: "vol-expand.UndoStartBrick",
+			Nodes:    addedNodes,
+			Skip:     len(added) == 0,
+		},
+		{
+			DoFunc: "vol-expand.NotifyClients",
+			Nodes:  allNodes,
+		},
+		{
+			DoFunc: "selfheal.ReloadDaemon",
+			Nodes:  volNodes,
+			Skip:   !started,
+		},
+		{
+			DoFunc: "vol-shrink.StopBricks",
+			Nodes:  removedNodes,
+			Skip:   removed == nil || !started,
+		},
+		{
+			DoFunc: "vol-shrink.CleanBricks",
+			Nodes:  removedNodes,
+			Skip:   removed == nil || !bricksAutoProvisioned,
+		},
+	}
+
+	if err := txn.Ctx.Set("volinfo", newvolinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("oldvolinfo", volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("bricks", added); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Used by other peers to check if proposed bricks are already in use.
+	// This check is however still prone to races. See issue #314
+	if err := txn.Ctx.Set("all-bricks-in-cluster", allBricks); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	checks := brick.PrepareChecks(req.Force, req.Flags)
+	if err := txn.Ctx.Set("brick-checks", checks); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if removed != nil {
+		if err := txn.Ctx.Set("removed-volinfo", removed); err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("volume-name", volname).Error("volume reshape transaction failed")
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	logger.WithField("volume-name", volname).Info("volume reshaped")
+
+	// Populate the new replicas. The volume has been reshaped already, so a
+	// failure to start the heal does not fail the request, the heal can be
+	// started again with the heal API.
+	if len(added) > 0 && glustershd.IsSelfHealDaemonNeeded(newvolinfo) {
+		if err := startReshapeHeal(ctx, newvolinfo); err != nil {
+			logger.WithError(err).WithField("volume-name", volname).Warn("failed to start full heal of reshaped volume")
+		}
+	}
+
+	events.Broadcast(volume.NewEvent(volume.EventVolumeReshaped, newvolinfo))
+	if removed != nil {
+		events.Broadcast(volume.NewEvent(volume.EventBrickRemoved, newvolinfo))
+	}
+
+	resp := createVolumeReshapeResp(newvolinfo)
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+// startReshapeHeal starts a full heal of the reshaped volume. It is called
+// with the volume locked.
+func startReshapeHeal(ctx context.Context, volinfo *volume.Volinfo) error {
+	txn := transaction.NewTxn(ctx)
+	defer txn.Done()
+
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "selfheal.Heal",
+			Nodes:  volinfo.Nodes(),
+		},
+	}
+
+	if err := txn.Ctx.Set("volinfo", volinfo); err != nil {
+		return err
+	}
+	if err := txn.Ctx.Set("healType", glustershdapi.HealFull); err != nil {
+		return err
+	}
+
+	return txn.Do()
+}
+
+func createVolumeReshapeResp(v *volume.Volinfo) *api.VolumeReshapeResp {
+	return (*api.VolumeReshapeResp)(volume.CreateVolumeInfoResp(v))
+}
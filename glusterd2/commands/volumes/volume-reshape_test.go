@@ -0,0 +1,120 @@
+package volumecommands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReshapeBricks(peerID uuid.UUID, prefix string, count int) []brick.Brickinfo {
+	var bricks []brick.Brickinfo
+	for i := 0; i < count; i++ {
+		bricks = append(bricks, brick.Brickinfo{
+			PeerID: peerID,
+			Path:   fmt.Sprintf("/bricks/%s%d", prefix, i),
+		})
+	}
+	return bricks
+}
+
+// TestReshapeVolinfoAdd validates adding a brick to every subvolume
+func TestReshapeVolinfoAdd(t *testing.T) {
+	u := uuid.NewRandom()
+
+	v := newDistReplicateVolinfo(u, 2)
+	_, _, _, err := reshapeVolinfo(v, 3, false, newReshapeBricks(u, "new", 1))
+	assert.NotNil(t, err)
+	_, _, _, err = reshapeVolinfo(v, 2, false, newReshapeBricks(u, "new", 2))
+	assert.NotNil(t, err)
+	_, _, _, err = reshapeVolinfo(v, 4, false, newReshapeBricks(u, "new", 2))
+	assert.NotNil(t, err)
+	_, _, _, err = reshapeVolinfo(v, 3, true, newReshapeBricks(u, "new", 2))
+	assert.NotNil(t, err)
+	_, _, _, err = reshapeVolinfo(v, 3, false, []brick.Brickinfo{v.Subvols[1].Bricks[0], {PeerID: u, Path: "/bricks/new"}})
+	assert.NotNil(t, err)
+
+	reshaped, removed, added, err := reshapeVolinfo(v, 3, false, newReshapeBricks(u, "new", 2))
+	assert.Nil(t, err)
+	assert.Nil(t, removed)
+	assert.Len(t, added, 2)
+	assert.Equal(t, volume.DistReplicate, reshaped.Type)
+	for _, sv := range reshaped.Subvols {
+		assert.Equal(t, 3, sv.ReplicaCount)
+		assert.Len(t, sv.Bricks, 3)
+	}
+	assert.Equal(t, "/bricks/new1", reshaped.Subvols[1].Bricks[2].Path)
+	// The original volinfo is left untouched
+	assert.Len(t, v.Subvols[0].Bricks, 2)
+
+	reshaped, _, added, err = reshapeVolinfo(v, 2, true, newReshapeBricks(u, "arb", 2))
+	assert.Nil(t, err)
+	assert.Equal(t, brick.Arbiter, added[0].Type)
+	assert.Equal(t, 2, reshaped.Subvols[0].ReplicaCount)
+	assert.Equal(t, 1, reshaped.Subvols[0].ArbiterCount)
+	assert.Equal(t, brick.Arbiter, reshaped.Subvols[0].Bricks[2].Type)
+	// The replicate xlator is told about the arbiter, as on volume create
+	assert.Equal(t, "1", reshaped.Options["replicate.arbiter-count"])
+	assert.NotContains(t, v.Options, "replicate.arbiter-count")
+
+	dist := &volume.Volinfo{
+		Name:      "dist",
+		Type:      volume.Distribute,
+		DistCount: 1,
+		Subvols: []volume.Subvol{{
+			Name:   "dist-distribute-0",
+			Type:   volume.SubvolDistribute,
+			Bricks: newReshapeBricks(u, "b", 2),
+		}},
+	}
+	reshaped, _, _, err = reshapeVolinfo(dist, 2, false, newReshapeBricks(u, "new", 2))
+	assert.Nil(t, err)
+	assert.Equal(t, volume.DistReplicate, reshaped.Type)
+	assert.Equal(t, 2, reshaped.DistCount)
+	assert.Equal(t, "dist-replicate-1", reshaped.Subvols[1].Name)
+	assert.Equal(t, "/bricks/b1", reshaped.Subvols[1].Bricks[0].Path)
+	assert.Equal(t, "/bricks/new1", reshaped.Subvols[1].Bricks[1].Path)
+}
+
+// TestReshapeVolinfoRemove validates removing a brick from every subvolume
+func TestReshapeVolinfoRemove(t *testing.T) {
+	u := uuid.NewRandom()
+
+	v := newDistReplicateVolinfo(u, 2)
+	_, _, _, err := reshapeVolinfo(v, 1, false, []brick.Brickinfo{v.Subvols[0].Bricks[0], v.Subvols[0].Bricks[1]})
+	assert.NotNil(t, err)
+	_, _, _, err = reshapeVolinfo(v, 1, false, []brick.Brickinfo{v.Subvols[0].Bricks[0], {PeerID: u, Path: "/bricks/none"}})
+	assert.NotNil(t, err)
+
+	reshaped, removed, added, err := reshapeVolinfo(v, 1, false, []brick.Brickinfo{v.Subvols[0].Bricks[0], v.Subvols[1].Bricks[1]})
+	assert.Nil(t, err)
+	assert.Empty(t, added)
+	assert.Equal(t, volume.Distribute, reshaped.Type)
+	assert.Equal(t, 1, reshaped.DistCount)
+	assert.Len(t, reshaped.Subvols, 1)
+	assert.Equal(t, volume.SubvolDistribute, reshaped.Subvols[0].Type)
+	assert.Len(t, reshaped.GetBricks(), 2)
+	assert.Len(t, removed.GetBricks(), 2)
+	assert.Equal(t, "/bricks/s0-b0", removed.Subvols[0].Bricks[0].Path)
+
+	arb, _, _, err := reshapeVolinfo(v, 2, true, newReshapeBricks(u, "arb", 2))
+	assert.Nil(t, err)
+	// Only the arbiter brick can be removed along with the arbiter
+	_, _, _, err = reshapeVolinfo(arb, 2, false, []brick.Brickinfo{arb.Subvols[0].Bricks[0], arb.Subvols[1].Bricks[0]})
+	assert.NotNil(t, err)
+	_, _, _, err = reshapeVolinfo(arb, 1, true, []brick.Brickinfo{arb.Subvols[0].Bricks[2], arb.Subvols[1].Bricks[2]})
+	assert.NotNil(t, err)
+
+	reshaped, removed, _, err = reshapeVolinfo(arb, 2, false, []brick.Brickinfo{arb.Subvols[0].Bricks[2], arb.Subvols[1].Bricks[2]})
+	assert.Nil(t, err)
+	assert.Equal(t, volume.DistReplicate, reshaped.Type)
+	assert.Equal(t, 0, reshaped.Subvols[0].ArbiterCount)
+	assert.NotContains(t, reshaped.Options, "replicate.arbiter-count")
+	assert.Equal(t, "1", arb.Options["replicate.arbiter-count"])
+	assert.Len(t, reshaped.Subvols[0].Bricks, 2)
+	assert.Equal(t, "/bricks/arb0", removed.Subvols[0].Bricks[0].Path)
+}
@@ -0,0 +1,177 @@
+package volumecommands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+	rebalanceapi "github.com/gluster/glusterd2/plugins/rebalance/api"
+)
+
+const (
+	// defragStatusComplete is the status reported by a rebalance process
+	// which has finished migrating data
+	defragStatusComplete = "3"
+)
+
+// decommissionedBricks returns the bricks of the volume which are being removed
+func decommissionedBricks(volinfo *volume.Volinfo) []brick.Brickinfo {
+	var bricks []brick.Brickinfo
+	for _, b := range volinfo.GetBricks() {
+		if b.Decommissioned {
+			bricks = append(bricks, b)
+		}
+	}
+	return bricks
+}
+
+// decommissionBricks marks the requested bricks of the volume as
+// decommissioned. Bricks of replicate and disperse subvolumes can only be
+// removed as whole subvolumes.
+func decommissionBricks(volinfo *volume.Volinfo, bricks []api.BrickReq) error {
+	if len(bricks) == 0 {
+		return errors.New("no bricks specified")
+	}
+
+	requested := make(map[string]bool)
+	for _, b := range bricks {
+		key := b.PeerID + ":" + filepath.Clean(b.Path)
+		if requested[key] {
+			return gderrors.ErrDuplicateBrickPath
+		}
+		requested[key] = true
+	}
+
+	remaining := 0
+	for sidx := range volinfo.Subvols {
+		sv := &volinfo.Subvols[sidx]
+		removed := 0
+		for bidx := range sv.Bricks {
+			b := &sv.Bricks[bidx]
+			key := b.PeerID.String() + ":" + b.Path
+			if !requested[key] {
+				continue
+			}
+			b.Decommissioned = true
+			delete(requested, key)
+			removed++
+		}
+
+		if removed != 0 && removed != len(sv.Bricks) && sv.Type != volume.SubvolDistribute {
+			return fmt.Errorf("all bricks of subvolume %s have to be removed together", sv.Name)
+		}
+		remaining += len(sv.Bricks) - removed
+	}
+
+	for key := range requested {
+		return fmt.Errorf("brick %s is not part of volume %s", key, volinfo.Name)
+	}
+
+	if remaining == 0 {
+		return errors.New("all bricks of the volume can not be removed")
+	}
+
+	return nil
+}
+
+// recommissionBricks clears the decommissioned flag of all bricks of the
+// volume
+func recommissionBricks(volinfo *volume.Volinfo) {
+	for sidx := range volinfo.Subvols {
+		for bidx := range volinfo.Subvols[sidx].Bricks {
+			volinfo.Subvols[sidx].Bricks[bidx].Decommissioned = false
+		}
+	}
+}
+
+// shrinkVolinfo returns the volinfo with the decommissioned bricks removed and
+// a volinfo containing only the decommissioned bricks
+func shrinkVolinfo(volinfo *volume.Volinfo) (*volume.Volinfo, *volume.Volinfo) {
+	shrunk := *volinfo
+	removed := *volinfo
+	shrunk.Subvols = nil
+	removed.Subvols = nil
+
+	total := 0
+	for _, sv := range volinfo.Subvols {
+		kept, gone := sv, sv
+		kept.Bricks, gone.Bricks = nil, nil
+		for _, b := range sv.Bricks {
+			if b.Decommissioned {
+				gone.Bricks = append(gone.Bricks, b)
+			} else {
+				kept.Bricks = append(kept.Bricks, b)
+			}
+			total++
+		}
+		if len(kept.Bricks) > 0 {
+			shrunk.Subvols = append(shrunk.Subvols, kept)
+		}
+		if len(gone.Bricks) > 0 {
+			removed.Subvols = append(removed.Subvols, gone)
+		}
+	}
+
+	shrunk.DistCount = len(shrunk.Subvols)
+	if shrunk.DistCount == 1 {
+		switch shrunk.Type {
+		case volume.DistReplicate:
+			shrunk.Type = volume.Replicate
+		case volume.DistDisperse:
+			shrunk.Type = volume.Disperse
+		}
+	}
+
+	// Capacity is spread evenly across the bricks of a volume
+	if total > 0 {
+		kept := uint64(len(shrunk.GetBricks()))
+		shrunk.Capacity = volinfo.Capacity / uint64(total) * kept
+	}
+
+	return &shrunk, &removed
+}
+
+// shrinkMigrationComplete returns true if all the rebalance processes have
+// finished migrating data off the decommissioned bricks without failures
+func shrinkMigrationComplete(rinfo *rebalanceapi.RebalInfo) bool {
+	if rinfo.State != rebalanceapi.Complete {
+		return false
+	}
+
+	for _, s := range rinfo.RebalStats {
+		if s.Status != defragStatusComplete {
+			return false
+		}
+		if s.RebalanceFailures != "" && s.RebalanceFailures != "0" {
+			return false
+		}
+	}
+	return true
+}
+
+func createShrinkNodeStatus(s *rebalanceapi.RebalNodeStatus) api.ShrinkNodeStatus {
+	return api.ShrinkNodeStatus{
+		PeerID:        s.PeerID,
+		Status:        s.Status,
+		MigratedFiles: s.RebalancedFiles,
+		MigratedSize:  s.RebalancedSize,
+		ScannedFiles:  s.LookedupFiles,
+		SkippedFiles:  s.SkippedFiles,
+		Failures:      s.RebalanceFailures,
+		RunTime:       s.ElapsedTime,
+		TimeLeft:      s.TimeLeft,
+	}
+}
+
+func stopRemovedBricks(c transaction.TxnCtx) error {
+	return stopVolinfoBricks(c, "removed-volinfo")
+}
+
+func cleanRemovedBricks(c transaction.TxnCtx) error {
+	return cleanVolinfoBricks(c, "removed-volinfo")
+}
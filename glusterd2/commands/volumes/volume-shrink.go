@@ -0,0 +1,469 @@
+package volumecommands
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/events"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/peer"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+	"github.com/gluster/glusterd2/plugins/rebalance"
+	rebalanceapi "github.com/gluster/glusterd2/plugins/rebalance/api"
+
+	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+)
+
+func registerVolShrinkStepFuncs() {
+	var sfs = []struct {
+		name string
+		sf   transaction.StepFunc
+	}{
+		{"vol-shrink.StopBricks", stopRemovedBricks},
+		{"vol-shrink.CleanBricks", cleanRemovedBricks},
+	}
+	for _, sf := range sfs {
+		transaction.RegisterStepFunc(sf.sf, sf.name)
+	}
+}
+
+// volumeShrinkHandler marks the requested bricks as decommissioned and starts
+// migrating data off them. The bricks are removed from the volume once the
+// shrink is committed.
+func volumeShrinkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	volname := mux.Vars(r)["volname"]
+
+	var req api.VolShrinkReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if len(decommissionedBricks(volinfo)) > 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, gderrors.ErrVolShrinkInProgress)
+		return
+	}
+
+	rinfo, err := rebalance.GetRebalanceInfo(volname)
+	if err == nil && rinfo.State == rebalanceapi.Started {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, gderrors.ErrRebalanceInProgress)
+		return
+	}
+
+	// Data can only be migrated by the rebalance process of a started volume
+	if volinfo.State != volume.VolStarted && !req.Force {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrVolNotStarted)
+		return
+	}
+
+	oldvolinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := decommissionBricks(volinfo, req.Bricks); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Force {
+		// Remove the bricks right away without migrating data
+		if _, status, err := commitShrink(ctx, txn, oldvolinfo, volinfo); err != nil {
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+		resp := createVolumeShrinkStatusResp(volinfo, nil, nil)
+		restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+		return
+	}
+
+	allNodes, err := peer.GetPeerIDs()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rinfo = rebalance.CreateRebalanceInfo(volname, &rebalanceapi.StartReq{})
+
+	txn.Nodes = allNodes
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc:   "vol-create.StoreVolume",
+			UndoFunc: "vol-create.UndoStoreVolume",
+			Nodes:    []uuid.UUID{gdctx.MyUUID},
+			Sync:     true,
+		},
+		{
+			DoFunc: "vol-expand.NotifyClients",
+			Nodes:  allNodes,
+		},
+		// The rebalance processes migrate data off the decommissioned
+		// bricks as per the updated volinfo
+		{
+			DoFunc: "rebalance-start",
+			Nodes:  volinfo.Nodes(),
+		},
+		{
+			DoFunc: "rebalance-store",
+			Nodes:  []uuid.UUID{gdctx.MyUUID},
+			Sync:   true,
+		},
+	}
+
+	if err := txn.Ctx.Set("volname", volname); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("volinfo", volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("oldvolinfo", oldvolinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("rinfo", rinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("volume-name", volname).Error("volume shrink transaction failed")
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	logger.WithField("volume-name", volname).Info("volume shrink started")
+
+	resp := createVolumeShrinkStatusResp(volinfo, rinfo, nil)
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func volumeShrinkStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	volname := mux.Vars(r)["volname"]
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if len(decommissionedBricks(volinfo)) == 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusNotFound, gderrors.ErrVolShrinkNotStarted)
+		return
+	}
+
+	rinfo, err := rebalance.GetRebalanceInfo(volname)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rstatus, err := rebalance.GetRebalanceStatus(ctx, volinfo, rinfo)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := createVolumeShrinkStatusResp(volinfo, rinfo, rstatus.Nodes)
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func volumeShrinkCommitHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	volname := mux.Vars(r)["volname"]
+
+	var req api.VolShrinkCommitReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil && err != io.EOF {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if len(decommissionedBricks(volinfo)) == 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrVolShrinkNotStarted)
+		return
+	}
+
+	rinfo, err := rebalance.GetRebalanceInfo(volname)
+	if !req.Force && (err != nil || !shrinkMigrationComplete(rinfo)) {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrVolShrinkIncomplete)
+		return
+	}
+
+	shrunk, status, err := commitShrink(ctx, txn, volinfo, volinfo)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, createVolumeShrinkResp(shrunk))
+}
+
+func volumeShrinkStopHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	volname := mux.Vars(r)["volname"]
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if len(decommissionedBricks(volinfo)) == 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrVolShrinkNotStarted)
+		return
+	}
+
+	allNodes, err := peer.GetPeerIDs()
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("oldvolinfo", volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	stopRebalance, err := setShrinkRebalanceStop(txn, volname)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Data already migrated stays on the remaining bricks
+	recommissionBricks(volinfo)
+
+	txn.Nodes = allNodes
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "rebalance-stop",
+			Nodes:  volinfo.Nodes(),
+			Skip:   !stopRebalance,
+		},
+		{
+			DoFunc: "rebalance-store",
+			Nodes:  []uuid.UUID{gdctx.MyUUID},
+			Sync:   true,
+			Skip:   !stopRebalance,
+		},
+		{
+			DoFunc:   "vol-create.StoreVolume",
+			UndoFunc: "vol-create.UndoStoreVolume",
+			Nodes:    []uuid.UUID{gdctx.MyUUID},
+			Sync:     true,
+		},
+		{
+			DoFunc: "vol-expand.NotifyClients",
+			Nodes:  allNodes,
+		},
+	}
+
+	if err := txn.Ctx.Set("volinfo", volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("volume-name", volname).Error("volume shrink stop transaction failed")
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	logger.WithField("volume-name", volname).Info("volume shrink stopped")
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, createVolumeShrinkResp(volinfo))
+}
+
+// setShrinkRebalanceStop sets up the transaction context to stop the rebalance
+// processes migrating data off the decommissioned bricks. It returns false if
+// they are not running.
+func setShrinkRebalanceStop(txn *transaction.Txn, volname string) (bool, error) {
+	rinfo, err := rebalance.GetRebalanceInfo(volname)
+	if err != nil || rinfo.State != rebalanceapi.Started {
+		return false, nil
+	}
+
+	rinfo.State = rebalanceapi.Stopped
+	rinfo.Cmd = rebalanceapi.CmdStop
+
+	if err := txn.Ctx.Set("volname", volname); err != nil {
+		return false, err
+	}
+	if err := txn.Ctx.Set("rinfo", rinfo); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// commitShrink removes the decommissioned bricks of volinfo from the volume
+// and stops them, stopping any data migration still in progress. oldvolinfo
+// is restored if the transaction fails.
+func commitShrink(ctx context.Context, txn *transaction.Txn, oldvolinfo, volinfo *volume.Volinfo) (*volume.Volinfo, int, error) {
+	logger := gdctx.GetReqLogger(ctx)
+
+	shrunk, removed := shrinkVolinfo(volinfo)
+
+	allNodes, err := peer.GetPeerIDs()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	stopRebalance, err := setShrinkRebalanceStop(txn, volinfo.Name)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	bricksAutoProvisioned := volinfo.IsAutoProvisioned() || volinfo.IsSnapshotProvisioned()
+
+	txn.Nodes = allNodes
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "rebalance-stop",
+			Nodes:  volinfo.Nodes(),
+			Skip:   !stopRebalance,
+		},
+		{
+			DoFunc: "rebalance-store",
+			Nodes:  []uuid.UUID{gdctx.MyUUID},
+			Sync:   true,
+			Skip:   !stopRebalance,
+		},
+		{
+			DoFunc:   "vol-create.StoreVolume",
+			UndoFunc: "vol-create.UndoStoreVolume",
+			Nodes:    []uuid.UUID{gdctx.MyUUID},
+			Sync:     true,
+		},
+		{
+			DoFunc: "vol-expand.NotifyClients",
+			Nodes:  allNodes,
+		},
+		{
+			DoFunc: "vol-shrink.StopBricks",
+			Nodes:  removed.Nodes(),
+			Skip:   volinfo.State != volume.VolStarted,
+		},
+		{
+			DoFunc: "vol-shrink.CleanBricks",
+			Nodes:  removed.Nodes(),
+			Skip:   !bricksAutoProvisioned,
+		},
+	}
+
+	if err := txn.Ctx.Set("volinfo", shrunk); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := txn.Ctx.Set("oldvolinfo", oldvolinfo); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := txn.Ctx.Set("removed-volinfo", removed); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("volume-name", volinfo.Name).Error("volume shrink commit transaction failed")
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
+	}
+
+	logger.WithField("volume-name", volinfo.Name).Info("volume shrunk")
+	events.Broadcast(volume.NewEvent(volume.EventBrickRemoved, shrunk))
+
+	return shrunk, http.StatusOK, nil
+}
+
+func createVolumeShrinkResp(v *volume.Volinfo) *api.VolumeShrinkResp {
+	return (*api.VolumeShrinkResp)(volume.CreateVolumeInfoResp(v))
+}
+
+func createVolumeShrinkStatusResp(v *volume.Volinfo, rinfo *rebalanceapi.RebalInfo, nodes []rebalanceapi.RebalNodeStatus) *api.VolumeShrinkStatusResp {
+	resp := &api.VolumeShrinkStatusResp{
+		VolName:  v.Name,
+		Complete: true,
+		Bricks:   []api.BrickInfo{},
+		Nodes:    []api.ShrinkNodeStatus{},
+	}
+
+	// No data is migrated when the bricks are removed forcefully
+	if rinfo != nil {
+		resp.RebalanceID = rinfo.RebalanceID
+		resp.Complete = shrinkMigrationComplete(rinfo)
+	}
+
+	for _, b := range decommissionedBricks(v) {
+		resp.Bricks = append(resp.Bricks, brick.CreateBrickInfo(&b))
+	}
+
+	for _, n := range nodes {
+		resp.Nodes = append(resp.Nodes, createShrinkNodeStatus(&n))
+	}
+
+	return resp
+}
@@ -0,0 +1,119 @@
+package volumecommands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newDistReplicateVolinfo returns a volinfo with numSubvols replica 2 subvols
+func newDistReplicateVolinfo(peerID uuid.UUID, numSubvols int) *volume.Volinfo {
+	v := &volume.Volinfo{
+		Name:      "vol",
+		Type:      volume.DistReplicate,
+		DistCount: numSubvols,
+		Capacity:  uint64(numSubvols) * 100,
+	}
+	for i := 0; i < numSubvols; i++ {
+		sv := volume.Subvol{
+			Name:         fmt.Sprintf("vol-replicate-%d", i),
+			Type:         volume.SubvolReplicate,
+			ReplicaCount: 2,
+		}
+		for j := 0; j < 2; j++ {
+			sv.Bricks = append(sv.Bricks, brick.Brickinfo{
+				PeerID: peerID,
+				Path:   fmt.Sprintf("/bricks/s%d-b%d", i, j),
+			})
+		}
+		v.Subvols = append(v.Subvols, sv)
+	}
+	return v
+}
+
+// TestDecommissionBricks validates decommissionBricks()
+func TestDecommissionBricks(t *testing.T) {
+	u := uuid.NewRandom()
+	b := func(path string) api.BrickReq {
+		return api.BrickReq{PeerID: u.String(), Path: path}
+	}
+
+	v := newDistReplicateVolinfo(u, 2)
+	assert.NotNil(t, decommissionBricks(v, nil))
+
+	// Part of a replicate subvol
+	v = newDistReplicateVolinfo(u, 2)
+	assert.NotNil(t, decommissionBricks(v, []api.BrickReq{b("/bricks/s0-b0")}))
+
+	// Unknown brick
+	v = newDistReplicateVolinfo(u, 2)
+	assert.NotNil(t, decommissionBricks(v, []api.BrickReq{b("/bricks/s0-b0"), b("/bricks/s0-b1"), b("/bricks/none")}))
+
+	// Duplicate brick
+	v = newDistReplicateVolinfo(u, 2)
+	assert.NotNil(t, decommissionBricks(v, []api.BrickReq{b("/bricks/s0-b0"), b("/bricks/s0-b0/")}))
+
+	// All bricks
+	v = newDistReplicateVolinfo(u, 1)
+	assert.NotNil(t, decommissionBricks(v, []api.BrickReq{b("/bricks/s0-b0"), b("/bricks/s0-b1")}))
+
+	v = newDistReplicateVolinfo(u, 2)
+	assert.Nil(t, decommissionBricks(v, []api.BrickReq{b("/bricks/s1-b0"), b("/bricks/s1-b1")}))
+	assert.Len(t, decommissionedBricks(v), 2)
+	assert.False(t, v.Subvols[0].Bricks[0].Decommissioned)
+	assert.True(t, v.Subvols[1].Bricks[1].Decommissioned)
+
+	recommissionBricks(v)
+	assert.Empty(t, decommissionedBricks(v))
+}
+
+// TestShrinkVolinfo validates shrinkVolinfo()
+func TestShrinkVolinfo(t *testing.T) {
+	u := uuid.NewRandom()
+	v := newDistReplicateVolinfo(u, 2)
+	assert.Nil(t, decommissionBricks(v, []api.BrickReq{
+		{PeerID: u.String(), Path: "/bricks/s0-b0"},
+		{PeerID: u.String(), Path: "/bricks/s0-b1"},
+	}))
+
+	shrunk, removed := shrinkVolinfo(v)
+	assert.Equal(t, volume.Replicate, shrunk.Type)
+	assert.Equal(t, 1, shrunk.DistCount)
+	assert.Equal(t, uint64(100), shrunk.Capacity)
+	assert.Len(t, shrunk.Subvols, 1)
+	assert.Equal(t, "vol-replicate-1", shrunk.Subvols[0].Name)
+	assert.Empty(t, decommissionedBricks(shrunk))
+
+	assert.Len(t, removed.Subvols, 1)
+	assert.Equal(t, "vol-replicate-0", removed.Subvols[0].Name)
+	assert.Len(t, removed.GetBricks(), 2)
+
+	// The original volinfo is left untouched
+	assert.Len(t, v.Subvols, 2)
+	assert.Equal(t, volume.DistReplicate, v.Type)
+
+	// Bricks of a plain distribute volume can be removed individually
+	dist := &volume.Volinfo{
+		Name: "dist",
+		Type: volume.Distribute,
+		Subvols: []volume.Subvol{{
+			Name: "dist-distribute-0",
+			Type: volume.SubvolDistribute,
+			Bricks: []brick.Brickinfo{
+				{PeerID: u, Path: "/bricks/b0"},
+				{PeerID: u, Path: "/bricks/b1"},
+			},
+		}},
+	}
+	assert.Nil(t, decommissionBricks(dist, []api.BrickReq{{PeerID: u.String(), Path: "/bricks/b1"}}))
+	shrunk, removed = shrinkVolinfo(dist)
+	assert.Len(t, shrunk.GetBricks(), 1)
+	assert.Equal(t, "/bricks/b0", shrunk.GetBricks()[0].Path)
+	assert.Len(t, removed.GetBricks(), 1)
+}
@@ -322,10 +322,16 @@ func txnUndoPrepareBricksLoop(req api.VolCreateReq, c transaction.TxnCtx) error
 }
 
 func txnCleanBricks(c transaction.TxnCtx) error {
+	return cleanVolinfoBricks(c, "volinfo")
+}
+
+// cleanVolinfoBricks removes the provisioned local bricks of the volinfo
+// stored in the transaction context under key
+func cleanVolinfoBricks(c transaction.TxnCtx, key string) error {
 	var volinfo volume.Volinfo
-	if err := c.Get("volinfo", &volinfo); err != nil {
+	if err := c.Get(key, &volinfo); err != nil {
 		c.Logger().WithError(err).WithField(
-			"key", "volinfo").Debug("Failed to get key from store")
+			"key", key).Debug("Failed to get key from store")
 		return err
 	}
 
@@ -0,0 +1,429 @@
+package volumecommands
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/daemon"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/servers/sunrpc/dict"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+)
+
+const (
+	brickStatusDetailTxnKey string = "brickstatusdetails"
+
+	statusOptionDetail = "detail"
+)
+
+// statusOps maps the detailed volume status options to the status
+// operations of brick processes (gf_cli_status_type). The detail view is
+// gathered by glusterd2 itself.
+var statusOps = map[string]int{
+	"mem":      0x01,
+	"clients":  0x02,
+	"inode":    0x04,
+	"fd":       0x08,
+	"callpool": 0x10,
+}
+
+func isValidStatusOption(option string) bool {
+	_, ok := statusOps[option]
+	return ok || option == statusOptionDetail
+}
+
+func registerVolStatusDetailStepFuncs() {
+	transaction.RegisterStepFunc(txnVolumeStatusDetail, "volume.StatusDetail")
+}
+
+func volumeStatusDetailHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	volname := mux.Vars(r)["volname"]
+	option := mux.Vars(r)["option"]
+
+	if !isValidStatusOption(option) {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, fmt.Errorf("invalid status option %s", option))
+		return
+	}
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if volinfo.State != volume.VolStarted {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrVolNotStarted)
+		return
+	}
+
+	bricks := volinfo.GetBricks()
+	nodes := volinfo.Nodes()
+	var brickID string
+	if name := r.URL.Query().Get("brick"); name != "" {
+		b, err := findBrick(volinfo, name)
+		if err != nil {
+			status, err := restutils.ErrToStatusCode(err)
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+		brickID = b.ID.String()
+		bricks = []brick.Brickinfo{*b}
+		nodes = []uuid.UUID{b.PeerID}
+	}
+
+	txn := transaction.NewTxn(ctx)
+	defer txn.Done()
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "volume.StatusDetail",
+			Nodes:  nodes,
+		},
+	}
+	txn.Ctx.Set("volname", volname)
+	txn.Ctx.Set("option", option)
+	txn.Ctx.Set("brick-id", brickID)
+
+	// Some nodes may not be up, which is okay.
+	txn.DontCheckAlive = true
+	txn.DisableRollback = true
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("volume", volname).Error("Failed to get detailed volume status")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Bricks on nodes which did not respond are reported offline
+	bmap := make(map[string]api.BrickStatusDetail)
+	for _, node := range nodes {
+		var tmp []api.BrickStatusDetail
+		if err := txn.Ctx.GetNodeResult(node, brickStatusDetailTxnKey, &tmp); err != nil {
+			continue
+		}
+		for _, b := range tmp {
+			bmap[b.Info.ID.String()] = b
+		}
+	}
+
+	resp := &api.VolumeStatusDetailResp{
+		Volume: volname,
+		Option: option,
+		Bricks: []api.BrickStatusDetail{},
+	}
+	for _, b := range bricks {
+		s, ok := bmap[b.ID.String()]
+		if !ok {
+			s.Info = brick.CreateBrickInfo(&b)
+		}
+		resp.Bricks = append(resp.Bricks, s)
+	}
+
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func txnVolumeStatusDetail(c transaction.TxnCtx) error {
+	var volname, option, brickID string
+	if err := c.Get("volname", &volname); err != nil {
+		return err
+	}
+	if err := c.Get("option", &option); err != nil {
+		return err
+	}
+	if err := c.Get("brick-id", &brickID); err != nil {
+		return err
+	}
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		c.Logger().WithError(err).Error("Failed to get volume information from store.")
+		return err
+	}
+
+	mtabEntries, err := volume.GetMounts()
+	if err != nil {
+		c.Logger().WithError(err).Error("Failed to read /etc/mtab file.")
+		return err
+	}
+
+	details := []api.BrickStatusDetail{}
+	for _, b := range volinfo.GetLocalBricks() {
+		if brickID != "" && b.ID.String() != brickID {
+			continue
+		}
+
+		status, err := volume.BrickStatus(b, mtabEntries)
+		if err != nil {
+			return err
+		}
+
+		detail := api.BrickStatusDetail{
+			BrickStatus: *(brick.CreateBrickStatusRsp([]brick.Brickstatus{status})[0]),
+		}
+
+		if option == statusOptionDetail {
+			var fstat syscall.Statfs_t
+			if err := syscall.Statfs(b.Path, &fstat); err == nil {
+				detail.BlockSize = uint64(fstat.Bsize)
+				detail.Inodes = fstat.Files
+				detail.FreeInodes = fstat.Ffree
+			}
+		} else if status.Online {
+			if err := brickStatusView(b, volname, option, &detail); err != nil {
+				c.Logger().WithError(err).WithField(
+					"brick", b.String()).Error("failed to get brick status")
+				detail.Error = err.Error()
+			}
+		}
+
+		details = append(details, detail)
+	}
+
+	// Store the results in transaction context. This will be consumed by
+	// the node that initiated the transaction.
+	return c.SetNodeResult(gdctx.MyUUID, brickStatusDetailTxnKey, details)
+}
+
+// brickStatusView gets a status view from a brick process and fills it into
+// the detail
+func brickStatusView(b brick.Brickinfo, volname, option string, detail *api.BrickStatusDetail) error {
+	brickDaemon, err := brick.NewGlusterfsd(b)
+	if err != nil {
+		return err
+	}
+
+	client, err := daemon.GetRPCClient(brickDaemon)
+	if err != nil {
+		return err
+	}
+
+	reqDict := map[string]string{
+		"cmd":        strconv.Itoa(statusOps[option]),
+		"brick-name": b.Path,
+		"volname":    volname,
+	}
+	req := &brick.GfBrickOpReq{
+		Name: b.Path,
+		Op:   int(brick.OpBrickStatus),
+	}
+	req.Input, err = dict.Serialize(reqDict)
+	if err != nil {
+		return err
+	}
+
+	var rsp brick.GfBrickOpRsp
+	if err := client.Call("Brick.OpBrickStatus", req, &rsp); err != nil {
+		return err
+	}
+	if rsp.OpRet != 0 {
+		if rsp.OpErrstr != "" {
+			return errors.New(rsp.OpErrstr)
+		}
+		return errors.New("brick status RPC failed")
+	}
+
+	output, err := dict.Unserialize(rsp.Output)
+	if err != nil {
+		return errors.New("error unserializing the output")
+	}
+
+	switch option {
+	case "clients":
+		detail.Clients = parseBrickClients(output)
+	case "inode":
+		detail.InodeTables = parseInodeTables(output)
+	case "fd":
+		detail.FdTables = parseFdTables(output)
+	case "mem":
+		detail.Memory = parseBrickMemInfo(output)
+	case "callpool":
+		detail.CallPool = parseCallPool(output)
+	}
+	return nil
+}
+
+func dictInt(d map[string]string, key string) int {
+	v, _ := strconv.Atoi(d[key])
+	return v
+}
+
+func dictUint64(d map[string]string, key string) uint64 {
+	v, _ := strconv.ParseUint(d[key], 10, 64)
+	return v
+}
+
+func dictUint32(d map[string]string, key string) uint32 {
+	v, _ := strconv.ParseUint(d[key], 10, 32)
+	return uint32(v)
+}
+
+// parseBrickClients parses the clients connected to a brick from the output
+// of the clients status op. Keys are of the form client<n>.<field>.
+func parseBrickClients(output map[string]string) []api.BrickClient {
+	count := dictInt(output, "clientcount")
+	clients := make([]api.BrickClient, 0, count)
+	for i := 0; i < count; i++ {
+		prefix := fmt.Sprintf("client%d", i)
+		clients = append(clients, api.BrickClient{
+			Hostname:     output[prefix+".hostname"],
+			BytesRead:    dictUint64(output, prefix+".bytesread"),
+			BytesWritten: dictUint64(output, prefix+".byteswrite"),
+			OpVersion:    dictUint32(output, prefix+".opversion"),
+		})
+	}
+	return clients
+}
+
+// parseInodes parses the inodes of an inode table list, with keys of the
+// form <prefix><n>.<field>
+func parseInodes(output map[string]string, prefix string) []api.BrickInode {
+	var inodes []api.BrickInode
+	for i := 0; ; i++ {
+		key := fmt.Sprintf("%s%d", prefix, i)
+		gfid, ok := output[key+".gfid"]
+		if !ok {
+			break
+		}
+		inodes = append(inodes, api.BrickInode{
+			GFID:    gfid,
+			NLookup: dictUint64(output, key+".nlookup"),
+			Ref:     dictUint32(output, key+".ref"),
+			IAType:  dictInt(output, key+".ia_type"),
+		})
+	}
+	return inodes
+}
+
+// parseInodeTables parses the inode tables of the client connections of a
+// brick from the output of the inode status op. Keys are of the form
+// conn<n>.itable.<field>.
+func parseInodeTables(output map[string]string) []api.InodeTable {
+	count := dictInt(output, "conncount")
+	tables := make([]api.InodeTable, 0, count)
+	for i := 0; i < count; i++ {
+		prefix := fmt.Sprintf("conn%d.itable", i)
+		tables = append(tables, api.InodeTable{
+			ActiveSize: dictUint32(output, prefix+".active_size"),
+			LRUSize:    dictUint32(output, prefix+".lru_size"),
+			PurgeSize:  dictUint32(output, prefix+".purge_size"),
+			Active:     parseInodes(output, prefix+".active"),
+			LRU:        parseInodes(output, prefix+".lru"),
+			Purge:      parseInodes(output, prefix+".purge"),
+		})
+	}
+	return tables
+}
+
+// parseFdTables parses the fd tables of the client connections of a brick
+// from the output of the fd status op. Keys are of the form
+// conn<n>.fdtable.<field>, and the fds are indexed by their entry in the
+// table.
+func parseFdTables(output map[string]string) []api.FdTable {
+	count := dictInt(output, "conncount")
+	tables := make([]api.FdTable, 0, count)
+	for i := 0; i < count; i++ {
+		prefix := fmt.Sprintf("conn%d.fdtable", i)
+		table := api.FdTable{
+			RefCount:  dictInt(output, prefix+".refcount"),
+			MaxFds:    dictUint32(output, prefix+".maxfds"),
+			FirstFree: dictInt(output, prefix+".firstfree"),
+			OpenFds:   dictInt(output, prefix+".openfds"),
+		}
+		for j := 0; j < int(table.MaxFds); j++ {
+			key := fmt.Sprintf("%s.fdentry%d", prefix, j)
+			if _, ok := output[key+".pid"]; !ok {
+				continue
+			}
+			table.Fds = append(table.Fds, api.BrickFd{
+				Entry:    j,
+				Pid:      dictInt(output, key+".pid"),
+				RefCount: dictInt(output, key+".refcount"),
+				Flags:    dictInt(output, key+".flags"),
+			})
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// parseBrickMemInfo parses the memory usage of a brick process from the
+// output of the mem status op. Keys are of the form mallinfo.<field> and
+// pool<n>.<field>.
+func parseBrickMemInfo(output map[string]string) *api.BrickMemInfo {
+	mem := &api.BrickMemInfo{
+		Mallinfo: make(map[string]uint64),
+		MemPools: []api.MemPool{},
+	}
+
+	for key := range output {
+		if strings.HasPrefix(key, "mallinfo.") {
+			mem.Mallinfo[strings.TrimPrefix(key, "mallinfo.")] = dictUint64(output, key)
+		}
+	}
+
+	count := dictInt(output, "mempool-count")
+	for i := 0; i < count; i++ {
+		prefix := fmt.Sprintf("pool%d", i)
+		mem.MemPools = append(mem.MemPools, api.MemPool{
+			Name:        output[prefix+".name"],
+			HotCount:    dictInt(output, prefix+".hotcount"),
+			ColdCount:   dictInt(output, prefix+".coldcount"),
+			PaddedSize:  dictUint64(output, prefix+".paddedsizeof"),
+			AllocCount:  dictUint64(output, prefix+".alloccount"),
+			MaxAlloc:    dictInt(output, prefix+".max_alloc"),
+			PoolMisses:  dictUint64(output, prefix+".pool-misses"),
+			MaxStdAlloc: dictInt(output, prefix+".max-stdalloc"),
+		})
+	}
+	return mem
+}
+
+// parseCallPool parses the pending call stacks of a brick process from the
+// output of the callpool status op. Keys are of the form
+// callpool.stack<n>.<field> and callpool.stack<n>.frame<m>.<field>.
+func parseCallPool(output map[string]string) []api.CallStack {
+	count := dictInt(output, "callpool.count")
+	stacks := make([]api.CallStack, 0, count)
+	for i := 0; i < count; i++ {
+		prefix := fmt.Sprintf("callpool.stack%d", i)
+		stack := api.CallStack{
+			UID:    dictInt(output, prefix+".uid"),
+			GID:    dictInt(output, prefix+".gid"),
+			PID:    dictInt(output, prefix+".pid"),
+			Unique: dictUint64(output, prefix+".unique"),
+			Op:     output[prefix+".op"],
+			Type:   dictInt(output, prefix+".type"),
+		}
+
+		frames := dictInt(output, prefix+".count")
+		for j := 0; j < frames; j++ {
+			key := fmt.Sprintf("%s.frame%d", prefix, j)
+			stack.Frames = append(stack.Frames, api.CallFrame{
+				RefCount:   dictInt(output, key+".refcount"),
+				Translator: output[key+".translator"],
+				Complete:   dictInt(output, key+".complete") != 0,
+				Parent:     output[key+".parent"],
+				WindFrom:   output[key+".windfrom"],
+				WindTo:     output[key+".windto"],
+				UnwindFrom: output[key+".unwindfrom"],
+				UnwindTo:   output[key+".unwindto"],
+			})
+		}
+		stacks = append(stacks, stack)
+	}
+	return stacks
+}
@@ -0,0 +1,134 @@
+package volumecommands
+
+import (
+	"testing"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsValidStatusOption validates isValidStatusOption()
+func TestIsValidStatusOption(t *testing.T) {
+	for _, option := range []string{"detail", "clients", "mem", "inode", "fd", "callpool"} {
+		assert.True(t, isValidStatusOption(option), option)
+	}
+	assert.False(t, isValidStatusOption("tasks"))
+	assert.False(t, isValidStatusOption(""))
+}
+
+// TestParseBrickClients validates parseBrickClients()
+func TestParseBrickClients(t *testing.T) {
+	output := map[string]string{
+		"clientcount":        "2",
+		"client0.hostname":   "192.168.1.1:49151",
+		"client0.bytesread":  "1024",
+		"client0.byteswrite": "2048",
+		"client0.opversion":  "40100",
+		"client1.hostname":   "192.168.1.2:49150",
+		"client1.bytesread":  "10",
+	}
+	assert.Equal(t, []api.BrickClient{
+		{Hostname: "192.168.1.1:49151", BytesRead: 1024, BytesWritten: 2048, OpVersion: 40100},
+		{Hostname: "192.168.1.2:49150", BytesRead: 10},
+	}, parseBrickClients(output))
+
+	assert.Empty(t, parseBrickClients(map[string]string{}))
+}
+
+// TestParseInodeTables validates parseInodeTables()
+func TestParseInodeTables(t *testing.T) {
+	output := map[string]string{
+		"conncount":                    "1",
+		"conn0.itable.active_size":     "1",
+		"conn0.itable.lru_size":        "2",
+		"conn0.itable.purge_size":      "0",
+		"conn0.itable.active0.gfid":    "00000000-0000-0000-0000-000000000001",
+		"conn0.itable.active0.nlookup": "3",
+		"conn0.itable.active0.ref":     "1",
+		"conn0.itable.active0.ia_type": "2",
+		"conn0.itable.lru0.gfid":       "a",
+		"conn0.itable.lru1.gfid":       "b",
+	}
+	tables := parseInodeTables(output)
+	assert.Len(t, tables, 1)
+	assert.Equal(t, uint32(1), tables[0].ActiveSize)
+	assert.Equal(t, uint32(2), tables[0].LRUSize)
+	assert.Equal(t, []api.BrickInode{
+		{GFID: "00000000-0000-0000-0000-000000000001", NLookup: 3, Ref: 1, IAType: 2},
+	}, tables[0].Active)
+	assert.Len(t, tables[0].LRU, 2)
+	assert.Empty(t, tables[0].Purge)
+}
+
+// TestParseFdTables validates parseFdTables()
+func TestParseFdTables(t *testing.T) {
+	output := map[string]string{
+		"conncount":                       "1",
+		"conn0.fdtable.refcount":          "1",
+		"conn0.fdtable.maxfds":            "8",
+		"conn0.fdtable.firstfree":         "2",
+		"conn0.fdtable.openfds":           "1",
+		"conn0.fdtable.fdentry3.pid":      "1234",
+		"conn0.fdtable.fdentry3.refcount": "1",
+		"conn0.fdtable.fdentry3.flags":    "32770",
+	}
+	tables := parseFdTables(output)
+	assert.Equal(t, []api.FdTable{
+		{
+			RefCount:  1,
+			MaxFds:    8,
+			FirstFree: 2,
+			OpenFds:   1,
+			Fds:       []api.BrickFd{{Entry: 3, Pid: 1234, RefCount: 1, Flags: 32770}},
+		},
+	}, tables)
+}
+
+// TestParseBrickMemInfo validates parseBrickMemInfo()
+func TestParseBrickMemInfo(t *testing.T) {
+	output := map[string]string{
+		"mallinfo.arena":     "1000",
+		"mallinfo.uordblks":  "600",
+		"mempool-count":      "1",
+		"pool0.name":         "glusterfs:dict_t",
+		"pool0.hotcount":     "5",
+		"pool0.coldcount":    "100",
+		"pool0.paddedsizeof": "140",
+		"pool0.alloccount":   "50",
+		"pool0.max_alloc":    "7",
+		"pool0.pool-misses":  "0",
+		"pool0.max-stdalloc": "0",
+	}
+	mem := parseBrickMemInfo(output)
+	assert.Equal(t, map[string]uint64{"arena": 1000, "uordblks": 600}, mem.Mallinfo)
+	assert.Equal(t, []api.MemPool{
+		{Name: "glusterfs:dict_t", HotCount: 5, ColdCount: 100, PaddedSize: 140, AllocCount: 50, MaxAlloc: 7},
+	}, mem.MemPools)
+}
+
+// TestParseCallPool validates parseCallPool()
+func TestParseCallPool(t *testing.T) {
+	output := map[string]string{
+		"callpool.count":                    "1",
+		"callpool.stack0.uid":               "0",
+		"callpool.stack0.pid":               "100",
+		"callpool.stack0.unique":            "42",
+		"callpool.stack0.op":                "LOOKUP",
+		"callpool.stack0.type":              "1",
+		"callpool.stack0.count":             "1",
+		"callpool.stack0.frame0.refcount":   "1",
+		"callpool.stack0.frame0.translator": "test-posix",
+		"callpool.stack0.frame0.complete":   "0",
+		"callpool.stack0.frame0.windfrom":   "default_lookup",
+	}
+	assert.Equal(t, []api.CallStack{
+		{
+			PID:    100,
+			Unique: 42,
+			Op:     "LOOKUP",
+			Type:   1,
+			Frames: []api.CallFrame{{RefCount: 1, Translator: "test-posix", WindFrom: "default_lookup"}},
+		},
+	}, parseCallPool(output))
+}
@@ -25,9 +25,15 @@ import (
 )
 
 func stopBricks(c transaction.TxnCtx) error {
+	return stopVolinfoBricks(c, "volinfo")
+}
+
+// stopVolinfoBricks stops the local bricks of the volinfo stored in the
+// transaction context under key
+func stopVolinfoBricks(c transaction.TxnCtx, key string) error {
 
 	var volinfo volume.Volinfo
-	if err := c.Get("volinfo", &volinfo); err != nil {
+	if err := c.Get(key, &volinfo); err != nil {
 		return err
 	}
 
@@ -71,6 +77,16 @@ func stopBricks(c transaction.TxnCtx) error {
 			continue
 		}
 
+		// On graceful shutdown of brick, daemon.Stop() isn't called. The
+		// brick is removed from the store before it is terminated, so
+		// that the daemon supervisor does not restart it.
+		if err := daemon.DelDaemon(brickDaemon); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"name": brickDaemon.Name(),
+				"id":   brickDaemon.ID(),
+			}).Warn("failed to delete brick entry from store, it may be restarted")
+		}
+
 		req := &brick.GfBrickOpReq{
 			Name: b.Path,
 			Op:   int(brick.OpBrickTerminate),
@@ -84,14 +100,6 @@ func stopBricks(c transaction.TxnCtx) error {
 			continue
 		}
 
-		// On graceful shutdown of brick, daemon.Stop() isn't called.
-		if err := daemon.DelDaemon(brickDaemon); err != nil {
-			log.WithError(err).WithFields(log.Fields{
-				"name": brickDaemon.Name(),
-				"id":   brickDaemon.ID(),
-			}).Warn("failed to delete brick entry from store, it may be restarted on GlusterD restart")
-		}
-
 		os.Remove(brickDaemon.PidFile())
 		os.Remove(brickDaemon.SocketFile())
 	}
@@ -0,0 +1,401 @@
+package volumecommands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/daemon"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/servers/sunrpc/dict"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+)
+
+const (
+	// statsOpTop is the io-stats operation which lists the top files
+	// (GF_CLI_STATS_TOP)
+	statsOpTop = 4
+
+	// io-stats keeps at most 100 entries for each metric
+	maxTopLimit = 100
+
+	// The throughput test writes a temporary file of block size times
+	// count bytes on the bricks, or reads as much, so both are bounded
+	maxTopBlockSize uint64 = 1024 * 1024 * 1024
+	maxTopTestSize  uint64 = 10 * 1024 * 1024 * 1024
+)
+
+// topMetrics maps the volume top metrics to the io-stats top operations
+// (gf1_cli_top_op)
+var topMetrics = map[string]int{
+	"open":       1,
+	"read":       2,
+	"write":      3,
+	"opendir":    4,
+	"readdir":    5,
+	"read-perf":  6,
+	"write-perf": 7,
+}
+
+// topReq is the volume top request sent to the nodes
+type topReq struct {
+	Metric     string
+	BrickID    string
+	Limit      int
+	BlockSize  uint64
+	BlockCount uint64
+}
+
+func isPerfMetric(metric string) bool {
+	return metric == "read-perf" || metric == "write-perf"
+}
+
+// parseTopLimit validates the maximum number of top entries requested. The
+// limit defaults to maxTopLimit.
+func parseTopLimit(value string) (int, error) {
+	if value == "" {
+		return maxTopLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > maxTopLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxTopLimit)
+	}
+	return limit, nil
+}
+
+// parseTopReq validates the volume top metric and the result limit given as
+// query parameters
+func parseTopReq(metric string, query url.Values) (*topReq, error) {
+	if _, ok := topMetrics[metric]; !ok {
+		return nil, fmt.Errorf("invalid metric %s", metric)
+	}
+
+	if query.Get("block-size") != "" || query.Get("count") != "" {
+		return nil, errors.New("the throughput test has to be requested with POST /volumes/{volname}/top/{metric}/throughput")
+	}
+
+	limit, err := parseTopLimit(query.Get("limit"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &topReq{
+		Metric: metric,
+		Limit:  limit,
+	}, nil
+}
+
+// newTopThroughputReq validates a throughput test request for the read-perf
+// and write-perf metrics
+func newTopThroughputReq(metric string, req *api.VolumeTopThroughputReq) (*topReq, error) {
+	if !isPerfMetric(metric) {
+		return nil, errors.New("the throughput test is only run for the read-perf and write-perf metrics")
+	}
+
+	limit := maxTopLimit
+	if req.Limit != 0 {
+		var err error
+		if limit, err = parseTopLimit(strconv.Itoa(req.Limit)); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.BlockSize == 0 || req.BlockSize > maxTopBlockSize {
+		return nil, fmt.Errorf("block size must be between 1 and %d bytes", maxTopBlockSize)
+	}
+	if req.Count == 0 {
+		return nil, errors.New("invalid count")
+	}
+	if req.Count > maxTopTestSize/req.BlockSize {
+		return nil, fmt.Errorf("the throughput test can read or write at most %d bytes", maxTopTestSize)
+	}
+
+	return &topReq{
+		Metric:     metric,
+		Limit:      limit,
+		BlockSize:  req.BlockSize,
+		BlockCount: req.Count,
+	}, nil
+}
+
+// findBrick returns the brick of the volume given as <peer-id>:<path> or
+// <hostname>:<path>
+func findBrick(volinfo *volume.Volinfo, name string) (*brick.Brickinfo, error) {
+	for _, b := range volinfo.GetBricks() {
+		if b.String() == name || b.Hostname+":"+b.Path == name {
+			return &b, nil
+		}
+	}
+	return nil, gderrors.ErrBrickNotFound
+}
+
+func registerVolTopStepFuncs() {
+	transaction.RegisterStepFunc(txnVolumeTop, "volume.Top")
+}
+
+func volumeTopHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := parseTopReq(mux.Vars(r)["metric"], r.URL.Query())
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, status, err := volumeTop(ctx, mux.Vars(r)["volname"], r.URL.Query().Get("brick"), req)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+// volumeTopThroughputHandler runs a throughput test on the bricks before
+// listing the top files for the read-perf and write-perf metrics. It is not
+// a GET request, as the test writes to the bricks.
+func volumeTopThroughputHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var throughputReq api.VolumeTopThroughputReq
+	if err := restutils.UnmarshalRequest(r, &throughputReq); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, gderrors.ErrJSONParsingFailed)
+		return
+	}
+
+	req, err := newTopThroughputReq(mux.Vars(r)["metric"], &throughputReq)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, status, err := volumeTop(ctx, mux.Vars(r)["volname"], throughputReq.Brick, req)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+// volumeTop gets the top files of the bricks of the volume, or of the given
+// brick only
+func volumeTop(ctx context.Context, volname, brickName string, req *topReq) (*api.VolumeTopResp, int, error) {
+	logger := gdctx.GetReqLogger(ctx)
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
+	}
+	defer txn.Done()
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
+	}
+
+	if volinfo.State != volume.VolStarted {
+		return nil, http.StatusBadRequest, gderrors.ErrVolNotStarted
+	}
+
+	nodes := volinfo.Nodes()
+	if brickName != "" {
+		b, err := findBrick(volinfo, brickName)
+		if err != nil {
+			status, err := restutils.ErrToStatusCode(err)
+			return nil, status, err
+		}
+		req.BrickID = b.ID.String()
+		nodes = []uuid.UUID{b.PeerID}
+	}
+
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "volume.Top",
+			Nodes:  nodes,
+		},
+	}
+
+	if err := txn.Ctx.Set("volinfo", volinfo); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := txn.Ctx.Set("top-req", req); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField(
+			"volname", volname).Error("transaction to get volume top failed")
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
+	}
+
+	var bricks []api.BrickTopInfo
+	for _, node := range nodes {
+		var nodeResult []api.BrickTopInfo
+		if err := txn.Ctx.GetNodeResult(node, "node-result", &nodeResult); err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		bricks = append(bricks, nodeResult...)
+	}
+
+	return &api.VolumeTopResp{
+		Volume:  volname,
+		Metric:  req.Metric,
+		Entries: aggregateTopEntries(bricks, req.Limit),
+		Bricks:  bricks,
+	}, http.StatusOK, nil
+}
+
+// aggregateTopEntries returns the top entries across all the bricks, highest
+// value first
+func aggregateTopEntries(bricks []api.BrickTopInfo, limit int) []api.TopEntry {
+	entries := []api.TopEntry{}
+	for _, b := range bricks {
+		for _, e := range b.Entries {
+			e.Brick = b.Brick
+			entries = append(entries, e)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Value > entries[j].Value
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+// parseBrickTop converts the volume top output of a brick from io-stats into
+// a BrickTopInfo
+func parseBrickTop(brickName string, output map[string]string) (api.BrickTopInfo, error) {
+	info := api.BrickTopInfo{
+		Brick:   brickName,
+		Entries: []api.TopEntry{},
+	}
+
+	// members is missing when the brick has no entries for the metric
+	members, _ := strconv.Atoi(output["members"])
+
+	for i := 1; i <= members; i++ {
+		value, err := strconv.ParseFloat(output[fmt.Sprintf("value-%d", i)], 64)
+		if err != nil {
+			return info, fmt.Errorf("invalid value of top entry %d: %s", i, err)
+		}
+
+		entry := api.TopEntry{
+			Filename: output[fmt.Sprintf("filename-%d", i)],
+			Value:    value,
+		}
+
+		if sec, err := strconv.ParseInt(output[fmt.Sprintf("time-sec-%d", i)], 10, 64); err == nil {
+			usec, _ := strconv.ParseInt(output[fmt.Sprintf("time-usec-%d", i)], 10, 64)
+			entry.Time = time.Unix(sec, usec*int64(time.Microsecond)).UTC().Format(time.RFC3339)
+		}
+		info.Entries = append(info.Entries, entry)
+	}
+
+	info.CurrentOpen, _ = strconv.ParseUint(output["current-open"], 10, 64)
+	info.MaxOpen, _ = strconv.ParseUint(output["max-open"], 10, 64)
+	info.MaxOpenTime = output["max-openfd-time"]
+	info.Throughput, _ = strconv.ParseFloat(output["throughput"], 64)
+	info.Duration, _ = strconv.ParseFloat(output["time"], 64)
+
+	return info, nil
+}
+
+func txnVolumeTop(c transaction.TxnCtx) error {
+	var volinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		return err
+	}
+
+	var req topReq
+	if err := c.Get("top-req", &req); err != nil {
+		return err
+	}
+
+	nodeTopInfo := []api.BrickTopInfo{}
+	for _, b := range volinfo.GetLocalBricks() {
+		if req.BrickID != "" && b.ID.String() != req.BrickID {
+			continue
+		}
+
+		brickDaemon, err := brick.NewGlusterfsd(b)
+		if err != nil {
+			c.Logger().WithError(err).WithField(
+				"brick", b.String()).Error("failed to initiate brick daemon")
+			return err
+		}
+
+		client, err := daemon.GetRPCClient(brickDaemon)
+		if err != nil {
+			c.Logger().WithError(err).WithField(
+				"brick", b.String()).Error("failed to connect to brick, aborting volume top operation")
+			return err
+		}
+
+		reqDict := map[string]string{
+			"volname":  volinfo.Name,
+			"vol-id":   volinfo.ID.String(),
+			"op":       strconv.Itoa(statsOpTop),
+			"top-op":   strconv.Itoa(topMetrics[req.Metric]),
+			"list-cnt": strconv.Itoa(req.Limit),
+		}
+		if req.BlockSize != 0 {
+			reqDict["blk-size"] = strconv.FormatUint(req.BlockSize, 10)
+			reqDict["blk-cnt"] = strconv.FormatUint(req.BlockCount, 10)
+		}
+
+		rpcReq := &brick.GfBrickOpReq{
+			Name: b.Path,
+			Op:   int(brick.OpBrickXlatorInfo),
+		}
+		rpcReq.Input, err = dict.Serialize(reqDict)
+		if err != nil {
+			c.Logger().WithError(err).WithField(
+				"reqDict", reqDict).Error("failed to convert map to slice of bytes")
+			return err
+		}
+
+		var rsp brick.GfBrickOpRsp
+		err = client.Call("Brick.OpBrickXlatorInfo", rpcReq, &rsp)
+		if err != nil || rsp.OpRet != 0 {
+			c.Logger().WithError(err).WithField(
+				"brick", b.String()).Error("failed to send volume top RPC")
+			if err == nil {
+				err = fmt.Errorf("volume top failed on brick %s: %s", b.String(), rsp.OpErrstr)
+			}
+			return err
+		}
+
+		output, err := dict.Unserialize(rsp.Output)
+		if err != nil {
+			return errors.New("error unserializing the output")
+		}
+
+		info, err := parseBrickTop(b.String(), output)
+		if err != nil {
+			return err
+		}
+		nodeTopInfo = append(nodeTopInfo, info)
+	}
+
+	return c.SetNodeResult(gdctx.MyUUID, "node-result", &nodeTopInfo)
+}
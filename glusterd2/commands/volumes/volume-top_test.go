@@ -0,0 +1,142 @@
+package volumecommands
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseTopReq validates parseTopReq()
+func TestParseTopReq(t *testing.T) {
+	req, err := parseTopReq("open", url.Values{})
+	assert.Nil(t, err)
+	assert.Equal(t, "open", req.Metric)
+	assert.Equal(t, maxTopLimit, req.Limit)
+
+	req, err = parseTopReq("read", url.Values{"limit": {"10"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, req.Limit)
+
+	invalid := []struct {
+		metric string
+		query  url.Values
+	}{
+		{"stat", url.Values{}},
+		{"read", url.Values{"limit": {"0"}}},
+		{"read", url.Values{"limit": {"101"}}},
+		// The throughput test is not run by GET requests
+		{"write-perf", url.Values{"block-size": {"4096"}, "count": {"100"}}},
+		{"read-perf", url.Values{"block-size": {"4096"}}},
+	}
+	for _, tc := range invalid {
+		_, err = parseTopReq(tc.metric, tc.query)
+		assert.NotNil(t, err, "metric %s, query %v", tc.metric, tc.query)
+	}
+}
+
+// TestNewTopThroughputReq validates newTopThroughputReq()
+func TestNewTopThroughputReq(t *testing.T) {
+	req, err := newTopThroughputReq("write-perf", &api.VolumeTopThroughputReq{BlockSize: 4096, Count: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4096), req.BlockSize)
+	assert.Equal(t, uint64(100), req.BlockCount)
+	assert.Equal(t, maxTopLimit, req.Limit)
+
+	req, err = newTopThroughputReq("read-perf", &api.VolumeTopThroughputReq{BlockSize: maxTopBlockSize, Count: 10, Limit: 5})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, req.Limit)
+
+	invalid := []struct {
+		metric string
+		req    api.VolumeTopThroughputReq
+	}{
+		{"read", api.VolumeTopThroughputReq{BlockSize: 4096, Count: 100}},
+		{"read-perf", api.VolumeTopThroughputReq{BlockSize: 4096}},
+		{"read-perf", api.VolumeTopThroughputReq{Count: 100}},
+		{"read-perf", api.VolumeTopThroughputReq{BlockSize: maxTopBlockSize + 1, Count: 1}},
+		{"write-perf", api.VolumeTopThroughputReq{BlockSize: maxTopBlockSize, Count: 11}},
+		{"write-perf", api.VolumeTopThroughputReq{BlockSize: 1 << 20, Count: 1 << 62}},
+		{"write-perf", api.VolumeTopThroughputReq{BlockSize: 4096, Count: 1, Limit: 101}},
+	}
+	for _, tc := range invalid {
+		_, err = newTopThroughputReq(tc.metric, &tc.req)
+		assert.NotNil(t, err, "metric %s, req %+v", tc.metric, tc.req)
+	}
+}
+
+// TestParseBrickTop validates parseBrickTop()
+func TestParseBrickTop(t *testing.T) {
+	output := map[string]string{
+		"members":         "2",
+		"filename-1":      "/dir/a",
+		"value-1":         "12",
+		"filename-2":      "/dir/b",
+		"value-2":         "3",
+		"current-open":    "4",
+		"max-open":        "9",
+		"max-openfd-time": "2018-08-01 10:00:00",
+	}
+	info, err := parseBrickTop("peer:/bricks/b1", output)
+	assert.Nil(t, err)
+	assert.Equal(t, "peer:/bricks/b1", info.Brick)
+	assert.Equal(t, []api.TopEntry{
+		{Filename: "/dir/a", Value: 12},
+		{Filename: "/dir/b", Value: 3},
+	}, info.Entries)
+	assert.Equal(t, uint64(4), info.CurrentOpen)
+	assert.Equal(t, uint64(9), info.MaxOpen)
+
+	output = map[string]string{
+		"members":     "1",
+		"filename-1":  "/dir/a",
+		"value-1":     "105.500000",
+		"time-sec-1":  "1533117600",
+		"time-usec-1": "0",
+		"throughput":  "210.250000",
+		"time":        "0.125000",
+	}
+	info, err = parseBrickTop("peer:/bricks/b1", output)
+	assert.Nil(t, err)
+	assert.Equal(t, 105.5, info.Entries[0].Value)
+	assert.Equal(t, "2018-08-01T10:00:00Z", info.Entries[0].Time)
+	assert.Equal(t, 210.25, info.Throughput)
+	assert.Equal(t, 0.125, info.Duration)
+
+	info, err = parseBrickTop("peer:/bricks/b1", map[string]string{})
+	assert.Nil(t, err)
+	assert.Empty(t, info.Entries)
+
+	_, err = parseBrickTop("peer:/bricks/b1", map[string]string{"members": "1", "value-1": "x"})
+	assert.NotNil(t, err)
+}
+
+// TestAggregateTopEntries validates aggregateTopEntries()
+func TestAggregateTopEntries(t *testing.T) {
+	bricks := []api.BrickTopInfo{
+		{
+			Brick: "b1",
+			Entries: []api.TopEntry{
+				{Filename: "/a", Value: 10},
+				{Filename: "/b", Value: 2},
+			},
+		},
+		{
+			Brick: "b2",
+			Entries: []api.TopEntry{
+				{Filename: "/c", Value: 5},
+			},
+		},
+	}
+
+	entries := aggregateTopEntries(bricks, 2)
+	assert.Equal(t, []api.TopEntry{
+		{Brick: "b1", Filename: "/a", Value: 10},
+		{Brick: "b2", Filename: "/c", Value: 5},
+	}, entries)
+
+	assert.Len(t, aggregateTopEntries(bricks, 100), 3)
+	assert.Empty(t, aggregateTopEntries(nil, 100))
+}
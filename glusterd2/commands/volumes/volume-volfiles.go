@@ -0,0 +1,105 @@
+package volumecommands
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/gorilla/mux"
+)
+
+// redacted replaces the credentials of the volume in the volfiles sent to
+// REST API clients
+const redacted = "<redacted>"
+
+// redactVolfiles replaces the username and the password which the trusted
+// clients of the volume authenticate with in the volfiles
+func redactVolfiles(auth volume.VolAuth, volfiles map[string]string) {
+	var pairs []string
+	for _, secret := range []string{auth.Username, auth.Password} {
+		if secret != "" {
+			pairs = append(pairs, secret, redacted)
+		}
+	}
+	if len(pairs) == 0 {
+		return
+	}
+
+	replacer := strings.NewReplacer(pairs...)
+	for id, content := range volfiles {
+		volfiles[id] = replacer.Replace(content)
+	}
+}
+
+// generateVolfiles generates the volfiles of the volume to be sent to REST
+// API clients, ie. without the credentials of the volume
+func generateVolfiles(volinfo *volume.Volinfo) (map[string]string, error) {
+	volfiles, err := volgen.Volfiles(volinfo)
+	if err != nil {
+		return nil, err
+	}
+	redactVolfiles(volinfo.Auth, volfiles)
+	return volfiles, nil
+}
+
+// renderVolfiles generates the volfiles of the volume from its current
+// volinfo. Nothing is read from or saved to the volfiles on disk.
+func renderVolfiles(r *http.Request) (map[string]string, int, error) {
+	volname := mux.Vars(r)["volname"]
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		return nil, status, err
+	}
+
+	volfiles, err := generateVolfiles(volinfo)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return volfiles, http.StatusOK, nil
+}
+
+func volumeVolfileListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	volfiles, status, err := renderVolfiles(r)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	resp := make(api.VolfileListResp, 0, len(volfiles))
+	for id, content := range volfiles {
+		resp = append(resp, api.VolfileGetResp{VolfileID: id, Content: content})
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].VolfileID < resp[j].VolfileID
+	})
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+func volumeVolfileGetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	volfileID := mux.Vars(r)["volfileid"]
+
+	volfiles, status, err := renderVolfiles(r)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	content, ok := volfiles[volfileID]
+	if !ok {
+		restutils.SendHTTPError(ctx, w, http.StatusNotFound, gderrors.ErrVolfileNotFound)
+		return
+	}
+
+	resp := &api.VolfileGetResp{VolfileID: volfileID, Content: content}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
@@ -0,0 +1,34 @@
+package volumecommands
+
+import (
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/volume"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRedactVolfiles validates redactVolfiles()
+func TestRedactVolfiles(t *testing.T) {
+	auth := volume.VolAuth{
+		Username: "5d9a1b8e-user",
+		Password: "0c7f2e4a-pass",
+	}
+	volfiles := map[string]string{
+		"vol": "volume vol-client-0\n    type protocol/client\n    option username 5d9a1b8e-user\n    option password 0c7f2e4a-pass\nend-volume\n",
+		"brick": "volume vol-server\n    type protocol/server\n    option auth.login./bricks/b1.allow 5d9a1b8e-user\n" +
+			"    option auth.login.5d9a1b8e-user.password 0c7f2e4a-pass\nend-volume\n",
+	}
+
+	redactVolfiles(auth, volfiles)
+	for id, content := range volfiles {
+		assert.NotContains(t, content, auth.Username, id)
+		assert.NotContains(t, content, auth.Password, id)
+	}
+	assert.Contains(t, volfiles["vol"], "option password <redacted>")
+
+	// Volumes without credentials are left untouched
+	volfiles = map[string]string{"vol": "volume vol-client-0\nend-volume\n"}
+	redactVolfiles(volume.VolAuth{}, volfiles)
+	assert.Equal(t, "volume vol-client-0\nend-volume\n", volfiles["vol"])
+}
@@ -7,8 +7,13 @@ import (
 	"path"
 	"strings"
 
+	"github.com/gluster/glusterd2/glusterd2/audit"
+	"github.com/gluster/glusterd2/glusterd2/backup"
+	"github.com/gluster/glusterd2/glusterd2/daemon"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/peertls"
 	"github.com/gluster/glusterd2/glusterd2/store"
+	"github.com/gluster/glusterd2/glusterd2/transactionv2"
 	"github.com/gluster/glusterd2/pkg/logging"
 	"github.com/gluster/glusterd2/pkg/tracing"
 
@@ -51,19 +56,27 @@ func initFlags() {
 
 	// TODO: Change default to false (disabled) in future.
 	flag.Bool("statedump", true, "Enable /statedump endpoint for metrics.")
+	flag.Bool("metrics-noauth", false, "Allow scraping the /metrics endpoint without authentication when REST API authentication is enabled.")
 
 	flag.String("clientaddress", defaultclientaddress, "Address to bind the REST service.")
 	flag.String("peeraddress", defaultpeeraddress, "Address to bind the inter glusterd2 RPC service.")
 
-	// TODO: SSL/TLS is currently only implemented for REST interface
 	flag.String("cert-file", "", "Certificate used for SSL/TLS connections from clients to glusterd2.")
 	flag.String("key-file", "", "Private key for the SSL/TLS certificate.")
+	flag.StringSlice("client-ca-file", nil, "CA certificates of the issuers of client certificates. Enables client certificate authentication. Can be given multiple times.")
+	flag.StringSlice("client-crl-file", nil, "Certificate revocation lists of the issuers of client certificates. Can be given multiple times.")
+	flag.Bool("client-cert-required", false, "Reject SSL/TLS connections from clients without a client certificate.")
 
 	// PID file
 	flag.String("pidfile", "", "PID file path. (default \"rundir/glusterd2.pid)\"")
 
+	peertls.InitFlags()
 	store.InitFlags()
 	tracing.InitFlags()
+	audit.InitFlags()
+	backup.InitFlags()
+	transaction.InitFlags()
+	daemon.InitFlags()
 
 	flag.Parse()
 }
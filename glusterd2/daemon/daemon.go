@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"github.com/gluster/glusterd2/glusterd2/events"
+	"github.com/gluster/glusterd2/glusterd2/metrics"
 	"github.com/gluster/glusterd2/pkg/errors"
 
 	log "github.com/sirupsen/logrus"
@@ -45,6 +46,18 @@ type Daemon interface {
 	ID() string
 }
 
+// Transient can be implemented by daemons which exit by themselves once they
+// are done, like the rebalance process. Transient daemons are not restarted
+// by the supervisor when they exit.
+type Transient interface {
+	Transient() bool
+}
+
+func isTransient(d Daemon) bool {
+	t, ok := d.(Transient)
+	return ok && t.Transient()
+}
+
 // Start function starts the daemon located at path returned by Path() with
 // args returned by Args() function. If the pidfile to the daemon exists, the
 // contents are read to determine if the daemon is already running. If it
@@ -74,6 +87,7 @@ func Start(d Daemon, wait bool, logger log.FieldLogger) error {
 	cmd := exec.Command(d.Path(), d.Args()...)
 	err = cmd.Start()
 	if err != nil {
+		metrics.DaemonStartFailures.WithLabelValues(d.Name()).Inc()
 		events.Broadcast(newEvent(d, daemonStartFailed, 0))
 		return err
 	}
@@ -88,6 +102,7 @@ func Start(d Daemon, wait bool, logger log.FieldLogger) error {
 
 		if errStatus != nil {
 			// Child exited with error
+			metrics.DaemonStartFailures.WithLabelValues(d.Name()).Inc()
 			events.Broadcast(newEvent(d, daemonStartFailed, 0))
 			return errStatus
 		}
@@ -98,6 +113,7 @@ func Start(d Daemon, wait bool, logger log.FieldLogger) error {
 		pid, err = ReadPidFromFile(d.PidFile())
 		if err != nil {
 			logger.WithError(err).WithField("pidfile", d.PidFile()).Error("Could not read pidfile")
+			metrics.DaemonStartFailures.WithLabelValues(d.Name()).Inc()
 			events.Broadcast(newEvent(d, daemonStartFailed, 0))
 			return err
 		}
@@ -121,6 +137,8 @@ func Start(d Daemon, wait bool, logger log.FieldLogger) error {
 		}()
 	}
 
+	metrics.DaemonStarts.WithLabelValues(d.Name()).Inc()
+
 	// Save daemon information in the store so it can be restarted
 	if err := saveDaemon(d); err != nil {
 		logger.WithError(err).WithField("name", d.Name()).Warn("failed to save daemon information into store, daemon may not be restarted on GlusterD restart")
@@ -166,6 +184,15 @@ func Stop(d Daemon, force bool, logger log.FieldLogger) error {
 	}).Debug("Stopping daemon.")
 	events.Broadcast(newEvent(d, daemonStopping, pid))
 
+	// The daemon is removed from the store before it is killed, so that the
+	// supervisor does not restart it
+	if err := DelDaemon(d); err != nil {
+		logger.WithError(err).WithFields(log.Fields{
+			"name": d.Name(),
+			"pid":  pid,
+		}).Warn("failed to delete daemon from store, it may be restarted")
+	}
+
 	err = Kill(pid, force)
 
 	// TODO: Do this under some lock ?
@@ -181,13 +208,6 @@ func Stop(d Daemon, force bool, logger log.FieldLogger) error {
 		events.Broadcast(newEvent(d, daemonStopped, 0))
 	}
 
-	if err := DelDaemon(d); err != nil {
-		logger.WithError(err).WithFields(log.Fields{
-			"name": d.Name(),
-			"pid":  pid,
-		}).Warn("failed to delete daemon from store, it may be restarted on GlusterD restart")
-	}
-
 	return nil
 }
 
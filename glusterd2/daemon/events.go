@@ -11,15 +11,18 @@ import (
 type daemonEvent string
 
 const (
-	daemonStarting       daemonEvent = "daemon.starting"
-	daemonStarted                    = "daemon.started"
-	daemonStartFailed                = "daemon.startfailed"
-	daemonStopping                   = "daemon.stopping"
-	daemonStopped                    = "daemon.stopped"
-	daemonStopFailed                 = "daemon.stopfailed"
-	daemonStartingAll                = "daemon.startingall"
-	daemonStartedAll                 = "daemon.startedall"
-	daemonStartAllFailed             = "daemon.startallfailed"
+	daemonStarting         daemonEvent = "daemon.starting"
+	daemonStarted                      = "daemon.started"
+	daemonStartFailed                  = "daemon.startfailed"
+	daemonStopping                     = "daemon.stopping"
+	daemonStopped                      = "daemon.stopped"
+	daemonStopFailed                   = "daemon.stopfailed"
+	daemonStartingAll                  = "daemon.startingall"
+	daemonStartedAll                   = "daemon.startedall"
+	daemonStartAllFailed               = "daemon.startallfailed"
+	daemonCrashed                      = "daemon.crashed"
+	daemonRestarted                    = "daemon.restarted"
+	daemonRestartAbandoned             = "daemon.restartabandoned"
 )
 
 // newEvent returns an event of given type with daemon data filled
@@ -6,6 +6,8 @@ type storedDaemon struct {
 	DName, DPath, DSocketFile, DPidFile, DID string
 
 	DArgs []string
+
+	DTransient bool
 }
 
 func newStoredDaemon(d Daemon) *storedDaemon {
@@ -16,6 +18,7 @@ func newStoredDaemon(d Daemon) *storedDaemon {
 		DSocketFile: d.SocketFile(),
 		DPidFile:    d.PidFile(),
 		DID:         d.ID(),
+		DTransient:  isTransient(d),
 	}
 }
 
@@ -42,3 +45,7 @@ func (s *storedDaemon) PidFile() string {
 func (s *storedDaemon) ID() string {
 	return s.DID
 }
+
+func (s *storedDaemon) Transient() bool {
+	return s.DTransient
+}
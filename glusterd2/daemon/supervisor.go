@@ -0,0 +1,331 @@
+package daemon
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/events"
+	"github.com/gluster/glusterd2/glusterd2/metrics"
+	"github.com/gluster/glusterd2/pkg/backoff"
+	"github.com/gluster/glusterd2/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+	flag "github.com/spf13/pflag"
+	config "github.com/spf13/viper"
+)
+
+const (
+	checkIntervalOpt = "daemon-check-interval"
+	maxRestartsOpt   = "daemon-max-restarts"
+	restartWindowOpt = "daemon-restart-window"
+
+	defaultCheckInterval = 30 * time.Second
+	defaultMaxRestarts   = 5
+	defaultRestartWindow = 10 * time.Minute
+
+	// exitSettleTime is how long a check triggered by a brick signing out
+	// waits, so that daemons being stopped are removed from the store
+	// before they are looked at
+	exitSettleTime = 2 * time.Second
+)
+
+// InitFlags sets up the daemon supervision flags
+func InitFlags() {
+	flag.Duration(checkIntervalOpt, defaultCheckInterval, "Interval at which daemons are checked and restarted if they have exited. 0 disables restarting daemons.")
+	flag.Int(maxRestartsOpt, defaultMaxRestarts, "Number of times a daemon is restarted before giving up, unless it keeps running for the daemon restart window.")
+	flag.Duration(restartWindowOpt, defaultRestartWindow, "Duration for which a restarted daemon has to keep running to reset its restart count.")
+}
+
+// supervisedDaemon is the restart state of a daemon which has exited
+type supervisedDaemon struct {
+	d           Daemon
+	backoff     backoff.BackOff
+	restarts    int
+	down        bool
+	gaveUp      bool
+	nextRestart time.Time
+	restartedAt time.Time
+}
+
+// supervisor restarts the daemons started by this node when they exit
+// without being stopped by glusterd2. Daemons are checked periodically and
+// when a brick signs out of the portmapper. They are restarted with an
+// exponential backoff, and are given up on after too many restarts.
+type supervisor struct {
+	interval    time.Duration
+	maxRestarts int
+	window      time.Duration
+
+	daemons  map[string]*supervisedDaemon
+	checkCh  chan struct{}
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// RestartFunc restarts a daemon which has exited
+type RestartFunc func(d Daemon, logger log.FieldLogger) error
+
+var (
+	supervisorMu     sync.Mutex
+	daemonSupervisor *supervisor
+
+	restartFuncsMu sync.Mutex
+	restartFuncs   = make(map[string]RestartFunc)
+
+	// getDaemonFunc is used by the supervisor to check that a daemon is
+	// still saved in the store. It can be overridden in tests.
+	getDaemonFunc = getDaemon
+)
+
+// RegisterRestartFunc registers the function used by the supervisor to
+// restart the daemons with the given name, in place of starting them again
+// with Start. Brick processes are restarted this way, so that the bricks
+// multiplexed into a brick process come back along with it.
+func RegisterRestartFunc(name string, f RestartFunc) {
+	restartFuncsMu.Lock()
+	defer restartFuncsMu.Unlock()
+	restartFuncs[name] = f
+}
+
+// restart restarts a daemon using the restart function registered for it,
+// if any
+func restart(d Daemon, logger log.FieldLogger) error {
+	restartFuncsMu.Lock()
+	f, ok := restartFuncs[d.Name()]
+	restartFuncsMu.Unlock()
+
+	if ok {
+		return f(d, logger)
+	}
+	return Start(d, true, logger)
+}
+
+// StartSupervisor starts supervising the daemons started by this node
+func StartSupervisor() {
+	interval := config.GetDuration(checkIntervalOpt)
+	if interval <= 0 {
+		log.Info("daemon supervision is disabled")
+		return
+	}
+
+	s := &supervisor{
+		interval:    interval,
+		maxRestarts: config.GetInt(maxRestartsOpt),
+		window:      config.GetDuration(restartWindowOpt),
+		daemons:     make(map[string]*supervisedDaemon),
+		checkCh:     make(chan struct{}, 1),
+		stopChan:    make(chan struct{}),
+	}
+
+	supervisorMu.Lock()
+	daemonSupervisor = s
+	supervisorMu.Unlock()
+
+	s.wg.Add(1)
+	go s.run()
+}
+
+// StopSupervisor stops supervising the daemons. The daemons are left
+// running.
+func StopSupervisor() {
+	supervisorMu.Lock()
+	s := daemonSupervisor
+	daemonSupervisor = nil
+	supervisorMu.Unlock()
+
+	if s != nil {
+		s.stop()
+	}
+}
+
+// CheckDaemons makes the supervisor look for exited daemons shortly,
+// without waiting for the next periodic check. It is called when a brick
+// signs out or disconnects from the portmapper.
+func CheckDaemons() {
+	supervisorMu.Lock()
+	s := daemonSupervisor
+	supervisorMu.Unlock()
+
+	if s == nil {
+		return
+	}
+
+	time.AfterFunc(exitSettleTime, func() {
+		select {
+		case s.checkCh <- struct{}{}:
+		default:
+			// A check is already pending
+		}
+	})
+}
+
+func (s *supervisor) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+		s.wg.Wait()
+	})
+}
+
+func (s *supervisor) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+		case <-s.checkCh:
+		case <-retry:
+		}
+
+		retry = nil
+		if next := s.check(time.Now()); !next.IsZero() {
+			retry = time.After(time.Until(next))
+		}
+	}
+}
+
+func newBackOff() backoff.BackOff {
+	return backoff.BackOff{
+		Duration:     time.Second,
+		Factor:       2,
+		MaxDuration:  time.Minute,
+		JitterFactor: 0.1,
+	}
+}
+
+// check looks at all the daemons saved in the store, and restarts the ones
+// which have exited and are due for a restart. It returns the time of the
+// earliest pending restart, or the zero time if there is none.
+func (s *supervisor) check(now time.Time) time.Time {
+	ds, err := getDaemons()
+	if err != nil {
+		log.WithError(err).Warn("failed to get saved daemons, skipping daemon check")
+		return time.Time{}
+	}
+
+	stored := make(map[string]bool)
+	var next time.Time
+	for _, d := range ds {
+		if isTransient(d) {
+			continue
+		}
+		stored[d.ID()] = true
+
+		at := s.checkDaemon(d, now)
+		if !at.IsZero() && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+
+	// Forget the daemons which were stopped
+	for id := range s.daemons {
+		if !stored[id] {
+			delete(s.daemons, id)
+		}
+	}
+
+	return next
+}
+
+// checkDaemon restarts a daemon if it has exited and is due for a restart.
+// It returns the time of its next restart, or the zero time if it is not to
+// be restarted.
+func (s *supervisor) checkDaemon(d Daemon, now time.Time) time.Time {
+	sd, ok := s.daemons[d.ID()]
+
+	if running, _ := IsRunning(d); running {
+		if !ok {
+			return time.Time{}
+		}
+		// A daemon which was given up on may have been started again
+		// manually, and a restarted daemon which kept running for the
+		// restart window is considered healthy again
+		if sd.gaveUp || (!sd.down && now.Sub(sd.restartedAt) >= s.window) {
+			delete(s.daemons, d.ID())
+		}
+		return time.Time{}
+	}
+
+	if !ok {
+		sd = &supervisedDaemon{d: d, backoff: newBackOff()}
+		s.daemons[d.ID()] = sd
+	}
+	if sd.gaveUp {
+		return time.Time{}
+	}
+
+	logger := log.WithFields(log.Fields{
+		"name": d.Name(),
+		"id":   d.ID(),
+	})
+
+	if !sd.down {
+		sd.down = true
+		logger.Warn("daemon has exited")
+		metrics.DaemonCrashes.WithLabelValues(d.Name()).Inc()
+		events.Broadcast(newEvent(d, daemonCrashed, 0))
+
+		if !s.scheduleRestart(sd, now, logger) {
+			return time.Time{}
+		}
+	}
+
+	if now.Before(sd.nextRestart) {
+		return sd.nextRestart
+	}
+
+	// The daemon may have been stopped while waiting to be restarted
+	if _, err := getDaemonFunc(d.ID()); err != nil {
+		delete(s.daemons, d.ID())
+		return time.Time{}
+	}
+
+	logger.WithField("restarts", sd.restarts+1).Info("restarting daemon")
+	err := restart(d, logger)
+	if err == nil {
+		// Restart functions do not always fail when the daemon could
+		// not be brought back, the brick processes restarted by brickmux
+		// are only logged on failure
+		if running, _ := IsRunning(d); !running {
+			err = errors.ErrProcessNotFound
+		}
+	}
+	if err != nil && err != errors.ErrProcessAlreadyRunning {
+		logger.WithError(err).Error("failed to restart daemon")
+		sd.restarts++
+		if !s.scheduleRestart(sd, now, logger) {
+			return time.Time{}
+		}
+		return sd.nextRestart
+	}
+
+	sd.restarts++
+	sd.down = false
+	sd.restartedAt = now
+	_, pid := IsRunning(d)
+	metrics.DaemonRestarts.WithLabelValues(d.Name()).Inc()
+	events.Broadcast(newEvent(d, daemonRestarted, pid))
+
+	return time.Time{}
+}
+
+// scheduleRestart sets the time of the next restart of an exited daemon as
+// per its backoff. It gives up on the daemon and returns false if it has
+// already been restarted too many times.
+func (s *supervisor) scheduleRestart(sd *supervisedDaemon, now time.Time, logger log.FieldLogger) bool {
+	if sd.restarts >= s.maxRestarts {
+		sd.gaveUp = true
+		logger.WithField("restarts", sd.restarts).Error("daemon keeps exiting, giving up on restarting it")
+		events.Broadcast(newEvent(sd.d, daemonRestartAbandoned, 0))
+		return false
+	}
+
+	sd.nextRestart = now.Add(sd.backoff.NextDuration())
+	return true
+}
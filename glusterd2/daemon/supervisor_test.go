@@ -0,0 +1,166 @@
+package daemon
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type testDaemon struct {
+	pidFile string
+}
+
+func (d *testDaemon) Name() string       { return "test-daemon" }
+func (d *testDaemon) Path() string       { return "/bin/true" }
+func (d *testDaemon) Args() []string     { return nil }
+func (d *testDaemon) SocketFile() string { return "" }
+func (d *testDaemon) PidFile() string    { return d.pidFile }
+func (d *testDaemon) ID() string         { return "test-daemon-1" }
+
+// setRunning writes the pid of the test process to the pidfile of the
+// daemon, or removes the pidfile
+func (d *testDaemon) setRunning(t *testing.T, running bool) {
+	if running {
+		assert.Nil(t, WritePidToFile(os.Getpid(), d.pidFile))
+		return
+	}
+	os.Remove(d.pidFile)
+}
+
+// setupSupervisorTest returns a supervisor and a daemon which has exited.
+// The daemon is restarted with restartFunc.
+func setupSupervisorTest(t *testing.T, maxRestarts int, restartFunc RestartFunc) (*supervisor, *testDaemon, func()) {
+	dir, err := ioutil.TempDir("", "supervisor")
+	assert.Nil(t, err)
+
+	d := &testDaemon{pidFile: path.Join(dir, "test-daemon.pid")}
+	s := &supervisor{
+		interval:    time.Minute,
+		maxRestarts: maxRestarts,
+		window:      10 * time.Minute,
+		daemons:     make(map[string]*supervisedDaemon),
+	}
+
+	RegisterRestartFunc(d.Name(), restartFunc)
+	getDaemonFunc = func(id string) (Daemon, error) { return d, nil }
+
+	return s, d, func() {
+		getDaemonFunc = getDaemon
+		restartFuncsMu.Lock()
+		delete(restartFuncs, d.Name())
+		restartFuncsMu.Unlock()
+		os.RemoveAll(dir)
+	}
+}
+
+// TestSupervisorBackoff validates that exited daemons are restarted with an
+// exponential backoff
+func TestSupervisorBackoff(t *testing.T) {
+	restarts := 0
+	s, d, cleanup := setupSupervisorTest(t, 5, func(Daemon, log.FieldLogger) error {
+		restarts++
+		return errors.New("failed to start")
+	})
+	defer cleanup()
+
+	now := time.Now()
+	next := s.checkDaemon(d, now)
+	assert.Equal(t, 0, restarts)
+	assert.True(t, s.daemons[d.ID()].down)
+	assert.True(t, next.Sub(now) >= time.Second && next.Sub(now) <= 1100*time.Millisecond)
+
+	// Not due for a restart yet
+	assert.Equal(t, next, s.checkDaemon(d, now.Add(500*time.Millisecond)))
+	assert.Equal(t, 0, restarts)
+
+	for _, delay := range []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second} {
+		now = next
+		next = s.checkDaemon(d, now)
+		assert.True(t, next.Sub(now) >= delay && next.Sub(now) <= delay+delay/10, delay.String())
+	}
+	assert.Equal(t, 3, restarts)
+}
+
+// TestSupervisorGiveUp validates that the supervisor stops restarting a
+// daemon which keeps exiting
+func TestSupervisorGiveUp(t *testing.T) {
+	restarts := 0
+	s, d, cleanup := setupSupervisorTest(t, 2, func(Daemon, log.FieldLogger) error {
+		restarts++
+		return errors.New("failed to start")
+	})
+	defer cleanup()
+
+	now := time.Now()
+	next := s.checkDaemon(d, now)
+	for !next.IsZero() {
+		now = next
+		next = s.checkDaemon(d, now)
+	}
+	assert.Equal(t, 2, restarts)
+	assert.True(t, s.daemons[d.ID()].gaveUp)
+
+	// No more restarts are attempted
+	assert.True(t, s.checkDaemon(d, now.Add(time.Hour)).IsZero())
+	assert.Equal(t, 2, restarts)
+
+	// A daemon given up on which was started again is supervised afresh
+	d.setRunning(t, true)
+	assert.True(t, s.checkDaemon(d, now.Add(time.Hour)).IsZero())
+	_, supervised := s.daemons[d.ID()]
+	assert.False(t, supervised)
+}
+
+// TestSupervisorWindowReset validates that the restart count and backoff of
+// a daemon are reset once it keeps running for the restart window
+func TestSupervisorWindowReset(t *testing.T) {
+	restarts := 0
+	s, d, cleanup := setupSupervisorTest(t, 2, func(rd Daemon, _ log.FieldLogger) error {
+		restarts++
+		return WritePidToFile(os.Getpid(), rd.PidFile())
+	})
+	defer cleanup()
+
+	now := s.checkDaemon(d, time.Now())
+	assert.True(t, s.checkDaemon(d, now).IsZero())
+	assert.Equal(t, 1, restarts)
+	assert.False(t, s.daemons[d.ID()].down)
+
+	// Exiting again within the restart window keeps the restart count
+	// and the backoff
+	now = now.Add(s.window / 2)
+	d.setRunning(t, false)
+	next := s.checkDaemon(d, now)
+	assert.True(t, next.Sub(now) >= 2*time.Second && next.Sub(now) <= 2200*time.Millisecond)
+	assert.Equal(t, 1, s.daemons[d.ID()].restarts)
+
+	now = next
+	assert.True(t, s.checkDaemon(d, now).IsZero())
+	assert.Equal(t, 2, restarts)
+
+	// Still within the restart window
+	assert.True(t, s.checkDaemon(d, now.Add(s.window/2)).IsZero())
+	_, supervised := s.daemons[d.ID()]
+	assert.True(t, supervised)
+
+	// Kept running for the restart window
+	now = now.Add(s.window)
+	assert.True(t, s.checkDaemon(d, now).IsZero())
+	_, supervised = s.daemons[d.ID()]
+	assert.False(t, supervised)
+
+	// Restarted afresh when it exits again, even though it was restarted
+	// as many times as allowed
+	d.setRunning(t, false)
+	next = s.checkDaemon(d, now)
+	assert.True(t, next.Sub(now) >= time.Second && next.Sub(now) <= 1100*time.Millisecond)
+	assert.Equal(t, 0, s.daemons[d.ID()].restarts)
+	assert.True(t, s.checkDaemon(d, next).IsZero())
+	assert.Equal(t, 3, restarts)
+}
@@ -12,6 +12,7 @@ type ctxKeyType int
 const (
 	reqIDKey ctxKeyType = iota
 	reqLoggerKey
+	userKey
 )
 
 // WithReqID returns a new context with provided request id set as a value in the context.
@@ -41,3 +42,17 @@ func GetReqLogger(ctx context.Context) log.FieldLogger {
 	}
 	return reqLogger
 }
+
+// WithUser returns a new context with the name of the authenticated user set as a value in the context.
+func WithUser(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, userKey, username)
+}
+
+// GetUser returns the name of the authenticated user stored in the context provided.
+func GetUser(ctx context.Context) string {
+	username, ok := ctx.Value(userKey).(string)
+	if !ok {
+		return ""
+	}
+	return username
+}
@@ -30,3 +30,11 @@ func TestWithReqLogger(t *testing.T) {
 	assert.NotNil(t, newlog)
 
 }
+
+func TestWithUser(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, GetUser(ctx))
+
+	ctx = WithUser(ctx, "monitoring")
+	assert.Equal(t, "monitoring", GetUser(ctx))
+}
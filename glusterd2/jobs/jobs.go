@@ -0,0 +1,436 @@
+// Package jobs implements asynchronous processing of long running REST
+// requests. The state and the progress of the jobs are tracked in the store.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/store"
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/pborman/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	jobsPrefix = "jobs/"
+
+	// finished jobs are removed from the store after a day
+	finishedJobTTL = 24 * 60 * 60
+
+	// PreferAsync is the preference requesting asynchronous processing,
+	// sent in the Prefer header
+	PreferAsync = "respond-async"
+)
+
+var (
+	// ErrJobNotFound is returned when a job is not found in the store
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobNotRunning is returned when cancelling a job which has finished
+	ErrJobNotRunning = errors.New("job is not running")
+	// ErrJobNotLocal is returned when cancelling a job run by another peer
+	ErrJobNotLocal = errors.New("job is running on another peer")
+	// ErrJobCancelled is returned by the transactions of a cancelled job
+	ErrJobCancelled = errors.New("job was cancelled")
+)
+
+// job is a job running on this node
+type job struct {
+	sync.Mutex
+	api.Job
+	cancel context.CancelFunc
+	done   chan struct{}
+	// aborted is set once a transaction of the job has been aborted
+	// because the job was cancelled
+	aborted bool
+}
+
+var (
+	running     = make(map[string]*job)
+	runningLock sync.Mutex
+)
+
+type ctxKeyType int
+
+const jobKey ctxKeyType = iota
+
+// jobContext is the context a job runs with. It has the values of the
+// request context, but it is not cancelled when the request completes.
+type jobContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c *jobContext) Value(key interface{}) interface{} {
+	if key == jobKey {
+		return c.Context.Value(key)
+	}
+	return c.values.Value(key)
+}
+
+// Prefers returns true if the request prefers to be processed asynchronously
+func Prefers(r *http.Request) bool {
+	for _, header := range r.Header["Prefer"] {
+		for _, pref := range strings.Split(header, ",") {
+			if strings.EqualFold(strings.TrimSpace(pref), PreferAsync) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fromContext(ctx context.Context) *job {
+	if ctx == nil {
+		return nil
+	}
+	j, _ := ctx.Value(jobKey).(*job)
+	return j
+}
+
+// save saves the job to the store. j must be locked.
+func (j *job) save() error {
+	j.UpdatedAt = time.Now().UTC()
+	b, err := json.Marshal(j.Job)
+	if err != nil {
+		return err
+	}
+
+	var opts []clientv3.OpOption
+	if j.State != api.JobRunning {
+		lease, err := store.Store.Grant(context.TODO(), finishedJobTTL)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, clientv3.WithLease(lease.ID))
+	}
+
+	_, err = store.Put(context.TODO(), jobsPrefix+j.ID.String(), string(b), opts...)
+	return err
+}
+
+// Start creates a job for the request, and runs fn in the background with a
+// context derived from the request context. fn returns the status code and
+// the body of the response to the request.
+func Start(r *http.Request, route string, fn func(ctx context.Context) (int, []byte)) (*api.Job, error) {
+	ctx := r.Context()
+	now := time.Now().UTC()
+
+	j := &job{
+		Job: api.Job{
+			ID:        uuid.NewRandom(),
+			Route:     route,
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			User:      gdctx.GetUser(ctx),
+			PeerID:    gdctx.MyUUID,
+			RequestID: gdctx.GetReqID(ctx).String(),
+			State:     api.JobRunning,
+			CreatedAt: now,
+		},
+		done: make(chan struct{}),
+	}
+
+	cctx, cancel := context.WithCancel(context.WithValue(context.Background(), jobKey, j))
+	j.cancel = cancel
+	jctx := &jobContext{Context: cctx, values: ctx}
+
+	j.Lock()
+	err := j.save()
+	created := j.Job
+	j.Unlock()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	runningLock.Lock()
+	running[j.ID.String()] = j
+	runningLock.Unlock()
+
+	go func() {
+		defer cancel()
+		j.finish(j.run(jctx, fn))
+	}()
+
+	return &created, nil
+}
+
+// run runs the handler of the job. A panic in the handler fails the job
+// instead of crashing glusterd2.
+func (j *job) run(ctx context.Context, fn func(ctx context.Context) (int, []byte)) (status int, body []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.WithFields(log.Fields{
+				"job":   j.ID.String(),
+				"panic": r,
+			}).Errorf("job handler panicked\n%s", debug.Stack())
+			status, body = http.StatusInternalServerError, nil
+		}
+	}()
+	return fn(ctx)
+}
+
+// finish records the result of the job in the store. The job is cancelled
+// only if it failed because a transaction was aborted on cancellation, a
+// job which completed before it was cancelled keeps its result.
+func (j *job) finish(status int, body []byte) {
+	runningLock.Lock()
+	delete(running, j.ID.String())
+	runningLock.Unlock()
+
+	j.Lock()
+	defer j.Unlock()
+
+	j.Status = status
+	j.Response = jobResponse(body)
+	j.State = jobState(status, j.aborted)
+
+	if err := j.save(); err != nil {
+		log.WithError(err).WithField("job", j.ID.String()).Error("failed to save finished job")
+	}
+	close(j.done)
+
+	log.WithFields(log.Fields{
+		"job":    j.ID.String(),
+		"route":  j.Route,
+		"state":  j.State,
+		"status": j.Status,
+	}).Info("job finished")
+}
+
+// secretFields are the fields of responses which are never saved in the
+// store. The routes whose responses carry secrets are not processed as jobs,
+// the secrets are removed in case such a route is processed as a job anyway.
+var secretFields = map[string]bool{
+	"password": true,
+	"secret":   true,
+}
+
+// jobResponse returns the response of a job to be saved in the store, with
+// the values of the secret fields removed. Responses which are not valid
+// JSON are not saved.
+func jobResponse(body []byte) json.RawMessage {
+	var resp interface{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil
+	}
+	if !redactSecrets(resp) {
+		return json.RawMessage(body)
+	}
+
+	redacted, err := json.Marshal(resp)
+	if err != nil {
+		return nil
+	}
+	return json.RawMessage(redacted)
+}
+
+// redactSecrets removes the values of the secret fields of the decoded JSON
+// value, and returns true if any was found
+func redactSecrets(v interface{}) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if secretFields[strings.ToLower(key)] {
+				delete(v, key)
+				found = true
+				continue
+			}
+			if redactSecrets(value) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if redactSecrets(value) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// jobState returns the state of a finished job from the status code of its
+// response, and whether a transaction of the job was aborted because the job
+// was cancelled
+func jobState(status int, aborted bool) api.JobState {
+	switch {
+	case status >= http.StatusOK && status < http.StatusMultipleChoices:
+		return api.JobSucceeded
+	case aborted:
+		return api.JobCancelled
+	default:
+		return api.JobFailed
+	}
+}
+
+// Get returns the job with the given ID
+func Get(id string) (*api.Job, error) {
+	resp, err := store.Get(context.TODO(), jobsPrefix+id)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Count != 1 {
+		return nil, ErrJobNotFound
+	}
+
+	var j api.Job
+	if err := json.Unmarshal(resp.Kvs[0].Value, &j); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// List returns all the jobs in the store
+func List() ([]api.Job, error) {
+	resp, err := store.Get(context.TODO(), jobsPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]api.Job, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var j api.Job
+		if err := json.Unmarshal(kv.Value, &j); err != nil {
+			log.WithError(err).WithField("key", string(kv.Key)).Error("failed to unmarshal job")
+			continue
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+// Cancel cancels the job with the given ID, and waits for it to finish. Only
+// jobs running on this node can be cancelled.
+func Cancel(id string) (*api.Job, error) {
+	runningLock.Lock()
+	j, ok := running[id]
+	runningLock.Unlock()
+
+	if !ok {
+		stored, err := Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if stored.State != api.JobRunning {
+			return nil, ErrJobNotRunning
+		}
+		if !uuid.Equal(stored.PeerID, gdctx.MyUUID) {
+			return nil, ErrJobNotLocal
+		}
+		// The job finished just now
+		return nil, ErrJobNotRunning
+	}
+
+	j.cancel()
+	<-j.done
+
+	return Get(id)
+}
+
+// FailInterrupted marks the jobs of this node which were running when
+// glusterd2 stopped as failed
+func FailInterrupted() error {
+	jobs, err := List()
+	if err != nil {
+		return err
+	}
+
+	for _, stored := range jobs {
+		if stored.State != api.JobRunning || !uuid.Equal(stored.PeerID, gdctx.MyUUID) {
+			continue
+		}
+		j := &job{Job: stored}
+		j.State = api.JobFailed
+		if err := j.save(); err != nil {
+			return err
+		}
+		log.WithField("job", j.ID.String()).Warn("marked job interrupted by restart as failed")
+	}
+	return nil
+}
+
+// Cancelled returns ErrJobCancelled if ctx is the context of a job which
+// has been cancelled. Transactions call it to abort the job, so the job is
+// marked as cancelled once it finishes.
+func Cancelled(ctx context.Context) error {
+	j := fromContext(ctx)
+	if j == nil {
+		return nil
+	}
+	if ctx.Err() != context.Canceled {
+		return nil
+	}
+
+	j.Lock()
+	j.aborted = true
+	j.Unlock()
+	return ErrJobCancelled
+}
+
+// CancelNotify returns a channel which is closed when the job running with
+// ctx is cancelled. It returns nil when ctx is not the context of a job.
+func CancelNotify(ctx context.Context) <-chan struct{} {
+	if fromContext(ctx) == nil {
+		return nil
+	}
+	return ctx.Done()
+}
+
+// StepStarted records that the transaction step with the given name started
+// for the job running with ctx
+func StepStarted(ctx context.Context, name string) {
+	j := fromContext(ctx)
+	if j == nil {
+		return
+	}
+
+	j.Lock()
+	defer j.Unlock()
+
+	j.Steps = append(j.Steps, api.JobStep{Name: name, State: api.JobRunning})
+	if err := j.save(); err != nil {
+		log.WithError(err).WithField("job", j.ID.String()).Error("failed to save job progress")
+	}
+}
+
+// StepDone records the result of the last started transaction step with the
+// given name for the job running with ctx
+func StepDone(ctx context.Context, name string, err error) {
+	j := fromContext(ctx)
+	if j == nil {
+		return
+	}
+
+	j.Lock()
+	defer j.Unlock()
+
+	for i := len(j.Steps) - 1; i >= 0; i-- {
+		step := &j.Steps[i]
+		if step.Name != name || step.State != api.JobRunning {
+			continue
+		}
+		if err != nil {
+			step.State = api.JobFailed
+			step.Error = err.Error()
+		} else {
+			step.State = api.JobSucceeded
+		}
+		break
+	}
+
+	if err := j.save(); err != nil {
+		log.WithError(err).WithField("job", j.ID.String()).Error("failed to save job progress")
+	}
+}
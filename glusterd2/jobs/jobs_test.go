@@ -0,0 +1,130 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefers(t *testing.T) {
+	tests := []struct {
+		headers []string
+		want    bool
+	}{
+		{nil, false},
+		{[]string{"return=minimal"}, false},
+		{[]string{"respond-async"}, true},
+		{[]string{"Respond-Async"}, true},
+		{[]string{"return=minimal, respond-async"}, true},
+		{[]string{"return=minimal", "respond-async"}, true},
+		{[]string{"respond-asynchronously"}, false},
+	}
+
+	for _, tt := range tests {
+		r, err := http.NewRequest("POST", "/v1/volumes", nil)
+		assert.Nil(t, err)
+		for _, h := range tt.headers {
+			r.Header.Add("Prefer", h)
+		}
+		assert.Equal(t, tt.want, Prefers(r), "Prefer: %v", tt.headers)
+	}
+}
+
+func TestNotJobContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// A cancelled request which is not a job is left to the callers
+	assert.Nil(t, Cancelled(ctx))
+	assert.Nil(t, CancelNotify(ctx))
+	assert.Nil(t, CancelNotify(nil))
+
+	StepStarted(ctx, "vol-create.Commit")
+	StepDone(ctx, "vol-create.Commit", nil)
+}
+
+func TestJobContext(t *testing.T) {
+	type key string
+	values := context.WithValue(context.Background(), key("user"), "alice")
+	reqCtx, reqCancel := context.WithCancel(values)
+
+	j := &job{}
+	cctx, cancel := context.WithCancel(context.WithValue(context.Background(), jobKey, j))
+	jctx := &jobContext{Context: cctx, values: reqCtx}
+
+	// The job outlives the request, but keeps its values
+	reqCancel()
+	assert.Nil(t, jctx.Err())
+	assert.Equal(t, "alice", jctx.Value(key("user")))
+	assert.Equal(t, j, fromContext(jctx))
+	assert.Nil(t, Cancelled(jctx))
+
+	assert.False(t, j.aborted)
+
+	cancel()
+	assert.Equal(t, ErrJobCancelled, Cancelled(jctx))
+	assert.True(t, j.aborted)
+	select {
+	case <-CancelNotify(jctx):
+	default:
+		t.Error("cancel of job not notified")
+	}
+}
+
+func TestJobState(t *testing.T) {
+	tests := []struct {
+		status  int
+		aborted bool
+		want    api.JobState
+	}{
+		{http.StatusOK, false, api.JobSucceeded},
+		{http.StatusCreated, false, api.JobSucceeded},
+		{http.StatusNoContent, false, api.JobSucceeded},
+		{http.StatusConflict, false, api.JobFailed},
+		{http.StatusInternalServerError, false, api.JobFailed},
+		{http.StatusInternalServerError, true, api.JobCancelled},
+		// A job which completed before it was cancelled keeps its
+		// result
+		{http.StatusOK, true, api.JobSucceeded},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, jobState(tt.status, tt.aborted), "status %d, aborted %v", tt.status, tt.aborted)
+	}
+}
+
+func TestJobRunPanic(t *testing.T) {
+	j := &job{}
+
+	status, body := j.run(context.Background(), func(ctx context.Context) (int, []byte) {
+		return http.StatusCreated, []byte("{}")
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, []byte("{}"), body)
+
+	status, body = j.run(context.Background(), func(ctx context.Context) (int, []byte) {
+		panic("handler bug")
+	})
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Nil(t, body)
+}
+
+func TestJobResponse(t *testing.T) {
+	// A block volume create response carries the CHAP password
+	resp := jobResponse([]byte(`{"name":"blk","hosts":["h1"],"username":"u","password":"chap-pass","info":[{"Secret":"s"}]}`))
+	assert.NotNil(t, resp)
+	assert.False(t, strings.Contains(string(resp), "chap-pass"))
+	assert.False(t, strings.Contains(strings.ToLower(string(resp)), "secret"))
+	assert.True(t, strings.Contains(string(resp), `"username":"u"`))
+
+	// Responses without secrets are saved as they are
+	assert.Equal(t, json.RawMessage(`{"name": "vol"}`), jobResponse([]byte(`{"name": "vol"}`)))
+	assert.Nil(t, jobResponse([]byte("not json")))
+	assert.Nil(t, jobResponse(nil))
+}
@@ -7,13 +7,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gluster/glusterd2/glusterd2/audit"
+	"github.com/gluster/glusterd2/glusterd2/backup"
+	"github.com/gluster/glusterd2/glusterd2/brick"
 	"github.com/gluster/glusterd2/glusterd2/brickmux"
+	"github.com/gluster/glusterd2/glusterd2/commands/snapshot"
 	"github.com/gluster/glusterd2/glusterd2/commands/volumes"
 	"github.com/gluster/glusterd2/glusterd2/conf"
 	"github.com/gluster/glusterd2/glusterd2/daemon"
 	"github.com/gluster/glusterd2/glusterd2/events"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/jobs"
 	"github.com/gluster/glusterd2/glusterd2/peer"
+	"github.com/gluster/glusterd2/glusterd2/peertls"
 	"github.com/gluster/glusterd2/glusterd2/pmap"
 	"github.com/gluster/glusterd2/glusterd2/servers"
 	"github.com/gluster/glusterd2/glusterd2/store"
@@ -82,13 +88,32 @@ func main() {
 		log.WithError(err).Fatal("Failed to load xlator options")
 	}
 
+	// Reseed the store and the volfiles from a backup archive if requested
+	restoreArchive := backup.RestoreArchive()
+	if restoreArchive != "" {
+		if err := backup.Restore(restoreArchive); err != nil {
+			log.WithError(err).Fatal("Failed to restore from backup archive")
+		}
+	}
+
+	// Load the certificates of peer TLS before the store and the peer RPC
+	// server use them
+	if err := peertls.Init(); err != nil {
+		log.WithError(err).Fatal("Failed to load peer TLS certificates")
+	}
+
 	// Initialize etcd store (etcd client connection)
 	if err := store.Init(nil); err != nil {
 		log.WithError(err).Fatal("Failed to initialize store (etcd client)")
 	}
 
+	if err := jobs.FailInterrupted(); err != nil {
+		log.WithError(err).Warn("Failed to mark interrupted jobs as failed")
+	}
+
 	transaction.StartTxnEngine()
 	cleanuphandler.StartCleanupLeader()
+	snapshotcommands.StartScheduler()
 	// Start the events framework after store is up
 	if err := events.Start(); err != nil {
 		log.WithError(err).Fatal("Failed to start internal events framework")
@@ -108,6 +133,11 @@ func main() {
 		log.WithError(err).Fatal("Failed to generate local auth token")
 	}
 
+	// Open the audit log of requests modifying the cluster
+	if err := audit.Init(); err != nil {
+		log.WithError(err).Fatal("Failed to open audit log")
+	}
+
 	// Create the Opencensus Jaeger exporter
 	if exporter := tracing.InitJaegerExporter(); exporter != nil {
 		defer tracing.Flush()
@@ -118,6 +148,18 @@ func main() {
 		log.WithError(err).Fatal("failed to load volgen templates")
 	}
 
+	// Load the volfile templates managed through the REST API
+	if err := volgen.LoadStoredTemplates(); err != nil {
+		log.WithError(err).Fatal("failed to load volgen templates from the store")
+	}
+
+	// Regenerate the volfiles of local bricks after restoring the store
+	if restoreArchive != "" {
+		if err := backup.ReconcileVolfiles(); err != nil {
+			log.WithError(err).Fatal("Failed to reconcile volfiles with the restored store")
+		}
+	}
+
 	// Start all servers (rest, peerrpc, sunrpc) managed by suture supervisor
 	super := initGD2Supervisor()
 	super.ServeBackground()
@@ -141,6 +183,10 @@ func main() {
 		log.WithError(err).Fatal("bmux.Reconcile() failed")
 	}
 
+	// Restart daemons which exit without being stopped
+	daemon.RegisterRestartFunc(brick.DaemonName, brickmux.Restart)
+	daemon.StartSupervisor()
+
 	// Use the main goroutine as signal handling loop
 	sigCh := make(chan os.Signal)
 	signal.Notify(sigCh)
@@ -154,9 +200,12 @@ func main() {
 			gdctx.IsTerminating = true
 			transaction.StopTxnEngine()
 			cleanuphandler.StopCleanupLeader()
+			snapshotcommands.StopScheduler()
+			daemon.StopSupervisor()
 			super.Stop()
 			events.Stop()
 			store.Close()
+			audit.Close()
 			_ = os.Remove(config.GetString("pidfile"))
 			log.Info("Stopped GlusterD")
 			return
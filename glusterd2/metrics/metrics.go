@@ -0,0 +1,107 @@
+// Package metrics defines the Prometheus metrics exported by glusterd2 on
+// the /metrics endpoint
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "glusterd2"
+
+var (
+	// RESTRequestDuration observes the time taken to serve REST requests
+	RESTRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rest",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to serve REST requests by route name.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "code"})
+
+	// TxnStepDuration observes the time taken by transaction steps run on
+	// this node
+	TxnStepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "transaction",
+		Name:      "step_duration_seconds",
+		Help:      "Time taken by transaction steps run on this node.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"step", "action"})
+
+	// TxnStepFailures counts the transaction steps which failed on this node
+	TxnStepFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "transaction",
+		Name:      "step_failures_total",
+		Help:      "Number of transaction steps which failed on this node.",
+	}, []string{"step", "action"})
+
+	// StoreOpDuration observes the latency of etcd store operations
+	StoreOpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "store",
+		Name:      "operation_duration_seconds",
+		Help:      "Latency of etcd store operations.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation"})
+
+	// DaemonStarts counts the daemons started by this node
+	DaemonStarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "daemon",
+		Name:      "starts_total",
+		Help:      "Number of times daemons were started by this node.",
+	}, []string{"daemon"})
+
+	// DaemonStartFailures counts the daemons which failed to start
+	DaemonStartFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "daemon",
+		Name:      "start_failures_total",
+		Help:      "Number of times daemons failed to start on this node.",
+	}, []string{"daemon"})
+
+	// DaemonCrashes counts the daemons which exited without being stopped
+	DaemonCrashes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "daemon",
+		Name:      "crashes_total",
+		Help:      "Number of times daemons exited on this node without being stopped.",
+	}, []string{"daemon"})
+
+	// DaemonRestarts counts the daemons restarted after they exited
+	DaemonRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "daemon",
+		Name:      "restarts_total",
+		Help:      "Number of times daemons were restarted by this node after they exited.",
+	}, []string{"daemon"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		RESTRequestDuration,
+		TxnStepDuration,
+		TxnStepFailures,
+		StoreOpDuration,
+		DaemonStarts,
+		DaemonStartFailures,
+		DaemonCrashes,
+		DaemonRestarts,
+	)
+}
+
+// Register registers a collector which gathers metrics when /metrics is
+// scraped
+func Register(c prometheus.Collector) {
+	prometheus.MustRegister(c)
+}
+
+// Handler returns the handler serving the registered metrics in the
+// Prometheus text exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
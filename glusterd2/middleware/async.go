@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gluster/glusterd2/glusterd2/jobs"
+	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// syncRoutes are the routes which are never processed as jobs. The responses
+// of jobs are saved in the store, so the routes whose responses carry secrets
+// or data only useful to the client which sent the request are processed
+// synchronously.
+var syncRoutes = map[string]bool{
+	"JobCancel":     true,
+	"UserCreate":    true,
+	"ClusterBackup": true,
+	"BlockCreate":   true,
+}
+
+// asyncRoutes are the routes which are processed as jobs even without the
+// "Prefer: respond-async" header, when the request would run for longer than
+// the write timeout of the server
+var asyncRoutes = map[string]func(r *http.Request) bool{
+	// Evacuating a device waits for the bricks moved off it to be healed
+	"DeviceDelete": func(r *http.Request) bool {
+		evacuate, _ := strconv.ParseBool(r.URL.Query().Get("evacuate"))
+		return evacuate
+	},
+}
+
+// isJobRequest returns true if the request to the named route is processed
+// as a job
+func isJobRequest(r *http.Request, routeName string) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead || syncRoutes[routeName] {
+		return false
+	}
+	if jobs.Prefers(r) {
+		return true
+	}
+	if async, ok := asyncRoutes[routeName]; ok {
+		return async(r)
+	}
+	return false
+}
+
+// jobRecorder records the response of a request processed as a job
+type jobRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *jobRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *jobRecorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
+
+func (rec *jobRecorder) WriteHeader(code int) {
+	rec.status = code
+}
+
+// Async is a middleware which processes the requests sent with the
+// "Prefer: respond-async" header, and the requests to asyncRoutes, as jobs.
+// The job is returned immediately with the 202 status, and the request is
+// processed in the background. It has to be set on the router after Auth so
+// that the matched route and the authenticated user are known.
+func Async(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var routeName string
+		if route := mux.CurrentRoute(r); route != nil {
+			routeName = route.GetName()
+		}
+		if !isJobRequest(r, routeName) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+
+		// The request body is closed once this handler returns
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+
+		job, err := jobs.Start(r, routeName, func(jctx context.Context) (int, []byte) {
+			jr := r.WithContext(jctx)
+			jr.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			rec := &jobRecorder{header: make(http.Header), status: http.StatusOK}
+			next.ServeHTTP(rec, jr)
+			return rec.status, rec.body.Bytes()
+		})
+		if err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Location", "/v1/jobs/"+job.ID.String())
+		if jobs.Prefers(r) {
+			w.Header().Set("Preference-Applied", jobs.PreferAsync)
+		}
+		restutils.SendHTTPResponse(ctx, w, http.StatusAccepted, job)
+	})
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncSynchronousRequests(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Async)
+	router.Methods("GET").Path("/volumes").Name("VolumeList").Handler(GetTestHandler())
+	router.Methods("POST").Path("/volumes/{volname}/start").Name("VolumeStart").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		})
+	router.Methods("DELETE").Path("/jobs/{jobid}").Name("JobCancel").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	router.Methods("POST").Path("/users").Name("UserCreate").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+	router.Methods("POST").Path("/blockvolumes/{provider}").Name("BlockCreate").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	do := func(method, path string, async bool) *http.Response {
+		req, err := http.NewRequest(method, ts.URL+path, nil)
+		assert.Nil(t, err)
+		if async {
+			req.Header.Set("Prefer", "respond-async")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return resp
+	}
+
+	// Requests without the preference are processed synchronously
+	resp := do("POST", "/volumes/vol1/start", false)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// GET requests, job cancellations and requests whose responses carry
+	// secrets are never processed as jobs
+	resp = do("GET", "/volumes", true)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Preference-Applied"))
+
+	resp = do("DELETE", "/jobs/1", true)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = do("POST", "/users", true)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Preference-Applied"))
+
+	resp = do("POST", "/blockvolumes/virtblock", true)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Preference-Applied"))
+}
+
+func TestIsJobRequest(t *testing.T) {
+	req := func(method, target string, async bool) *http.Request {
+		r := httptest.NewRequest(method, target, nil)
+		if async {
+			r.Header.Set("Prefer", "respond-async")
+		}
+		return r
+	}
+
+	assert.True(t, isJobRequest(req("POST", "/v1/volumes/vol1/start", true), "VolumeStart"))
+	assert.False(t, isJobRequest(req("POST", "/v1/volumes/vol1/start", false), "VolumeStart"))
+	assert.False(t, isJobRequest(req("GET", "/v1/volumes", true), "VolumeList"))
+	assert.False(t, isJobRequest(req("POST", "/v1/blockvolumes/virtblock", true), "BlockCreate"))
+
+	// Device evacuation is always processed as a job
+	assert.True(t, isJobRequest(req("DELETE", "/v1/devices/p1/dev/sdb?evacuate=true", false), "DeviceDelete"))
+	assert.True(t, isJobRequest(req("DELETE", "/v1/devices/p1/dev/sdb?evacuate=true", true), "DeviceDelete"))
+	assert.False(t, isJobRequest(req("DELETE", "/v1/devices/p1/dev/sdb", false), "DeviceDelete"))
+	assert.False(t, isJobRequest(req("DELETE", "/v1/devices/p1/dev/sdb?evacuate=false", false), "DeviceDelete"))
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/audit"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/gorilla/mux"
+)
+
+type auditRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *auditRecorder) WriteHeader(code int) {
+	rec.status = code
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+// Audit is a middleware which records every request that can modify the
+// cluster in the audit log. It has to be set on the router after Auth so
+// that the matched route and the authenticated user are known.
+func Audit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		ctx := audit.NewContext(r.Context())
+		rec := &auditRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		entry := &api.AuditEntry{
+			Time:       start.UTC(),
+			User:       gdctx.GetUser(ctx),
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			RequestID:  gdctx.GetReqID(ctx).String(),
+			Status:     rec.status,
+			DurationMs: int64(time.Since(start) / time.Millisecond),
+		}
+		if route := mux.CurrentRoute(r); route != nil {
+			entry.Route = route.GetName()
+		}
+		vars := mux.Vars(r)
+		entry.Volume = vars["volname"]
+		entry.Peer = vars["peerid"]
+		entry.Snapshot = vars["snapname"]
+
+		// The objects named by the request body, like the volume being
+		// created, are set by the handlers
+		volume, peer, snapshot := audit.Objects(ctx)
+		if volume != "" {
+			entry.Volume = volume
+		}
+		if peer != "" {
+			entry.Peer = peer
+		}
+		if snapshot != "" {
+			entry.Snapshot = snapshot
+		}
+
+		audit.Record(entry)
+	})
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/audit"
+
+	"github.com/gorilla/mux"
+	config "github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	config.Set("logdir", dir)
+	config.Set("audit-logfile", "audit.log")
+	assert.Nil(t, audit.Init())
+	defer audit.Close()
+
+	router := mux.NewRouter()
+	router.Use(Audit)
+	router.Methods("GET").Path("/volumes/{volname}").Name("VolumeInfo").Handler(GetTestHandler())
+	router.Methods("POST").Path("/volumes/{volname}/start").Name("VolumeStart").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		})
+	router.Methods("POST").Path("/volumes").Name("VolumeCreate").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			audit.SetVolume(r.Context(), "vol2")
+			w.WriteHeader(http.StatusCreated)
+		})
+	router.Methods("DELETE").Path("/snapshots/{snapname}").Name("SnapshotDelete").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/volumes/vol1")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Post(ts.URL+"/volumes/vol1/start", "application/json", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Only the mutating request is recorded
+	entries, err := audit.Query(audit.Filter{})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "VolumeStart", entries[0].Route)
+	assert.Equal(t, "vol1", entries[0].Volume)
+	assert.Equal(t, http.StatusConflict, entries[0].Status)
+
+	// The volume being created is set by the handler
+	resp, err = http.Post(ts.URL+"/volumes", "application/json", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	req, err := http.NewRequest("DELETE", ts.URL+"/snapshots/snap1", nil)
+	assert.Nil(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	entries, err = audit.Query(audit.Filter{})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "VolumeCreate", entries[1].Route)
+	assert.Equal(t, "vol2", entries[1].Volume)
+	assert.Equal(t, "SnapshotDelete", entries[2].Route)
+	assert.Equal(t, "snap1", entries[2].Snapshot)
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
@@ -8,43 +9,79 @@ import (
 
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
 	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/user"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
 	"github.com/gluster/glusterd2/pkg/utils"
 
 	"github.com/dgrijalva/jwt-go"
-)
-
-const (
-	internalUser = "glustercli"
+	"github.com/gorilla/mux"
+	config "github.com/spf13/viper"
 )
 
 var (
 	requiredClaims = []string{"iss", "exp", "qsh"}
 )
 
-func getAuthSecret(issuer string) string {
-	if issuer == internalUser {
-		return gdctx.LocalAuthToken
+// getAuthUser returns the user who issued a token. The internal user is a
+// cluster admin, others are looked up in the store.
+func getAuthUser(issuer string) *user.User {
+	if issuer == user.InternalUser {
+		return &user.User{
+			Name:   user.InternalUser,
+			Secret: gdctx.LocalAuthToken,
+			Role:   user.ClusterAdmin,
+		}
 	}
 
-	// TODO: Look for issuer secret in etcd if not internal user, this depends on User management feature
+	u, err := user.GetUserF(issuer)
+	if err != nil {
+		return nil
+	}
+	return u
+}
 
+// getCertUser returns the user named by the common name of the subject of a
+// verified client certificate. The internal user can not authenticate with
+// a certificate.
+func getCertUser(cert *x509.Certificate) *user.User {
+	name := cert.Subject.CommonName
+	if name == "" || name == user.InternalUser {
+		return nil
+	}
+
+	u, err := user.GetUserF(name)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+func getAuthSecret(issuer string) string {
+	if u := getAuthUser(issuer); u != nil {
+		return u.Secret
+	}
 	return ""
 }
 
-//isRestAuthRequired return false for few URL which doesn't require authentication
+//isRestAuthRequired return false for few URL which doesn't require authentication.
+//The metrics can only be scraped without authentication if metrics-noauth is set.
 func isRestAuthRequired(url string) bool {
 	switch url {
 	case "/ping":
 		fallthrough
 	case "/endpoints":
 		return false
+	case "/metrics":
+		return !config.GetBool("metrics-noauth")
 	default:
 		return true
 	}
 
 }
 
-// Auth is a middleware which authenticates HTTP requests
+// Auth is a middleware which authenticates HTTP requests and checks that the
+// role of the user permits calling the requested route. It has to be set on
+// the router so that the matched route is known.
 func Auth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// If Auth disabled Return as is
@@ -53,8 +90,21 @@ func Auth(next http.Handler) http.Handler {
 			return
 		}
 		ctx := r.Context()
-		// Verify if Authorization header exists or not
 		authHeader := strings.TrimSpace(r.Header.Get("Authorization"))
+
+		// Clients which presented a verified certificate are authenticated
+		// by it, unless they send a token
+		if authHeader == "" && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			authUser := getCertUser(r.TLS.VerifiedChains[0][0])
+			if authUser == nil {
+				restutils.SendHTTPError(ctx, w, http.StatusUnauthorized, errors.New("no user found for the client certificate"))
+				return
+			}
+			authorize(w, r, authUser, next)
+			return
+		}
+
+		// Verify if Authorization header exists or not
 		if authHeader == "" {
 			restutils.SendHTTPError(ctx, w, http.StatusUnauthorized, errors.New("'Authorization' header is required"))
 			return
@@ -68,6 +118,7 @@ func Auth(next http.Handler) http.Handler {
 		}
 
 		// Verify JWT token with additional validations for Claims
+		var authUser *user.User
 		token, err := jwt.Parse(authHeaderParts[1], func(token *jwt.Token) (interface{}, error) {
 			claims, ok := token.Claims.(jwt.MapClaims)
 			if !ok {
@@ -86,8 +137,9 @@ func Auth(next http.Handler) http.Handler {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
 
-			secret := getAuthSecret(claims["iss"].(string))
-			if secret == "" {
+			issuer, _ := claims["iss"].(string)
+			authUser = getAuthUser(issuer)
+			if authUser == nil || authUser.Secret == "" {
 				return nil, fmt.Errorf("invalid App ID: %s", claims["iss"])
 			}
 			// Check qsh claim
@@ -95,7 +147,7 @@ func Auth(next http.Handler) http.Handler {
 				return nil, errors.New("invalid qsh claim in token")
 			}
 			// All checks GOOD, return the Secret to validate
-			return []byte(secret), nil
+			return []byte(authUser.Secret), nil
 		})
 
 		// Check if token is Valid
@@ -108,9 +160,22 @@ func Auth(next http.Handler) http.Handler {
 			return
 		}
 
-		// TODO: Filter URLs here if any role based control of APIs, this depends on User management feature
-
-		// Authentication is successful, continue serving the request
-		next.ServeHTTP(w, r)
+		authorize(w, r, authUser, next)
 	})
 }
+
+// authorize checks that the role of an authenticated user permits calling
+// the requested route, and serves the request as the user
+func authorize(w http.ResponseWriter, r *http.Request, authUser *user.User, next http.Handler) {
+	var routeName string
+	if route := mux.CurrentRoute(r); route != nil {
+		routeName = route.GetName()
+	}
+	if !authUser.Role.Allows(routeName, r.Method) {
+		restutils.SendHTTPError(r.Context(), w, http.StatusForbidden, gderrors.ErrPermissionDenied)
+		return
+	}
+
+	// Authentication is successful, continue serving the request
+	next.ServeHTTP(w, r.WithContext(gdctx.WithUser(r.Context(), authUser.Name)))
+}
@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/hex"
 	"net/http"
 	"net/http/httptest"
@@ -13,14 +16,32 @@ import (
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/user"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+	"github.com/gorilla/mux"
 	config "github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
+// getTestUser returns a user which is not in the store, unless it is the
+// read-only user "monitoring"
+func getTestUser(name string) (*user.User, error) {
+	if name == "monitoring" {
+		return &user.User{Name: name, Secret: "monitoring-secret", Role: user.ReadOnly}, nil
+	}
+	return nil, gderrors.ErrUserNotFound
+}
+
 func TestGetAuthSecret(t *testing.T) {
+	user.GetUserF = getTestUser
+	defer func() { user.GetUserF = user.GetUser }()
+
 	secret := getAuthSecret("test")
 	assert.Empty(t, secret)
 
+	secret = getAuthSecret("monitoring")
+	assert.Equal(t, "monitoring-secret", secret)
+
 	config.Set("restauth", true)
 	config.Set("localstatedir", "")
 	err := gdctx.GenerateLocalAuthToken()
@@ -33,7 +54,7 @@ func TestGetAuthSecret(t *testing.T) {
 
 func getAuthToken(username string, password string, r *http.Request) {
 	// Generate qsh
-	qshstring := "GET&/"
+	qshstring := r.Method + "&/"
 	hash := sha256.New()
 	hash.Write([]byte(qshstring))
 	// Create Token
@@ -59,6 +80,8 @@ func generateLocalauthtoken() {
 }
 
 func TestAuth(t *testing.T) {
+	user.GetUserF = getTestUser
+	defer func() { user.GetUserF = user.GetUser }()
 
 	ts := httptest.NewServer(Auth(GetTestHandler()))
 	gdctx.RESTAPIAuthEnabled = false
@@ -96,6 +119,93 @@ func TestAuth(t *testing.T) {
 	os.Remove("auth")
 }
 
+func TestAuthMetrics(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Auth)
+	router.Methods("GET").Path("/metrics").Name("Metrics").Handler(GetTestHandler())
+	gdctx.RESTAPIAuthEnabled = true
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	config.Set("metrics-noauth", true)
+	defer config.Set("metrics-noauth", false)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthRole(t *testing.T) {
+	user.GetUserF = getTestUser
+	defer func() { user.GetUserF = user.GetUser }()
+
+	router := mux.NewRouter()
+	router.Use(Auth)
+	router.Methods("GET").Path("/").Name("VolumeList").Handler(GetTestHandler())
+	router.Methods("POST").Path("/").Name("VolumeCreate").Handler(GetTestHandler())
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+	gdctx.RESTAPIAuthEnabled = true
+
+	client := http.Client{}
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	assert.Nil(t, err)
+	getAuthToken("monitoring", "monitoring-secret", req)
+	resp, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Read-only users can not modify the cluster
+	req, err = http.NewRequest("POST", ts.URL, nil)
+	assert.Nil(t, err)
+	getAuthToken("monitoring", "monitoring-secret", req)
+	resp, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestAuthClientCert(t *testing.T) {
+	user.GetUserF = getTestUser
+	defer func() { user.GetUserF = user.GetUser }()
+
+	router := mux.NewRouter()
+	router.Use(Auth)
+	router.Methods("GET").Path("/").Name("VolumeList").Handler(GetTestHandler())
+	router.Methods("POST").Path("/").Name("VolumeCreate").Handler(GetTestHandler())
+	gdctx.RESTAPIAuthEnabled = true
+
+	certReq := func(method string, name string) *http.Request {
+		req := httptest.NewRequest(method, "/", nil)
+		req.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{
+				{{Subject: pkix.Name{CommonName: name}}},
+			},
+		}
+		return req
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, certReq("GET", "monitoring"))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// The role of the user of the certificate applies
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, certReq("POST", "monitoring"))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, certReq("GET", "testuser"))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// The internal user can not authenticate with a certificate
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, certReq("GET", "glustercli"))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
 func GetTestHandler() http.HandlerFunc {
 	fn := func(rw http.ResponseWriter, req *http.Request) {
 
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+type metricsRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *metricsRecorder) WriteHeader(code int) {
+	rec.status = code
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+// Metrics is a middleware which observes the time taken to serve requests
+// by route name. It has to be set on the router so that the matched route
+// is known.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &metricsRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		var routeName string
+		if route := mux.CurrentRoute(r); route != nil {
+			routeName = route.GetName()
+		}
+		metrics.RESTRequestDuration.WithLabelValues(
+			routeName, r.Method, strconv.Itoa(rec.status),
+		).Observe(time.Since(start).Seconds())
+	})
+}
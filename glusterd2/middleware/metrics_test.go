@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/metrics"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Metrics)
+	router.Methods("GET").Path("/volumes/{volname}").Name("VolumeInfo").Handler(GetTestHandler())
+	router.Methods("POST").Path("/volumes/{volname}/start").Name("VolumeStart").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		})
+	router.Methods("GET").Path("/metrics").Name("Metrics").Handler(metrics.Handler())
+
+	ts := httptest.NewServer(router)
+	defer ts.Close()
+
+	_, err := http.Get(ts.URL + "/volumes/vol1")
+	assert.Nil(t, err)
+
+	_, err = http.Post(ts.URL+"/volumes/vol1/start", "application/json", nil)
+	assert.Nil(t, err)
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+
+	assert.Contains(t, string(body), `glusterd2_rest_request_duration_seconds_count{code="200",method="GET",route="VolumeInfo"} 1`)
+	assert.Contains(t, string(body), `glusterd2_rest_request_duration_seconds_count{code="409",method="POST",route="VolumeStart"} 1`)
+}
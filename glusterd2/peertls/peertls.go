@@ -0,0 +1,204 @@
+// Package peertls sets up TLS with mutual certificate authentication for the
+// traffic between the peers of the cluster, ie. the peer RPC services and the
+// client and peer URLs of the embedded store.
+//
+// Peer TLS is enabled when the peer certificate, its key and the CA which
+// issued the certificates of all the peers are configured. It has to be
+// enabled on all the peers of a cluster, and the certificate of a peer has
+// to be valid for all the addresses of the peer.
+//
+// The certificates can be rotated without restarting glusterd2 as long as
+// they are issued by the same CA. The embedded store loads the peer
+// certificate and its key on every handshake, but loads the CA certificate
+// only once at startup, so a new CA takes effect in the store after a
+// restart only.
+package peertls
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"sync"
+
+	"github.com/gluster/glusterd2/pkg/tlsutils"
+
+	log "github.com/sirupsen/logrus"
+	flag "github.com/spf13/pflag"
+	config "github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	certFileOpt = "peer-cert-file"
+	keyFileOpt  = "peer-key-file"
+	caFileOpt   = "peer-ca-file"
+)
+
+var (
+	// ErrPeerTLSDisabled is returned when reloading the certificates while
+	// peer TLS is not enabled
+	ErrPeerTLSDisabled = errors.New("peer TLS is not enabled")
+)
+
+// certs are the certificates currently in use
+type certs struct {
+	cert    *tls.Certificate
+	caCerts []*x509.Certificate
+	cas     *x509.CertPool
+}
+
+var (
+	mu      sync.RWMutex
+	current *certs
+	// storeCACerts are the CA certificates loaded at startup, which the
+	// embedded store keeps using until glusterd2 is restarted
+	storeCACerts []*x509.Certificate
+)
+
+// InitFlags sets up the peer TLS flags
+func InitFlags() {
+	flag.String(certFileOpt, "", "Certificate used for SSL/TLS connections between peers, and by the embedded store.")
+	flag.String(keyFileOpt, "", "Private key of the peer certificate.")
+	flag.String(caFileOpt, "", "CA certificate used to verify the certificates of peers.")
+}
+
+// Enabled returns true if peer TLS is configured
+func Enabled() bool {
+	return CertFile() != "" && KeyFile() != "" && CAFile() != ""
+}
+
+// CertFile returns the path of the peer certificate
+func CertFile() string {
+	return config.GetString(certFileOpt)
+}
+
+// KeyFile returns the path of the private key of the peer certificate
+func KeyFile() string {
+	return config.GetString(keyFileOpt)
+}
+
+// CAFile returns the path of the CA certificate which issued the peer
+// certificates
+func CAFile() string {
+	return config.GetString(caFileOpt)
+}
+
+// Init loads the peer certificates if peer TLS is enabled
+func Init() error {
+	if !Enabled() {
+		return nil
+	}
+	return Reload()
+}
+
+// Reload reloads the peer certificate, its key and the CA certificate from
+// their files. Connections made after the reload use the new certificates,
+// except for the CA certificate of the embedded store, see
+// StoreRestartRequired. The certificates in use are retained if any of them
+// can not be loaded.
+func Reload() error {
+	if !Enabled() {
+		return ErrPeerTLSDisabled
+	}
+
+	cert, err := tls.LoadX509KeyPair(CertFile(), KeyFile())
+	if err != nil {
+		return err
+	}
+
+	cas, err := tlsutils.LoadCerts(CAFile())
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	current = &certs{
+		cert:    &cert,
+		caCerts: cas,
+		cas:     tlsutils.NewCertPool(cas),
+	}
+	if storeCACerts == nil {
+		storeCACerts = cas
+	}
+	mu.Unlock()
+
+	log.WithFields(log.Fields{
+		"cert-file": CertFile(),
+		"ca-file":   CAFile(),
+	}).Info("loaded peer TLS certificates")
+	return nil
+}
+
+// StoreRestartRequired returns true if the CA certificate has changed since
+// glusterd2 was started. The embedded store uses the new CA certificate only
+// after glusterd2 is restarted.
+func StoreRestartRequired() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return false
+	}
+	if len(current.caCerts) != len(storeCACerts) {
+		return true
+	}
+	for i, cert := range current.caCerts {
+		if !bytes.Equal(cert.Raw, storeCACerts[i].Raw) {
+			return true
+		}
+	}
+	return false
+}
+
+func getCerts() *certs {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// serverConfig returns the TLS config of the peer RPC server. The current
+// certificates are picked on every handshake.
+func serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := getCerts()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*c.cert},
+				ClientCAs:    c.cas,
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
+
+// clientConfig returns the TLS config for connecting to peers, with the
+// current certificates
+func clientConfig() *tls.Config {
+	c := getCerts()
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*c.cert},
+		RootCAs:      c.cas,
+	}
+}
+
+// ServerOptions returns the gRPC server options of the peer RPC server
+func ServerOptions() []grpc.ServerOption {
+	if getCerts() == nil {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(serverConfig()))}
+}
+
+// DialOption returns the gRPC dial option to connect to peers
+func DialOption() grpc.DialOption {
+	if getCerts() == nil {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(clientConfig()))
+}
@@ -0,0 +1,145 @@
+package peertls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	config "github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) writeCert(t *testing.T, file string) {
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	require.Nil(t, ioutil.WriteFile(file, data, 0600))
+}
+
+// issue writes a certificate issued by the CA and its key to the given
+// files, and returns the certificate
+func (ca *testCA) issue(t *testing.T, name string, serial int64, certFile string, keyFile string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+
+	require.Nil(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.Nil(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return der
+}
+
+func resetPeerTLS() {
+	config.Set(certFileOpt, "")
+	config.Set(keyFileOpt, "")
+	config.Set(caFileOpt, "")
+	current = nil
+	storeCACerts = nil
+}
+
+// serverCert returns the certificate used by the peer RPC server
+func serverCert(t *testing.T) []byte {
+	conf, err := serverConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	require.Nil(t, err)
+	return conf.Certificates[0].Certificate[0]
+}
+
+// TestReload validates that Reload() picks up new certificates
+func TestReload(t *testing.T) {
+	resetPeerTLS()
+	defer resetPeerTLS()
+
+	dir, err := ioutil.TempDir("", "peertls")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		certFile = filepath.Join(dir, "peer.crt")
+		keyFile  = filepath.Join(dir, "peer.key")
+		caFile   = filepath.Join(dir, "ca.crt")
+	)
+
+	assert.Equal(t, ErrPeerTLSDisabled, Reload())
+	assert.Nil(t, Init())
+	assert.Nil(t, ServerOptions())
+
+	ca := newTestCA(t, "ca1")
+	ca.writeCert(t, caFile)
+	cert := ca.issue(t, "peer1", 2, certFile, keyFile)
+
+	config.Set(certFileOpt, certFile)
+	config.Set(keyFileOpt, keyFile)
+	config.Set(caFileOpt, caFile)
+
+	require.Nil(t, Init())
+	assert.Equal(t, cert, clientConfig().Certificates[0].Certificate[0])
+	assert.Equal(t, cert, serverCert(t))
+	assert.False(t, StoreRestartRequired())
+
+	// A certificate issued by the same CA
+	cert = ca.issue(t, "peer1", 3, certFile, keyFile)
+	require.Nil(t, Reload())
+	assert.Equal(t, cert, clientConfig().Certificates[0].Certificate[0])
+	assert.Equal(t, cert, serverCert(t))
+	assert.False(t, StoreRestartRequired())
+
+	// A new CA is picked up by the peer RPC, but not by the embedded store
+	ca = newTestCA(t, "ca2")
+	ca.writeCert(t, caFile)
+	cert = ca.issue(t, "peer1", 2, certFile, keyFile)
+	require.Nil(t, Reload())
+	assert.Equal(t, cert, clientConfig().Certificates[0].Certificate[0])
+	assert.Equal(t, ca.cert.Raw, getCerts().caCerts[0].Raw)
+	assert.True(t, StoreRestartRequired())
+
+	// The certificates in use are retained if the new ones can not be loaded
+	require.Nil(t, ioutil.WriteFile(keyFile, []byte("not a key"), 0600))
+	assert.NotNil(t, Reload())
+	assert.Equal(t, cert, clientConfig().Certificates[0].Certificate[0])
+	assert.Equal(t, ca.cert.Raw, getCerts().caCerts[0].Raw)
+}
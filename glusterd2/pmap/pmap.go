@@ -2,6 +2,8 @@ package pmap
 
 import (
 	"net"
+
+	"github.com/gluster/glusterd2/glusterd2/daemon"
 )
 
 // RegistrySearch searches for a brick path in the pmap registry and
@@ -12,6 +14,8 @@ func RegistrySearch(brickpath string) (int, error) {
 
 // ProcessDisconnect will handle a TCP connection disconnection
 func ProcessDisconnect(conn net.Conn) error {
+	// The brick process may have exited
+	daemon.CheckDaemons()
 	return registry.RemovePortByConn(conn)
 }
 
@@ -3,6 +3,7 @@ package pmap
 import (
 	"net"
 
+	"github.com/gluster/glusterd2/glusterd2/daemon"
 	"github.com/gluster/glusterd2/pkg/sunrpc"
 
 	log "github.com/sirupsen/logrus"
@@ -177,5 +178,8 @@ func (p *GfPortmap) SignOut(args *SignOutReq, reply *SignOutRsp) error {
 
 	registry.Remove(args.Port, args.Brick, conn)
 
+	// The brick process signs out when it exits
+	daemon.CheckDaemons()
+
 	return nil
 }
@@ -0,0 +1,42 @@
+package rest
+
+import (
+	"crypto/tls"
+
+	"github.com/gluster/glusterd2/pkg/tlsutils"
+
+	config "github.com/spf13/viper"
+)
+
+// setClientAuth enables the verification of client certificates issued by
+// any of the CAs in client-ca-file. Certificates revoked in client-crl-file
+// are rejected. The auth middleware maps clients which present a verified
+// certificate to the REST API user named by the certificate subject.
+func setClientAuth(tlsConfig *tls.Config) error {
+	caFiles := config.GetStringSlice("client-ca-file")
+	if len(caFiles) == 0 {
+		return nil
+	}
+
+	cas, err := tlsutils.LoadCerts(caFiles...)
+	if err != nil {
+		return err
+	}
+	tlsConfig.ClientCAs = tlsutils.NewCertPool(cas)
+
+	// Clients without a certificate can still authenticate with a token
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if config.GetBool("client-cert-required") {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	if crlFiles := config.GetStringSlice("client-crl-file"); len(crlFiles) > 0 {
+		verifier, err := tlsutils.NewCRLVerifier(cas, crlFiles)
+		if err != nil {
+			return err
+		}
+		tlsConfig.VerifyPeerCertificate = verifier.VerifyPeerCertificate
+	}
+
+	return nil
+}
@@ -49,6 +49,10 @@ func tlsListener(l net.Listener, certfile, keyfile string) (net.Listener, error)
 		Rand:         rand.Reader,
 	}
 
+	if err := setClientAuth(config); err != nil {
+		return nil, err
+	}
+
 	return tls.NewListener(l, config), nil
 }
 
@@ -91,6 +95,12 @@ func NewMuxed(m cmux.CMux) *GDRest {
 		gdutils.EnableProfiling(rest.Routes)
 	}
 
+	// Metrics, Auth, Async and Audit run after routing, as the request
+	// metrics, permissions of a user, jobs and the audit records depend on
+	// the matched route. Audit runs within the job of an asynchronous
+	// request to record its final status.
+	rest.Routes.Use(middleware.Metrics, middleware.Auth, middleware.Async, middleware.Audit)
+
 	// Set chain of ordered middlewares
 	rest.server.Handler = alice.New(
 		middleware.Recover,
@@ -98,7 +108,6 @@ func NewMuxed(m cmux.CMux) *GDRest {
 		middleware.Expvar,
 		middleware.ReqIDGenerator,
 		middleware.LogRequest,
-		middleware.Auth,
 	).Then(rest.Routes)
 
 	return rest
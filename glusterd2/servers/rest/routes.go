@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gluster/glusterd2/glusterd2/commands"
+	"github.com/gluster/glusterd2/glusterd2/metrics"
 	"github.com/gluster/glusterd2/glusterd2/plugin"
 	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
 	"github.com/gluster/glusterd2/pkg/api"
@@ -62,6 +63,11 @@ func (r *GDRest) registerRoutes() {
 		p.RegisterStepFuncs()
 	}
 
+	r.setRoutes(r.moreRoutes())
+}
+
+// moreRoutes returns the routes served by glusterd2 itself
+func (r *GDRest) moreRoutes() route.Routes {
 	// Expose /statedump and /endpoints handlers
 	var moreRoutes route.Routes
 
@@ -80,10 +86,16 @@ func (r *GDRest) registerRoutes() {
 		ResponseType: utils.GetTypeString((*api.ListEndpointsResp)(nil)),
 		HandlerFunc:  r.listEndpointsHandler()})
 
+	moreRoutes = append(moreRoutes, route.Route{
+		Name:        "Metrics",
+		Method:      "GET",
+		Pattern:     "/metrics",
+		HandlerFunc: metrics.Handler().ServeHTTP})
+
 	moreRoutes = append(moreRoutes, route.Route{
 		Name:        "Glusterd2 service status",
 		Method:      "GET",
 		Pattern:     "/ping",
 		HandlerFunc: r.Ping()})
-	r.setRoutes(moreRoutes)
+	return moreRoutes
 }
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/commands"
+	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+	"github.com/gluster/glusterd2/glusterd2/user"
+	"github.com/gluster/glusterd2/plugins/bitrot"
+	"github.com/gluster/glusterd2/plugins/blockvolume"
+	"github.com/gluster/glusterd2/plugins/device"
+	"github.com/gluster/glusterd2/plugins/events"
+	"github.com/gluster/glusterd2/plugins/georeplication"
+	"github.com/gluster/glusterd2/plugins/glustershd"
+	"github.com/gluster/glusterd2/plugins/quota"
+	"github.com/gluster/glusterd2/plugins/rebalance"
+	"github.com/gluster/glusterd2/plugins/tracemgmt"
+
+	config "github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRoutesHaveRoles validates that the roles permitted to call every route
+// are known, so that new routes are not left to cluster admins by mistake.
+// The plugins are listed as in plugin.PluginsList, which is empty unless
+// built with the plugins tag.
+func TestRoutesHaveRoles(t *testing.T) {
+	config.Set("statedump", true)
+	defer config.Set("statedump", false)
+
+	var routes route.Routes
+	for _, c := range commands.Commands {
+		routes = append(routes, c.Routes()...)
+	}
+	plugins := []interface{ RestRoutes() route.Routes }{
+		&georeplication.Plugin{},
+		&bitrot.Plugin{},
+		&quota.Plugin{},
+		&events.Plugin{},
+		&glustershd.Plugin{},
+		&device.Plugin{},
+		&rebalance.Plugin{},
+		&blockvolume.BlockVolume{},
+		&tracemgmt.Plugin{},
+	}
+	for _, p := range plugins {
+		routes = append(routes, p.RestRoutes()...)
+	}
+	routes = append(routes, (&GDRest{}).moreRoutes()...)
+
+	for _, r := range routes {
+		assert.True(t, user.IsClassifiedRoute(r.Name), "route %s is not classified in glusterd2/user/role.go", r.Name)
+	}
+}
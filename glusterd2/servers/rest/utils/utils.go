@@ -100,6 +100,26 @@ func ErrToStatusCode(err error) (int, error) {
 		statuscode = http.StatusNotFound
 	case gderrors.ErrSnapNotFound:
 		statuscode = http.StatusNotFound
+	case gderrors.ErrSnapScheduleNotFound:
+		statuscode = http.StatusNotFound
+	case gderrors.ErrUserNotFound:
+		statuscode = http.StatusNotFound
+	case gderrors.ErrDeviceNotFound:
+		statuscode = http.StatusNotFound
+	case gderrors.ErrTemplateNotFound:
+		statuscode = http.StatusNotFound
+	case gderrors.ErrVolfileNotFound:
+		statuscode = http.StatusNotFound
+	case gderrors.ErrBrickNotFound:
+		statuscode = http.StatusNotFound
+	case gderrors.ErrBlockVolNotFound:
+		statuscode = http.StatusNotFound
+	case gderrors.ErrBlockSnapNotFound:
+		statuscode = http.StatusNotFound
+	case gderrors.ErrBlockSnapExists:
+		statuscode = http.StatusConflict
+	case gderrors.ErrBlockSnapNotSupported:
+		statuscode = http.StatusNotImplemented
 	case transaction.ErrLockTimeout:
 		statuscode = http.StatusConflict
 	default:
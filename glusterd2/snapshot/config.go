@@ -0,0 +1,251 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	gdstore "github.com/gluster/glusterd2/glusterd2/store"
+	"github.com/gluster/glusterd2/pkg/api"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+)
+
+const (
+	snapConfigPrefix     string = "snapconfig/"
+	clusterSnapConfigKey string = snapConfigPrefix + "cluster"
+	volSnapConfigPrefix  string = snapConfigPrefix + "volumes/"
+
+	// ConfigHardLimit is the maximum number of snapshots a volume can have
+	ConfigHardLimit = "snap-max-hard-limit"
+	// ConfigSoftLimit is the percentage of the hard limit after which
+	// snapshots are auto-deleted or a warning is logged
+	ConfigSoftLimit = "snap-max-soft-limit"
+	// ConfigAutoDelete enables deletion of the oldest snapshot when the
+	// soft limit is reached
+	ConfigAutoDelete = "auto-delete"
+	// ConfigActivateOnCreate enables activation of snapshots as soon as
+	// they are created
+	ConfigActivateOnCreate = "activate-on-create"
+
+	// MaxHardLimit is the largest value snap-max-hard-limit can be set to
+	MaxHardLimit uint64 = 256
+	// DefaultSoftLimit is the default value of snap-max-soft-limit
+	DefaultSoftLimit uint64 = 90
+)
+
+// Config represents the snapshot configuration of the cluster. Volumes can
+// only override the hard limit.
+type Config struct {
+	HardLimit        uint64
+	SoftLimit        uint64
+	AutoDelete       bool
+	ActivateOnCreate bool
+}
+
+// VolConfig represents the snapshot configuration of a volume
+type VolConfig struct {
+	HardLimit uint64
+}
+
+// DefaultConfig returns the snapshot configuration used when nothing has
+// been configured
+func DefaultConfig() *Config {
+	return &Config{
+		HardLimit: MaxHardLimit,
+		SoftLimit: DefaultSoftLimit,
+	}
+}
+
+// GetClusterConfig returns the cluster-wide snapshot configuration
+func GetClusterConfig() (*Config, error) {
+	resp, err := gdstore.Get(context.TODO(), clusterSnapConfigKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := DefaultConfig()
+	if resp.Count != 1 {
+		return cfg, nil
+	}
+
+	if err := json.Unmarshal(resp.Kvs[0].Value, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// SetClusterConfig stores the cluster-wide snapshot configuration
+func SetClusterConfig(cfg *Config) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = gdstore.Put(context.TODO(), clusterSnapConfigKey, string(data))
+	return err
+}
+
+// GetVolConfig returns the snapshot configuration of the volume with the
+// given ID. A nil VolConfig is returned if the volume has not been
+// configured.
+func GetVolConfig(volID string) (*VolConfig, error) {
+	resp, err := gdstore.Get(context.TODO(), volSnapConfigPrefix+volID)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Count != 1 {
+		return nil, nil
+	}
+
+	var cfg VolConfig
+	if err := json.Unmarshal(resp.Kvs[0].Value, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// SetVolConfig stores the snapshot configuration of the volume with the
+// given ID
+func SetVolConfig(volID string, cfg *VolConfig) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = gdstore.Put(context.TODO(), volSnapConfigPrefix+volID, string(data))
+	return err
+}
+
+// DeleteVolConfig removes the snapshot configuration of the volume with the
+// given ID
+func DeleteVolConfig(volID string) error {
+	_, err := gdstore.Delete(context.TODO(), volSnapConfigPrefix+volID)
+	return err
+}
+
+// GetEffectiveConfig returns the snapshot configuration applicable to the
+// volume with the given ID. The volume hard limit is capped by the cluster
+// hard limit.
+func GetEffectiveConfig(volID string) (*Config, error) {
+	cfg, err := GetClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	volCfg, err := GetVolConfig(volID)
+	if err != nil {
+		return nil, err
+	}
+
+	if volCfg != nil && volCfg.HardLimit != 0 && volCfg.HardLimit < cfg.HardLimit {
+		cfg.HardLimit = volCfg.HardLimit
+	}
+	return cfg, nil
+}
+
+// SoftLimitCount returns the number of snapshots after which the soft limit
+// is considered to be reached
+func (cfg *Config) SoftLimitCount() uint64 {
+	return cfg.HardLimit * cfg.SoftLimit / 100
+}
+
+// Set validates and applies a single option to the configuration
+func (cfg *Config) Set(key, value string) error {
+	switch key {
+	case ConfigHardLimit:
+		limit, err := parseHardLimit(value)
+		if err != nil {
+			return err
+		}
+		cfg.HardLimit = limit
+	case ConfigSoftLimit:
+		limit, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || limit == 0 || limit > 100 {
+			return fmt.Errorf("%s should be a percentage between 1 and 100", ConfigSoftLimit)
+		}
+		cfg.SoftLimit = limit
+	case ConfigAutoDelete:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s should be a boolean", ConfigAutoDelete)
+		}
+		cfg.AutoDelete = b
+	case ConfigActivateOnCreate:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s should be a boolean", ConfigActivateOnCreate)
+		}
+		cfg.ActivateOnCreate = b
+	default:
+		return gderrors.ErrInvalidSnapConfig
+	}
+	return nil
+}
+
+// Reset sets a single option of the configuration to its default value
+func (cfg *Config) Reset(key string) error {
+	def := DefaultConfig()
+	switch key {
+	case ConfigHardLimit:
+		cfg.HardLimit = def.HardLimit
+	case ConfigSoftLimit:
+		cfg.SoftLimit = def.SoftLimit
+	case ConfigAutoDelete:
+		cfg.AutoDelete = def.AutoDelete
+	case ConfigActivateOnCreate:
+		cfg.ActivateOnCreate = def.ActivateOnCreate
+	default:
+		return gderrors.ErrInvalidSnapConfig
+	}
+	return nil
+}
+
+// Set validates and applies a single option to the volume configuration
+func (cfg *VolConfig) Set(key, value string) error {
+	switch key {
+	case ConfigHardLimit:
+		limit, err := parseHardLimit(value)
+		if err != nil {
+			return err
+		}
+		cfg.HardLimit = limit
+	case ConfigSoftLimit, ConfigAutoDelete, ConfigActivateOnCreate:
+		return gderrors.ErrClusterOnlySnapConfig
+	default:
+		return gderrors.ErrInvalidSnapConfig
+	}
+	return nil
+}
+
+// Reset removes the volume override of a single option
+func (cfg *VolConfig) Reset(key string) error {
+	switch key {
+	case ConfigHardLimit:
+		cfg.HardLimit = 0
+	case ConfigSoftLimit, ConfigAutoDelete, ConfigActivateOnCreate:
+		return gderrors.ErrClusterOnlySnapConfig
+	default:
+		return gderrors.ErrInvalidSnapConfig
+	}
+	return nil
+}
+
+// ToAPI converts the configuration to its REST API representation
+func (cfg *Config) ToAPI() api.SnapConfig {
+	return api.SnapConfig{
+		HardLimit:        cfg.HardLimit,
+		SoftLimit:        cfg.SoftLimit,
+		AutoDelete:       cfg.AutoDelete,
+		ActivateOnCreate: cfg.ActivateOnCreate,
+	}
+}
+
+func parseHardLimit(value string) (uint64, error) {
+	limit, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || limit == 0 || limit > MaxHardLimit {
+		return 0, fmt.Errorf("%s should be a number between 1 and %d", ConfigHardLimit, MaxHardLimit)
+	}
+	return limit, nil
+}
@@ -0,0 +1,97 @@
+package snapshot
+
+import (
+	"testing"
+
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfigSet validates Config.Set()
+func TestConfigSet(t *testing.T) {
+	tests := []struct {
+		key     string
+		value   string
+		want    Config
+		wantErr bool
+	}{
+		{key: ConfigHardLimit, value: "10", want: Config{HardLimit: 10, SoftLimit: DefaultSoftLimit}},
+		{key: ConfigHardLimit, value: "256", want: Config{HardLimit: MaxHardLimit, SoftLimit: DefaultSoftLimit}},
+		{key: ConfigHardLimit, value: "0", wantErr: true},
+		{key: ConfigHardLimit, value: "257", wantErr: true},
+		{key: ConfigHardLimit, value: "-1", wantErr: true},
+		{key: ConfigSoftLimit, value: "50", want: Config{HardLimit: MaxHardLimit, SoftLimit: 50}},
+		{key: ConfigSoftLimit, value: "100", want: Config{HardLimit: MaxHardLimit, SoftLimit: 100}},
+		{key: ConfigSoftLimit, value: "0", wantErr: true},
+		{key: ConfigSoftLimit, value: "101", wantErr: true},
+		{key: ConfigAutoDelete, value: "true", want: Config{HardLimit: MaxHardLimit, SoftLimit: DefaultSoftLimit, AutoDelete: true}},
+		{key: ConfigAutoDelete, value: "yes", wantErr: true},
+		{key: ConfigActivateOnCreate, value: "1", want: Config{HardLimit: MaxHardLimit, SoftLimit: DefaultSoftLimit, ActivateOnCreate: true}},
+		{key: ConfigActivateOnCreate, value: "on", wantErr: true},
+		{key: "snap-max-limit", value: "10", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		err := cfg.Set(tt.key, tt.value)
+		if tt.wantErr {
+			assert.NotNil(t, err, tt.key+"="+tt.value)
+			assert.Equal(t, DefaultConfig(), cfg, tt.key+"="+tt.value)
+			continue
+		}
+		assert.Nil(t, err, tt.key+"="+tt.value)
+		assert.Equal(t, &tt.want, cfg, tt.key+"="+tt.value)
+	}
+
+	assert.Equal(t, gderrors.ErrInvalidSnapConfig, DefaultConfig().Set("snap-max-limit", "10"))
+}
+
+// TestConfigReset validates Config.Reset()
+func TestConfigReset(t *testing.T) {
+	cfg := &Config{HardLimit: 10, SoftLimit: 50, AutoDelete: true, ActivateOnCreate: true}
+	for _, key := range []string{ConfigHardLimit, ConfigSoftLimit, ConfigAutoDelete, ConfigActivateOnCreate} {
+		assert.Nil(t, cfg.Reset(key), key)
+	}
+	assert.Equal(t, DefaultConfig(), cfg)
+
+	assert.Equal(t, gderrors.ErrInvalidSnapConfig, cfg.Reset("snap-max-limit"))
+}
+
+// TestVolConfig validates VolConfig.Set() and VolConfig.Reset()
+func TestVolConfig(t *testing.T) {
+	cfg := &VolConfig{}
+	assert.Nil(t, cfg.Set(ConfigHardLimit, "20"))
+	assert.Equal(t, uint64(20), cfg.HardLimit)
+	assert.NotNil(t, cfg.Set(ConfigHardLimit, "300"))
+	assert.Equal(t, uint64(20), cfg.HardLimit)
+
+	for _, key := range []string{ConfigSoftLimit, ConfigAutoDelete, ConfigActivateOnCreate} {
+		assert.Equal(t, gderrors.ErrClusterOnlySnapConfig, cfg.Set(key, "1"), key)
+		assert.Equal(t, gderrors.ErrClusterOnlySnapConfig, cfg.Reset(key), key)
+	}
+	assert.Equal(t, gderrors.ErrInvalidSnapConfig, cfg.Set("snap-max-limit", "1"))
+	assert.Equal(t, gderrors.ErrInvalidSnapConfig, cfg.Reset("snap-max-limit"))
+
+	assert.Nil(t, cfg.Reset(ConfigHardLimit))
+	assert.Equal(t, uint64(0), cfg.HardLimit)
+}
+
+// TestSoftLimitCount validates Config.SoftLimitCount()
+func TestSoftLimitCount(t *testing.T) {
+	tests := []struct {
+		hardLimit uint64
+		softLimit uint64
+		want      uint64
+	}{
+		{hardLimit: 256, softLimit: 90, want: 230},
+		{hardLimit: 10, softLimit: 100, want: 10},
+		{hardLimit: 10, softLimit: 55, want: 5},
+		{hardLimit: 1, softLimit: 50, want: 0},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{HardLimit: tt.hardLimit, SoftLimit: tt.softLimit}
+		assert.Equal(t, tt.want, cfg.SoftLimitCount())
+	}
+}
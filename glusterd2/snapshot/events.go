@@ -0,0 +1,34 @@
+package snapshot
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/events"
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+// Event represents Snapshot life cycle events
+type Event string
+
+const (
+	// EventScheduleRunSucceeded represents a scheduled snapshot being created
+	EventScheduleRunSucceeded Event = "snapshot.schedule.succeeded"
+	// EventScheduleRunFailed represents a failure to create a scheduled snapshot
+	EventScheduleRunFailed = "snapshot.schedule.failed"
+	// EventScheduledSnapPruned represents a scheduled snapshot being deleted
+	// as per the retention rules
+	EventScheduledSnapPruned = "snapshot.schedule.pruned"
+)
+
+// NewScheduleEvent adds required details to event based on the schedule and
+// the snapshot operated upon
+func NewScheduleEvent(e Event, s *Schedule, snapname string, err error) *api.Event {
+	data := map[string]string{
+		"schedule.name": s.Name,
+		"volume.name":   s.VolName,
+		"snapshot.name": snapname,
+	}
+	if err != nil {
+		data["error"] = err.Error()
+	}
+
+	return events.New(string(e), data, true)
+}
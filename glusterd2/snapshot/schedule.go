@@ -0,0 +1,228 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	gdstore "github.com/gluster/glusterd2/glusterd2/store"
+	"github.com/gluster/glusterd2/pkg/api"
+	"github.com/gluster/glusterd2/pkg/cron"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/coreos/etcd/clientv3"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	schedulePrefix string = "snapschedules/"
+)
+
+// Schedule represents a snapshot schedule of a volume
+type Schedule struct {
+	Name         string
+	VolName      string
+	Schedule     string
+	Retention    api.SnapRetention
+	Description  string
+	Disabled     bool
+	CreatedAt    time.Time
+	LastRun      time.Time
+	LastSnapshot string
+	LastError    string
+}
+
+// NextRun returns the time at which the schedule is due next. The zero time
+// is returned if the schedule is disabled or invalid.
+func (s *Schedule) NextRun() time.Time {
+	if s.Disabled {
+		return time.Time{}
+	}
+
+	c, err := cron.Parse(s.Schedule)
+	if err != nil {
+		return time.Time{}
+	}
+
+	last := s.LastRun
+	if last.IsZero() {
+		last = s.CreatedAt
+	}
+	return c.Next(last.UTC())
+}
+
+// IsScheduledSnap returns true if the snapshot was created by the schedule.
+// The snapshots are marked with the schedule which created them, so that the
+// snapshots created by users are never pruned whatever their names.
+func (s *Schedule) IsScheduledSnap(snap *Snapinfo) bool {
+	return snap.ParentVolume == s.VolName && snap.Schedule == s.Name
+}
+
+// ToAPI converts the schedule to its REST API representation
+func (s *Schedule) ToAPI() api.SnapSchedule {
+	return api.SnapSchedule{
+		Name:         s.Name,
+		VolName:      s.VolName,
+		Schedule:     s.Schedule,
+		Retention:    s.Retention,
+		Description:  s.Description,
+		Disabled:     s.Disabled,
+		CreatedAt:    s.CreatedAt,
+		LastRun:      s.LastRun,
+		LastSnapshot: s.LastSnapshot,
+		LastError:    s.LastError,
+		NextRun:      s.NextRun(),
+	}
+}
+
+// ScheduleLockID returns the lock ID of a snapshot schedule, which is kept
+// distinct from volume and snapshot names
+func ScheduleLockID(name string) string {
+	return "snapschedule/" + name
+}
+
+// GetSchedule fetches the snapshot schedule with the given name from store
+func GetSchedule(name string) (*Schedule, error) {
+	resp, err := gdstore.Get(context.TODO(), schedulePrefix+name)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Count != 1 {
+		return nil, gderrors.ErrSnapScheduleNotFound
+	}
+
+	var s Schedule
+	if err := json.Unmarshal(resp.Kvs[0].Value, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetSchedules fetches all snapshot schedules from store
+func GetSchedules() ([]*Schedule, error) {
+	resp, err := gdstore.Get(context.TODO(), schedulePrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*Schedule, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var s Schedule
+		if err := json.Unmarshal(kv.Value, &s); err != nil {
+			log.WithError(err).WithField("schedule", string(kv.Key)).Error("Failed to unmarshal snapshot schedule")
+			continue
+		}
+		schedules = append(schedules, &s)
+	}
+	return schedules, nil
+}
+
+// AddOrUpdateSchedule stores the snapshot schedule
+func AddOrUpdateSchedule(s *Schedule) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = gdstore.Put(context.TODO(), schedulePrefix+s.Name, string(data))
+	return err
+}
+
+// DeleteSchedule removes the snapshot schedule from store
+func DeleteSchedule(name string) error {
+	_, err := gdstore.Delete(context.TODO(), schedulePrefix+name)
+	return err
+}
+
+// GetVolSchedules fetches the snapshot schedules of the given volume from
+// store
+func GetVolSchedules(volname string) ([]*Schedule, error) {
+	schedules, err := GetSchedules()
+	if err != nil {
+		return nil, err
+	}
+
+	var volSchedules []*Schedule
+	for _, s := range schedules {
+		if s.VolName == volname {
+			volSchedules = append(volSchedules, s)
+		}
+	}
+	return volSchedules, nil
+}
+
+// DeleteVolSchedules removes the snapshot schedules of the given volume from
+// store
+func DeleteVolSchedules(volname string) error {
+	schedules, err := GetVolSchedules(volname)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range schedules {
+		if err := DeleteSchedule(s.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SnapsToPrune returns the snapshots which are not kept by any of the
+// retention rules
+func SnapsToPrune(snaps []*Snapinfo, r api.SnapRetention) []*Snapinfo {
+	if r == (api.SnapRetention{}) {
+		return nil
+	}
+
+	sorted := make([]*Snapinfo, len(snaps))
+	copy(sorted, snaps)
+	// Newest first
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	keep := make(map[*Snapinfo]bool)
+	for i := 0; i < r.Last && i < len(sorted); i++ {
+		keep[sorted[i]] = true
+	}
+
+	periods := []struct {
+		count  int
+		bucket func(t time.Time) string
+	}{
+		{r.Hourly, func(t time.Time) string { return t.Format("2006-01-02 15") }},
+		{r.Daily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{r.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{r.Monthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+
+	for _, p := range periods {
+		seen := make(map[string]bool)
+		for _, snap := range sorted {
+			if len(seen) >= p.count {
+				break
+			}
+			b := p.bucket(snap.CreatedAt.UTC())
+			if seen[b] {
+				continue
+			}
+			// The first snapshot seen in a period is its newest one
+			seen[b] = true
+			keep[snap] = true
+		}
+	}
+
+	var prune []*Snapinfo
+	for _, snap := range sorted {
+		if !keep[snap] {
+			prune = append(prune, snap)
+		}
+	}
+	return prune
+}
@@ -0,0 +1,77 @@
+package snapshot
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func snapNames(snaps []*Snapinfo) []string {
+	var names []string
+	for _, s := range snaps {
+		names = append(names, s.SnapVolinfo.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestSnapsToPrune(t *testing.T) {
+	base := time.Date(2019, time.January, 10, 12, 0, 0, 0, time.UTC)
+
+	// A snapshot every 30 minutes for two days
+	var snaps []*Snapinfo
+	for i := 0; i < 96; i++ {
+		s := new(Snapinfo)
+		s.SnapVolinfo.Name = base.Add(-time.Duration(i) * 30 * time.Minute).Format("s-2006-01-02-15-04")
+		s.CreatedAt = base.Add(-time.Duration(i) * 30 * time.Minute)
+		snaps = append(snaps, s)
+	}
+
+	assert.Empty(t, SnapsToPrune(snaps, api.SnapRetention{}))
+
+	prune := SnapsToPrune(snaps, api.SnapRetention{Last: 3})
+	assert.Len(t, prune, 93)
+	assert.NotContains(t, snapNames(prune), "s-2019-01-10-12-00")
+	assert.NotContains(t, snapNames(prune), "s-2019-01-10-11-00")
+
+	// Newest snapshot of each of the last 4 hours
+	prune = SnapsToPrune(snaps, api.SnapRetention{Hourly: 4})
+	assert.Len(t, prune, 92)
+	for _, name := range []string{"s-2019-01-10-12-00", "s-2019-01-10-11-30", "s-2019-01-10-10-30", "s-2019-01-10-09-30"} {
+		assert.NotContains(t, snapNames(prune), name)
+	}
+
+	// Rules are combined
+	prune = SnapsToPrune(snaps, api.SnapRetention{Last: 1, Daily: 3})
+	assert.Len(t, prune, 93)
+	for _, name := range []string{"s-2019-01-10-12-00", "s-2019-01-09-23-30", "s-2019-01-08-23-30"} {
+		assert.NotContains(t, snapNames(prune), name)
+	}
+}
+
+func TestIsScheduledSnap(t *testing.T) {
+	sched := &Schedule{Name: "hourly", VolName: "vol1"}
+
+	newSnap := func(name, volname, schedule string) *Snapinfo {
+		s := new(Snapinfo)
+		s.SnapVolinfo.Name = name
+		s.ParentVolume = volname
+		s.Schedule = schedule
+		return s
+	}
+
+	assert.True(t, sched.IsScheduledSnap(newSnap("hourly_GMT_2019_01_10_12_00_00", "vol1", "hourly")))
+
+	// Snapshots created by users are never taken for scheduled ones, even
+	// if their names match those of the scheduled snapshots
+	assert.False(t, sched.IsScheduledSnap(newSnap("hourly_GMT_2019_01_10_12_00_00", "vol1", "")))
+	assert.False(t, sched.IsScheduledSnap(newSnap("hourly_GMT_keep", "vol1", "")))
+
+	// Snapshots of other schedules or of other volumes
+	assert.False(t, sched.IsScheduledSnap(newSnap("daily_GMT_2019_01_10_12_00_00", "vol1", "daily")))
+	assert.False(t, sched.IsScheduledSnap(newSnap("hourly_GMT_2019_01_10_12_00_00", "vol2", "hourly")))
+}
@@ -14,4 +14,7 @@ type Snapinfo struct {
 	Description  string
 	OptionChange map[string]string
 	CreatedAt    time.Time
+	// Schedule is the name of the snapshot schedule which created the
+	// snapshot, it is empty for the snapshots created by users
+	Schedule string
 }
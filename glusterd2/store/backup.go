@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/gluster/glusterd2/pkg/elasticetcd"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrRestoreNotSupported is returned when restoring the store is requested
+// with a remote etcd cluster
+var ErrRestoreNotSupported = errors.New("store restore is supported only with the embedded store")
+
+// Snapshot returns a reader streaming a point-in-time snapshot of the etcd
+// backend of the store. The caller must close the reader.
+func Snapshot(ctx context.Context) (io.ReadCloser, error) {
+	defer storeCounters.Add("snapshot", 1)
+	return Store.Client.Snapshot(ctx)
+}
+
+// Restore reseeds the embedded store with the etcd snapshot saved at
+// snapshotPath. It must be called before the store is initialized. The store
+// config is reset to start a new embedded etcd server on the next Init.
+func Restore(snapshotPath string) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if Store != nil {
+		return ErrStoreInitedAlready
+	}
+
+	conf := GetConfig()
+	if conf.NoEmbed {
+		return ErrRestoreNotSupported
+	}
+
+	// Endpoints of the old cluster would make elasticetcd join it instead
+	// of starting the restored server
+	conf.Endpoints = []string{elasticetcd.DefaultEndpoint}
+
+	econf, err := getElasticConfig(conf)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"snapshot": snapshotPath,
+		"datadir":  econf.Dir,
+	}).Info("restoring embedded store")
+
+	if err := elasticetcd.Restore(econf, snapshotPath); err != nil {
+		return err
+	}
+
+	return conf.Save()
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 
+	"github.com/gluster/glusterd2/glusterd2/peertls"
 	"github.com/gluster/glusterd2/pkg/elasticetcd"
 
 	"github.com/pelletier/go-toml"
@@ -176,6 +177,18 @@ func GetConfig() *Config {
 		conf.UseTLS = config.GetBool(useTLSOpt)
 	}
 
+	// Peer TLS secures the embedded store and the connections to it with
+	// the peer certificates
+	if peertls.Enabled() {
+		conf.UseTLS = true
+		conf.CertFile = peertls.CertFile()
+		conf.KeyFile = peertls.KeyFile()
+		conf.CAFile = peertls.CAFile()
+		conf.ClntCertFile = peertls.CertFile()
+		conf.ClntKeyFile = peertls.KeyFile()
+		conf.ClntCAFile = peertls.CAFile()
+	}
+
 	log.Debug("saving updated store config")
 	if err := conf.Save(); err != nil {
 		log.WithError(err).Warn("failed to save updated store config")
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/metrics"
 	"github.com/gluster/glusterd2/pkg/elasticetcd"
 
 	"github.com/coreos/etcd/clientv3"
@@ -264,6 +265,11 @@ func newNamespacedStore(oc *clientv3.Client, conf *Config) (*GDStore, error) {
 	}, nil
 }
 
+// observeOp records the latency of a store operation which started at start
+func observeOp(op string, start time.Time) {
+	metrics.StoreOpDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+}
+
 //Get is a wrapper function that calls clientv3.KV.Get with a default timeout if an empty context is passed
 func Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
 	var cancel context.CancelFunc
@@ -278,6 +284,7 @@ func Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.
 	}
 
 	defer storeCounters.Add("get", 1)
+	defer observeOp("get", time.Now())
 	return Store.Get(ctx, key, opts...)
 }
 
@@ -291,6 +298,7 @@ func Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clie
 	}
 
 	defer storeCounters.Add("put", 1)
+	defer observeOp("put", time.Now())
 	return Store.Put(ctx, key, val, opts...)
 }
 
@@ -304,6 +312,7 @@ func Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*client
 	}
 
 	defer storeCounters.Add("delete", 1)
+	defer observeOp("delete", time.Now())
 	return Store.Delete(ctx, key, opts...)
 }
 
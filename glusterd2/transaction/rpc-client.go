@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/gluster/glusterd2/glusterd2/peer"
+	"github.com/gluster/glusterd2/glusterd2/peertls"
 	"github.com/gluster/glusterd2/pkg/utils"
 
 	"github.com/pborman/uuid"
@@ -35,7 +36,7 @@ func runStepOn(origCtx context.Context, step string, node uuid.UUID, c TxnCtx) e
 
 	conn, err = grpc.Dial(remote,
 		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
-		grpc.WithInsecure(),
+		peertls.DialOption(),
 	)
 	if err == nil && conn != nil {
 		logger.WithFields(log.Fields{
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/jobs"
 	"github.com/gluster/glusterd2/glusterd2/store"
 
 	"github.com/coreos/etcd/clientv3"
@@ -140,7 +141,21 @@ func (t *Txn) Do() error {
 			continue
 		}
 
-		if err := s.do(t.OrigCtx, t.Ctx); err != nil {
+		// Steps of a cancelled job are not run, and the steps which
+		// were already run are rolled back
+		if err := jobs.Cancelled(t.OrigCtx); err != nil {
+			expTxn.Add("initiated_txn_failure", 1)
+			if !t.DisableRollback && i > 0 {
+				t.Ctx.Logger().WithError(err).Error("Transaction cancelled, rolling back changes")
+				t.undo(i - 1)
+			}
+			return err
+		}
+
+		jobs.StepStarted(t.OrigCtx, s.DoFunc)
+		err := s.do(t.OrigCtx, t.Ctx)
+		jobs.StepDone(t.OrigCtx, s.DoFunc, err)
+		if err != nil {
 			if t.DontCheckAlive && isNodeUnreachable(err) {
 				continue
 			}
@@ -5,4 +5,8 @@ import "errors"
 var (
 	errTxnTimeout     = errors.New("txn timeout")
 	errTxnSyncTimeout = errors.New("timeout in synchronizing txn")
+
+	// ErrTxnNotFound is returned when a txn is neither pending nor in the
+	// transaction history
+	ErrTxnNotFound = errors.New("transaction not found")
 )
@@ -26,6 +26,7 @@ func NewExecutor() Executor {
 
 	stepManager := newStepManager()
 	stepManager = newTracingManager(stepManager)
+	stepManager = newMetricsManager(stepManager)
 	e.stepManager = stepManager
 	return e
 }
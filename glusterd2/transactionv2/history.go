@@ -0,0 +1,311 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/store"
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/pborman/uuid"
+	log "github.com/sirupsen/logrus"
+	flag "github.com/spf13/pflag"
+	config "github.com/spf13/viper"
+)
+
+const (
+	// TxnHistoryPrefix is the etcd namespace into which finished txns are
+	// recorded, eg.. transaction-history/<txn-ID>
+	TxnHistoryPrefix = "transaction-history/"
+
+	historySizeOpt = "txn-history-size"
+	historyAgeOpt  = "txn-history-max-age"
+
+	defaultHistorySize = 100
+	defaultHistoryAge  = 24 * time.Hour
+)
+
+// InitFlags sets up the transaction history flags
+func InitFlags() {
+	flag.Int(historySizeOpt, defaultHistorySize, "Number of finished transactions to keep in the transaction history. 0 disables the history.")
+	flag.Duration(historyAgeOpt, defaultHistoryAge, "Duration for which finished transactions are kept in the transaction history.")
+}
+
+// txnInfo returns the details of a txn along with its current status on each
+// of the nodes involved in it
+func txnInfo(txn *Txn) api.Txn {
+	info := api.Txn{
+		ID:              txn.ID,
+		ReqID:           txn.ReqID,
+		DisableRollback: txn.DisableRollback,
+		StartTime:       txn.StartTime,
+	}
+
+	for _, s := range txn.Steps {
+		info.Steps = append(info.Steps, api.TxnStep{
+			DoFunc:   s.DoFunc,
+			UndoFunc: s.UndoFunc,
+			Nodes:    s.Nodes,
+			Skip:     s.Skip,
+			Sync:     s.Sync,
+		})
+	}
+
+	// A single read of the txn namespace, instead of locking and reading
+	// the status of each node
+	key := path.Join(PendingTxnPrefix, txn.ID.String()) + "/"
+	var kvs []*mvccpb.KeyValue
+	resp, err := store.Get(context.TODO(), key, clientv3.WithPrefix())
+	if err != nil {
+		txn.Ctx.Logger().WithError(err).Error("failed to get status of txn")
+	} else {
+		kvs = resp.Kvs
+	}
+
+	info.Nodes = txnNodeStatuses(txn.Nodes, key, kvs)
+	setTxnState(&info)
+
+	return info
+}
+
+// txnNodeStatuses returns the status of each of the nodes of a txn, read from
+// the keys of the txn namespace
+func txnNodeStatuses(nodeIDs []uuid.UUID, prefix string, kvs []*mvccpb.KeyValue) []api.TxnNodeStatus {
+	nodes := make(map[string]*api.TxnNodeStatus)
+	for _, nodeID := range nodeIDs {
+		nodes[nodeID.String()] = &api.TxnNodeStatus{
+			PeerID:           nodeID,
+			State:            string(txnUnknown),
+			LastExecutedStep: -1,
+		}
+	}
+
+	for _, kv := range kvs {
+		nodeID, field := path.Split(strings.TrimPrefix(string(kv.Key), prefix))
+		node, ok := nodes[strings.TrimSuffix(nodeID, "/")]
+		if !ok {
+			continue
+		}
+		switch field {
+		case TxnStatusPrefix:
+			var status TxnStatus
+			if err := json.Unmarshal(kv.Value, &status); err == nil && status.State.Valid() {
+				node.State = string(status.State)
+				node.Reason = status.Reason
+			}
+		case LastExecutedStepPrefix:
+			if i, err := strconv.Atoi(string(kv.Value)); err == nil {
+				node.LastExecutedStep = i
+			}
+		}
+	}
+
+	var statuses []api.TxnNodeStatus
+	for _, nodeID := range nodeIDs {
+		statuses = append(statuses, *nodes[nodeID.String()])
+	}
+	return statuses
+}
+
+// setTxnState derives the state, error and rollback state of a txn from the
+// status of its nodes
+func setTxnState(info *api.Txn) {
+	var failed, succeeded, running int
+	for i := range info.Nodes {
+		node := &info.Nodes[i]
+		switch TxnState(node.State) {
+		case txnFailed:
+			failed++
+			node.RolledBack = node.LastExecutedStep == -1
+			if info.Error == "" {
+				info.Error = node.Reason
+			}
+		case txnSucceeded:
+			succeeded++
+		case txnRunning:
+			running++
+		}
+	}
+
+	switch {
+	case failed > 0:
+		info.State = string(txnFailed)
+		info.Rollback = api.TxnRollbackCompleted
+		for _, node := range info.Nodes {
+			if node.LastExecutedStep != -1 {
+				info.Rollback = api.TxnRollbackPending
+			}
+		}
+	case succeeded == len(info.Nodes):
+		info.State = string(txnSucceeded)
+	case running > 0:
+		info.State = string(txnRunning)
+	default:
+		info.State = string(txnPending)
+	}
+}
+
+// recordTxn adds a finished txn to the transaction history. The error and
+// end time of an already recorded txn are retained, so that the record can
+// be updated once the txn is rolled back.
+func recordTxn(info api.Txn) {
+	size := config.GetInt(historySizeOpt)
+	if size <= 0 {
+		return
+	}
+
+	logger := log.WithField("txnid", info.ID.String())
+	key := TxnHistoryPrefix + info.ID.String()
+
+	if old, err := getRecordedTxn(info.ID); err == nil {
+		if info.Error == "" {
+			info.Error = old.Error
+		}
+		if !old.EndTime.IsZero() {
+			info.EndTime = old.EndTime
+		}
+	}
+	if info.EndTime.IsZero() {
+		info.EndTime = time.Now()
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal txn history")
+		return
+	}
+
+	var opts []clientv3.OpOption
+	if age := config.GetDuration(historyAgeOpt); age > 0 {
+		lease, err := store.Store.Grant(context.TODO(), int64(age/time.Second))
+		if err != nil {
+			logger.WithError(err).Error("failed to grant lease for txn history")
+			return
+		}
+		opts = append(opts, clientv3.WithLease(lease.ID))
+	}
+
+	if _, err := store.Put(context.TODO(), key, string(data), opts...); err != nil {
+		logger.WithError(err).Error("failed to record txn history")
+		return
+	}
+
+	pruneHistory(size)
+}
+
+// pruneHistory removes the oldest txns from the history, retaining the
+// given number of txns
+func pruneHistory(size int) {
+	resp, err := store.Get(context.TODO(), TxnHistoryPrefix, clientv3.WithPrefix(), clientv3.WithKeysOnly(),
+		clientv3.WithSort(clientv3.SortByCreateRevision, clientv3.SortAscend))
+	if err != nil {
+		log.WithError(err).Error("failed to get txn history")
+		return
+	}
+
+	for _, key := range historyToPrune(resp.Kvs, size) {
+		if _, err := store.Delete(context.TODO(), key); err != nil {
+			log.WithError(err).WithField("key", key).Error("failed to prune txn history")
+		}
+	}
+}
+
+// historyToPrune returns the keys of the oldest txns in excess of the given
+// history size. The keys are expected to be sorted from the oldest txn.
+func historyToPrune(kvs []*mvccpb.KeyValue, size int) []string {
+	var keys []string
+	for i := 0; i < len(kvs)-size; i++ {
+		keys = append(keys, string(kvs[i].Key))
+	}
+	return keys
+}
+
+func getRecordedTxn(id uuid.UUID) (*api.Txn, error) {
+	resp, err := store.Get(context.TODO(), TxnHistoryPrefix+id.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, ErrTxnNotFound
+	}
+
+	var info api.Txn
+	if err := json.Unmarshal(resp.Kvs[0].Value, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// mergeRecorded fills the details only known to the history into the info
+// of a pending txn
+func mergeRecorded(info *api.Txn, recorded *api.Txn) {
+	if info.Error == "" {
+		info.Error = recorded.Error
+	}
+	info.EndTime = recorded.EndTime
+}
+
+// GetTxnInfo returns the details of a pending or a recorded txn
+func GetTxnInfo(id uuid.UUID) (*api.Txn, error) {
+	recorded, err := getRecordedTxn(id)
+	if err != nil && err != ErrTxnNotFound {
+		return nil, err
+	}
+
+	txn, perr := GlobalTxnManager.GetTxnByUUID(id)
+	if perr != nil {
+		if recorded == nil {
+			return nil, ErrTxnNotFound
+		}
+		return recorded, nil
+	}
+
+	info := txnInfo(txn)
+	if recorded != nil {
+		mergeRecorded(&info, recorded)
+	}
+	return &info, nil
+}
+
+// ListTxnInfos returns the details of the pending txns and the txns in the
+// transaction history, the most recent first
+func ListTxnInfos() ([]api.Txn, error) {
+	resp, err := store.Get(context.TODO(), TxnHistoryPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make(map[string]api.Txn)
+	for _, kv := range resp.Kvs {
+		var info api.Txn
+		if err := json.Unmarshal(kv.Value, &info); err != nil {
+			log.WithError(err).WithField("key", string(kv.Key)).Error("failed to unmarshal txn history")
+			continue
+		}
+		txns[info.ID.String()] = info
+	}
+
+	for _, txn := range GlobalTxnManager.GetTxns() {
+		info := txnInfo(txn)
+		if recorded, ok := txns[info.ID.String()]; ok {
+			mergeRecorded(&info, &recorded)
+		}
+		txns[info.ID.String()] = info
+	}
+
+	list := make([]api.Txn, 0, len(txns))
+	for _, info := range txns {
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].StartTime.After(list[j].StartTime)
+	})
+	return list, nil
+}
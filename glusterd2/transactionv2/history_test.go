@@ -0,0 +1,108 @@
+package transaction
+
+import (
+	"path"
+	"testing"
+
+	"github.com/gluster/glusterd2/pkg/api"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTxnNodeStatuses validates txnNodeStatuses()
+func TestTxnNodeStatuses(t *testing.T) {
+	txnID := uuid.NewRandom()
+	node1, node2, node3 := uuid.NewRandom(), uuid.NewRandom(), uuid.NewRandom()
+	prefix := path.Join(PendingTxnPrefix, txnID.String()) + "/"
+
+	kvs := []*mvccpb.KeyValue{
+		{Key: []byte(prefix + node1.String() + "/" + TxnStatusPrefix), Value: []byte(`{"txn_state":"Failed","reason":"brick not found"}`)},
+		{Key: []byte(prefix + node1.String() + "/" + LastExecutedStepPrefix), Value: []byte("2")},
+		{Key: []byte(prefix + node2.String() + "/" + TxnStatusPrefix), Value: []byte(`{"txn_state":"Bogus"}`)},
+		{Key: []byte(prefix + node2.String() + "/" + LastExecutedStepPrefix), Value: []byte("not a step")},
+		{Key: []byte(prefix + uuid.NewRandom().String() + "/" + TxnStatusPrefix), Value: []byte(`{"txn_state":"Running"}`)},
+	}
+
+	nodes := txnNodeStatuses([]uuid.UUID{node1, node2, node3}, prefix, kvs)
+	assert.Equal(t, []api.TxnNodeStatus{
+		{PeerID: node1, State: string(txnFailed), Reason: "brick not found", LastExecutedStep: 2},
+		{PeerID: node2, State: string(txnUnknown), LastExecutedStep: -1},
+		{PeerID: node3, State: string(txnUnknown), LastExecutedStep: -1},
+	}, nodes)
+}
+
+// TestSetTxnState validates setTxnState()
+func TestSetTxnState(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodes      []api.TxnNodeStatus
+		state      TxnState
+		rollback   string
+		err        string
+		rolledBack []bool
+	}{
+		{
+			name:  "all nodes succeeded",
+			nodes: []api.TxnNodeStatus{{State: string(txnSucceeded)}, {State: string(txnSucceeded)}},
+			state: txnSucceeded,
+		},
+		{
+			name:  "a node still running",
+			nodes: []api.TxnNodeStatus{{State: string(txnSucceeded)}, {State: string(txnRunning)}},
+			state: txnRunning,
+		},
+		{
+			name:  "nodes not started",
+			nodes: []api.TxnNodeStatus{{State: string(txnPending)}, {State: string(txnUnknown)}},
+			state: txnPending,
+		},
+		{
+			name: "failed and rolled back",
+			nodes: []api.TxnNodeStatus{
+				{State: string(txnFailed), Reason: "first", LastExecutedStep: -1},
+				{State: string(txnFailed), Reason: "second", LastExecutedStep: -1},
+			},
+			state:      txnFailed,
+			rollback:   api.TxnRollbackCompleted,
+			err:        "first",
+			rolledBack: []bool{true, true},
+		},
+		{
+			name: "failed with rollback pending",
+			nodes: []api.TxnNodeStatus{
+				{State: string(txnSucceeded), LastExecutedStep: 3},
+				{State: string(txnFailed), Reason: "failed", LastExecutedStep: 1},
+			},
+			state:      txnFailed,
+			rollback:   api.TxnRollbackPending,
+			err:        "failed",
+			rolledBack: []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		info := api.Txn{Nodes: tt.nodes}
+		setTxnState(&info)
+		assert.Equal(t, string(tt.state), info.State, tt.name)
+		assert.Equal(t, tt.rollback, info.Rollback, tt.name)
+		assert.Equal(t, tt.err, info.Error, tt.name)
+		for i, rolledBack := range tt.rolledBack {
+			assert.Equal(t, rolledBack, info.Nodes[i].RolledBack, tt.name)
+		}
+	}
+}
+
+// TestHistoryToPrune validates historyToPrune()
+func TestHistoryToPrune(t *testing.T) {
+	var kvs []*mvccpb.KeyValue
+	for _, id := range []string{"a", "b", "c", "d"} {
+		kvs = append(kvs, &mvccpb.KeyValue{Key: []byte(TxnHistoryPrefix + id)})
+	}
+
+	assert.Equal(t, []string{TxnHistoryPrefix + "a", TxnHistoryPrefix + "b"}, historyToPrune(kvs, 2))
+	assert.Empty(t, historyToPrune(kvs, 4))
+	assert.Empty(t, historyToPrune(kvs, 10))
+	assert.Equal(t, 4, len(historyToPrune(kvs, 0)))
+}
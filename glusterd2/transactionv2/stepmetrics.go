@@ -0,0 +1,66 @@
+package transaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/metrics"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+
+	"github.com/pborman/uuid"
+)
+
+func newMetricsManager(next StepManager) StepManager {
+	return &metricsManager{next: next, selfNodeID: gdctx.MyUUID}
+}
+
+type metricsManager struct {
+	next       StepManager
+	selfNodeID uuid.UUID
+}
+
+// observeStep records the duration and the failure of a step function
+func observeStep(stepName, action string, start time.Time, err error) {
+	metrics.TxnStepDuration.WithLabelValues(stepName, action).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.TxnStepFailures.WithLabelValues(stepName, action).Inc()
+	}
+}
+
+// RunStep is a middleware which records metrics of step.DoFunc when it is
+// run on this node
+func (m *metricsManager) RunStep(ctx context.Context, step *transaction.Step, txnCtx transaction.TxnCtx) (err error) {
+	if !stepRunsOnNode(step, m.selfNodeID) {
+		return m.next.RunStep(ctx, step, txnCtx)
+	}
+
+	defer func(start time.Time) {
+		observeStep(step.DoFunc, "run", start, err)
+	}(time.Now())
+
+	return m.next.RunStep(ctx, step, txnCtx)
+}
+
+// RollBackStep is a middleware which records metrics of step.UndoFunc when
+// it is run on this node
+func (m *metricsManager) RollBackStep(ctx context.Context, step *transaction.Step, txnCtx transaction.TxnCtx) (err error) {
+	if step.UndoFunc == "" || !stepRunsOnNode(step, m.selfNodeID) {
+		return m.next.RollBackStep(ctx, step, txnCtx)
+	}
+
+	defer func(start time.Time) {
+		observeStep(step.UndoFunc, "rollback", start, err)
+	}(time.Now())
+
+	return m.next.RollBackStep(ctx, step, txnCtx)
+}
+
+// SyncStep is a middleware which records metrics of Sync steps
+func (m *metricsManager) SyncStep(ctx context.Context, stepIndex int, txn *Txn) (err error) {
+	defer func(start time.Time) {
+		observeStep(txn.Steps[stepIndex].DoFunc, "sync", start, err)
+	}(time.Now())
+
+	return m.next.SyncStep(ctx, stepIndex, txn)
+}
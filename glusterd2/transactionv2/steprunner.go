@@ -31,12 +31,17 @@ func newStepManager() StepManager {
 }
 
 func (sm *stepManager) shouldRunStep(step *transaction.Step) bool {
+	return stepRunsOnNode(step, sm.selfNodeID)
+}
+
+// stepRunsOnNode reports whether the step is to be run on the given node
+func stepRunsOnNode(step *transaction.Step, nodeID uuid.UUID) bool {
 	if step.Skip {
 		return false
 	}
 
 	for _, id := range step.Nodes {
-		if uuid.Equal(sm.selfNodeID, id) {
+		if uuid.Equal(nodeID, id) {
 			return true
 		}
 	}
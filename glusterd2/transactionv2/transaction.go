@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/jobs"
 	"github.com/gluster/glusterd2/glusterd2/store"
 	"github.com/gluster/glusterd2/glusterd2/transaction"
 
@@ -44,12 +45,18 @@ type Txn struct {
 	error     chan error
 	stop      chan struct{}
 	succeeded bool
+
+	// ctx is the context of the request which created the txn
+	ctx context.Context
+	// doErr is the error the txn failed with
+	doErr error
 }
 
 // NewTxn returns an initialized Txn without any steps
 func NewTxn(ctx context.Context) *Txn {
 	t := new(Txn)
 
+	t.ctx = ctx
 	t.ID = uuid.NewRandom()
 	t.ReqID = gdctx.GetReqID(ctx)
 	t.locks = transaction.Locks{}
@@ -103,6 +110,7 @@ func (t *Txn) releaseLocks() {
 // Done must be called after a transaction ends
 func (t *Txn) Done() {
 	defer t.releaseLocks()
+	t.record()
 	if !t.succeeded {
 		return
 	}
@@ -115,6 +123,20 @@ func (t *Txn) Done() {
 	}
 }
 
+// record adds the txn to the transaction history once it has been run
+func (t *Txn) record() {
+	if t.StartTime.IsZero() {
+		return
+	}
+
+	info := txnInfo(t)
+	if t.doErr != nil {
+		info.State = string(txnFailed)
+		info.Error = t.doErr.Error()
+	}
+	recordTxn(info)
+}
+
 func (t *Txn) removeContextData() {
 	if _, err := store.Delete(context.TODO(), t.StorePrefix, clientv3.WithPrefix()); err != nil {
 		t.Ctx.Logger().WithError(err).WithField("key",
@@ -149,26 +171,56 @@ func (t *Txn) Do() error {
 
 	if err := t.prepare(); err != nil {
 		t.Ctx.Logger().WithError(err).Error("failed in preparing transaction")
+		t.doErr = err
 		return err
 	}
 
 	t.Ctx.Logger().Debug("waiting for completion of transaction")
 
+	// The steps are run by the txn engine of each node, so the steps of a
+	// job are only reported as done once the whole txn completes
+	t.stepsStarted()
+
 	select {
 	case <-t.success:
 		t.succeeded = true
+		t.stepsDone(nil)
 	case err := <-t.error:
 		t.onFailure(err)
+		t.stepsDone(err)
 		return err
 	case <-timer.C:
 		t.onFailure(errTxnTimeout)
+		t.stepsDone(errTxnTimeout)
 		return errTxnTimeout
+	case <-jobs.CancelNotify(t.ctx):
+		err := jobs.Cancelled(t.ctx)
+		t.onFailure(err)
+		t.stepsDone(err)
+		return err
 	}
 
 	return nil
 }
 
+func (t *Txn) stepsStarted() {
+	for _, s := range t.Steps {
+		if !s.Skip {
+			jobs.StepStarted(t.ctx, s.DoFunc)
+		}
+	}
+}
+
+func (t *Txn) stepsDone(err error) {
+	for _, s := range t.Steps {
+		if !s.Skip {
+			jobs.StepDone(t.ctx, s.DoFunc, err)
+		}
+	}
+}
+
 func (t *Txn) onFailure(err error) error {
+	t.doErr = err
 	t.Ctx.Logger().WithError(err).Error("error in executing txn, marking as failure")
 	txnStatus := TxnStatus{State: txnFailed, TxnID: t.ID, Reason: err.Error()}
 	return GlobalTxnManager.UpDateTxnStatus(txnStatus, t.ID, t.Nodes...)
@@ -415,6 +415,7 @@ func (tm *txnManager) RemoveFailedTxns() {
 
 		if nodesRollbacked == len(txn.Nodes) {
 			txn.Ctx.Logger().Info("txn rolled back on all nodes, cleaning from store")
+			recordTxn(txnInfo(txn))
 			txn.removeContextData()
 			tm.RemoveTransaction(txn.ID)
 		}
@@ -0,0 +1,224 @@
+package user
+
+import (
+	"net/http"
+)
+
+// Role decides the REST APIs which a user is permitted to call
+type Role string
+
+const (
+	// ReadOnly users can only call APIs which do not modify the cluster
+	ReadOnly Role = "read-only"
+	// VolumeAdmin users can manage volumes, but not the cluster itself
+	VolumeAdmin Role = "volume-admin"
+	// ClusterAdmin users can call all the APIs
+	ClusterAdmin Role = "cluster-admin"
+)
+
+// routeRoles has the least privileged role which is permitted to call each
+// route. Read-only users can call the routes which only read the state of
+// the cluster, volume admins can also call the routes which manage volumes
+// and the objects within them. Routes which are not listed can only be
+// called by cluster admins, and every route is expected to be listed.
+var routeRoles = map[string]Role{
+	// Unauthenticated routes, listed for completeness
+	"Glusterd2 service status": ReadOnly,
+	"List Endpoints":           ReadOnly,
+	"Metrics":                  ReadOnly,
+
+	"GetVersion":        ReadOnly,
+	"GetPeer":           ReadOnly,
+	"GetPeers":          ReadOnly,
+	"AddPeer":           ClusterAdmin,
+	"DeletePeer":        ClusterAdmin,
+	"EditPeer":          ClusterAdmin,
+	"PeerTLSReload":     ClusterAdmin,
+	"GetClusterOptions": ReadOnly,
+	"SetClusterOptions": ClusterAdmin,
+	"ClusterBackup":     ClusterAdmin,
+	"UserCreate":        ClusterAdmin,
+	"UserList":          ClusterAdmin,
+	"UserGet":           ClusterAdmin,
+	"UserEdit":          ClusterAdmin,
+	"UserDelete":        ClusterAdmin,
+	"AuditList":         ClusterAdmin,
+	"TransactionList":   ClusterAdmin,
+	"TransactionGet":    ClusterAdmin,
+	// Jobs are only shown to the users who started them
+	"JobList":   ReadOnly,
+	"JobGet":    ReadOnly,
+	"JobCancel": VolumeAdmin,
+
+	"TemplateList":   ReadOnly,
+	"TemplateGet":    ReadOnly,
+	"TemplateCreate": ClusterAdmin,
+	"TemplateEdit":   ClusterAdmin,
+	"TemplateDelete": ClusterAdmin,
+
+	"OptionGroupList":   ReadOnly,
+	"OptionGroupCreate": ClusterAdmin,
+	"OptionGroupDelete": ClusterAdmin,
+
+	"VolumeList":          ReadOnly,
+	"VolumeInfo":          ReadOnly,
+	"VolumeStatus":        ReadOnly,
+	"VolumeBricksStatus":  ReadOnly,
+	"VolumeStatusDetail":  ReadOnly,
+	"VolumeOptionGet":     ReadOnly,
+	"VolumeOptionsGet":    ReadOnly,
+	"VolumeShrinkStatus":  ReadOnly,
+	"VolumeTop":           ReadOnly,
+	"VolumeVolfileList":   ReadOnly,
+	"VolumeVolfileGet":    ReadOnly,
+	"VolumeCreate":        VolumeAdmin,
+	"VolumeDelete":        VolumeAdmin,
+	"VolumeStart":         VolumeAdmin,
+	"VolumeStop":          VolumeAdmin,
+	"VolumeExpand":        VolumeAdmin,
+	"VolumeShrink":        VolumeAdmin,
+	"VolumeShrinkCommit":  VolumeAdmin,
+	"VolumeShrinkStop":    VolumeAdmin,
+	"VolumeReshape":       VolumeAdmin,
+	"VolumeRename":        VolumeAdmin,
+	"VolumeOptions":       VolumeAdmin,
+	"VolumeReset":         VolumeAdmin,
+	"EditVolume":          VolumeAdmin,
+	"ReplaceBrick":        VolumeAdmin,
+	"ProfileVolume":       VolumeAdmin,
+	"VolumeTopThroughput": VolumeAdmin,
+	// Volume statedumps, and the expvar statedump of glusterd2
+	"Statedump": VolumeAdmin,
+
+	"SnapshotListAll":        ReadOnly,
+	"SnapshotInfo":           ReadOnly,
+	"SnapshotStatus":         ReadOnly,
+	"SnapshotConfigGet":      ReadOnly,
+	"SnapshotScheduleList":   ReadOnly,
+	"SnapshotScheduleInfo":   ReadOnly,
+	"SnapshotCreate":         VolumeAdmin,
+	"SnapshotDelete":         VolumeAdmin,
+	"SnapshotActivate":       VolumeAdmin,
+	"SnapshotDeactivate":     VolumeAdmin,
+	"SnapshotClone":          VolumeAdmin,
+	"SnapshotRestore":        VolumeAdmin,
+	"SnapshotScheduleCreate": VolumeAdmin,
+	"SnapshotScheduleEdit":   VolumeAdmin,
+	"SnapshotScheduleDelete": VolumeAdmin,
+	"SnapshotConfigSet":      ClusterAdmin,
+	"SnapshotConfigReset":    ClusterAdmin,
+
+	"BitrotScrubStatus":      ReadOnly,
+	"BitrotEnable":           VolumeAdmin,
+	"BitrotDisable":          VolumeAdmin,
+	"BitrotScrubOndemand":    VolumeAdmin,
+	"SelfHealInfo":           ReadOnly,
+	"SelfHealInfo2":          ReadOnly,
+	"SelfHeal":               VolumeAdmin,
+	"Split-Brain-Operations": VolumeAdmin,
+	"RebalanceStatus":        ReadOnly,
+	"RebalanceStart":         VolumeAdmin,
+	"RebalanceStop":          VolumeAdmin,
+	"QuotaList":              ReadOnly,
+	"QuotaLimit":             VolumeAdmin,
+	"QuotaRemove":            VolumeAdmin,
+
+	"GeoReplicationStatus":         ReadOnly,
+	"GeoReplicationStatusList":     ReadOnly,
+	"GeoReplicationConfigGet":      ReadOnly,
+	"GeoReplicationSshKeyGet":      ReadOnly,
+	"GeoReplicationCreate":         VolumeAdmin,
+	"GeoReplicationStart":          VolumeAdmin,
+	"GeoReplicationStop":           VolumeAdmin,
+	"GeoReplicationDelete":         VolumeAdmin,
+	"GeoReplicationPause":          VolumeAdmin,
+	"GeoReplicationResume":         VolumeAdmin,
+	"GeoReplicationConfigSet":      VolumeAdmin,
+	"GeoReplicationConfigReset":    VolumeAdmin,
+	"GeoReplicationSshKeyGenerate": VolumeAdmin,
+	"GeoReplicationSshKeyPush":     VolumeAdmin,
+
+	// Block volumes are returned with their CHAP password
+	"BlockList":           ReadOnly,
+	"BlockSnapshotList":   ReadOnly,
+	"BlockSnapshotGet":    ReadOnly,
+	"BlockGet":            VolumeAdmin,
+	"BlockCreate":         VolumeAdmin,
+	"BlockDelete":         VolumeAdmin,
+	"BlockResize":         VolumeAdmin,
+	"BlockSnapshotCreate": VolumeAdmin,
+	"BlockSnapshotDelete": VolumeAdmin,
+	"BlockClone":          VolumeAdmin,
+
+	"DevicesList":   ReadOnly,
+	"DevicesInPeer": ReadOnly,
+	"DeviceInfo":    ReadOnly,
+	"DeviceAdd":     ClusterAdmin,
+	"DeviceEdit":    ClusterAdmin,
+	"DeviceDelete":  ClusterAdmin,
+	"DeviceResize":  ClusterAdmin,
+
+	"EventsList":          ReadOnly,
+	"EventsWebhookList":   ReadOnly,
+	"EventsWebhookAdd":    ClusterAdmin,
+	"EventsWebhookDelete": ClusterAdmin,
+	"EventsWebhookTest":   ClusterAdmin,
+	"TraceStatus":         ReadOnly,
+	"TraceEnable":         ClusterAdmin,
+	"TraceUpdate":         ClusterAdmin,
+	"TraceDisable":        ClusterAdmin,
+}
+
+// IsClassifiedRoute returns true if the roles permitted to call the route
+// with the given name are known
+func IsClassifiedRoute(routeName string) bool {
+	_, ok := routeRoles[routeName]
+	return ok
+}
+
+// IsValid returns true if r is one of the supported roles
+func (r Role) IsValid() bool {
+	switch r {
+	case ReadOnly, VolumeAdmin, ClusterAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// Allows returns true if the role permits calling the route with the given
+// name using the given HTTP method
+func (r Role) Allows(routeName string, method string) bool {
+	required, ok := routeRoles[routeName]
+	if !ok {
+		required = ClusterAdmin
+	}
+
+	switch r {
+	case ClusterAdmin:
+		return true
+	case VolumeAdmin:
+		return required == VolumeAdmin || required == ReadOnly
+	case ReadOnly:
+		if required != ReadOnly {
+			return false
+		}
+		return method == http.MethodGet || method == http.MethodHead
+	default:
+		return false
+	}
+}
+
+// IsClusterAdmin returns true if the user with the given name is a cluster
+// admin
+func IsClusterAdmin(name string) bool {
+	if name == InternalUser {
+		return true
+	}
+
+	u, err := GetUserF(name)
+	if err != nil {
+		return false
+	}
+	return u.Role == ClusterAdmin
+}
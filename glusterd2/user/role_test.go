@@ -0,0 +1,64 @@
+package user
+
+import (
+	"net/http"
+	"testing"
+
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleIsValid(t *testing.T) {
+	assert.True(t, ReadOnly.IsValid())
+	assert.True(t, VolumeAdmin.IsValid())
+	assert.True(t, ClusterAdmin.IsValid())
+	assert.False(t, Role("admin").IsValid())
+	assert.False(t, Role("").IsValid())
+}
+
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, ReadOnly.Allows("VolumeList", http.MethodGet))
+	assert.False(t, ReadOnly.Allows("VolumeCreate", http.MethodPost))
+	assert.False(t, ReadOnly.Allows("UserList", http.MethodGet))
+	assert.False(t, ReadOnly.Allows("TransactionList", http.MethodGet))
+	// Block volumes are returned with their CHAP password
+	assert.False(t, ReadOnly.Allows("BlockGet", http.MethodGet))
+
+	assert.True(t, VolumeAdmin.Allows("VolumeCreate", http.MethodPost))
+	assert.True(t, VolumeAdmin.Allows("GetPeers", http.MethodGet))
+	assert.False(t, VolumeAdmin.Allows("AddPeer", http.MethodPost))
+	assert.False(t, VolumeAdmin.Allows("UserCreate", http.MethodPost))
+	assert.False(t, VolumeAdmin.Allows("TransactionGet", http.MethodGet))
+	assert.False(t, VolumeAdmin.Allows("SnapshotConfigSet", http.MethodPost))
+	assert.True(t, VolumeAdmin.Allows("BlockGet", http.MethodGet))
+
+	// Routes which are not classified are only permitted to cluster admins
+	assert.False(t, VolumeAdmin.Allows("NewClusterRoute", http.MethodPost))
+	assert.False(t, ReadOnly.Allows("NewClusterRoute", http.MethodGet))
+	assert.True(t, ClusterAdmin.Allows("NewClusterRoute", http.MethodPost))
+
+	assert.True(t, ClusterAdmin.Allows("AddPeer", http.MethodPost))
+	assert.True(t, ClusterAdmin.Allows("UserDelete", http.MethodDelete))
+
+	assert.False(t, Role("admin").Allows("VolumeList", http.MethodGet))
+}
+
+func TestIsClusterAdmin(t *testing.T) {
+	GetUserF = func(name string) (*User, error) {
+		switch name {
+		case "admin":
+			return &User{Name: name, Role: ClusterAdmin}, nil
+		case "voladmin":
+			return &User{Name: name, Role: VolumeAdmin}, nil
+		default:
+			return nil, gderrors.ErrUserNotFound
+		}
+	}
+	defer func() { GetUserF = GetUser }()
+
+	assert.True(t, IsClusterAdmin(InternalUser))
+	assert.True(t, IsClusterAdmin("admin"))
+	assert.False(t, IsClusterAdmin("voladmin"))
+	assert.False(t, IsClusterAdmin("nobody"))
+}
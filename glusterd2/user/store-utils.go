@@ -0,0 +1,97 @@
+package user
+
+// This file contains helper functions facilitate easier interaction with the
+// user information stored in the store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gluster/glusterd2/glusterd2/store"
+	"github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/coreos/etcd/clientv3"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	userPrefix string = "users/"
+)
+
+var (
+	// GetUserF returns specified user from the store
+	GetUserF = GetUser
+)
+
+// AddOrUpdateUser adds/updates given user in the store
+func AddOrUpdateUser(u *User) error {
+	json, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+
+	if _, err := store.Put(context.TODO(), userPrefix+u.Name, string(json)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetUser returns specified user from the store
+func GetUser(name string) (*User, error) {
+	resp, err := store.Get(context.TODO(), userPrefix+name)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Count != 1 {
+		return nil, errors.ErrUserNotFound
+	}
+
+	var u User
+	if err := json.Unmarshal(resp.Kvs[0].Value, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// GetUsers returns all users in the store
+func GetUsers() ([]*User, error) {
+	resp, err := store.Get(context.TODO(), userPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*User
+	for _, kv := range resp.Kvs {
+		var u User
+		if err := json.Unmarshal(kv.Value, &u); err != nil {
+			log.WithError(err).WithField("user", string(kv.Key)).Error("Failed to unmarshal user")
+			continue
+		}
+		users = append(users, &u)
+	}
+
+	return users, nil
+}
+
+// DeleteUser deletes given user from the store
+func DeleteUser(name string) error {
+	resp, err := store.Delete(context.TODO(), userPrefix+name)
+	if err != nil {
+		return err
+	}
+	if resp.Deleted != 1 {
+		return errors.ErrUserNotFound
+	}
+	return nil
+}
+
+// Exists checks whether a given user exists or not
+func Exists(name string) bool {
+	resp, err := store.Get(context.TODO(), userPrefix+name)
+	if err != nil {
+		return false
+	}
+	return resp.Count == 1
+}
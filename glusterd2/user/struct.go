@@ -0,0 +1,18 @@
+// Package user implements the users of the GlusterD REST API. Users
+// authenticate requests with JWT tokens signed using their own shared secret,
+// or with a client certificate whose subject common name is the name of the
+// user. They are permitted to call the APIs allowed by their role.
+package user
+
+// InternalUser is the user glustercli authenticates as using the local auth
+// token. It is a cluster admin and is not kept in the store.
+const InternalUser = "glustercli"
+
+// User represents a REST API user
+type User struct {
+	Name string `json:"name"`
+	// Secret is empty for users which can only authenticate with a
+	// client certificate
+	Secret string `json:"secret"`
+	Role   Role   `json:"role"`
+}
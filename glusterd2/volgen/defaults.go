@@ -18,7 +18,8 @@ func LoadDefaultTemplates() error {
 	// If directory not exists, create the directory and then generate default templates
 	_, err := os.Stat(defaultTemplatesPath)
 	if os.IsNotExist(err) {
-		content, err := json.MarshalIndent(namespaces[DefaultTemplateNamespace], "", "    ")
+		tmpls, _ := GetTemplates(DefaultTemplateNamespace)
+		content, err := json.MarshalIndent(tmpls, "", "    ")
 		if err != nil {
 			return err
 		}
@@ -38,7 +39,7 @@ func LoadDefaultTemplates() error {
 		if err != nil {
 			return err
 		}
-		namespaces[DefaultTemplateNamespace] = tmpls
+		SetTemplates(DefaultTemplateNamespace, tmpls)
 		return nil
 	}
 	return err
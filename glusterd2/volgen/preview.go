@@ -0,0 +1,73 @@
+package volgen
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/utils"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Volfiles generates the client volfile and the volfiles of all the bricks
+// of the volume without saving them. The volfiles are keyed by volfile ID.
+func Volfiles(volinfo *volume.Volinfo) (map[string]string, error) {
+	volfiles := make(map[string]string)
+
+	tmpl, err := GetTemplateFromVolinfo(volinfo, utils.ClientVolfile)
+	if err != nil {
+		return nil, err
+	}
+	volfile, err := VolumeLevelVolfile(tmpl, volinfo)
+	if err != nil {
+		return nil, err
+	}
+	volfiles[volinfo.VolfileID] = volfile
+
+	tmpl, err = GetTemplateFromVolinfo(volinfo, utils.BrickVolfile)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range volinfo.GetBricks() {
+		volfile, err := BrickLevelVolfile(tmpl, volinfo, b.PeerID.String(), b.Path)
+		if err != nil {
+			return nil, err
+		}
+		volfiles[brick.GetPeerVolfileID(volinfo.Name, b.PeerID.String(), b.Path)] = volfile
+	}
+
+	return volfiles, nil
+}
+
+// DiffVolfiles returns the unified diffs of the volfiles which differ
+// between the old and the new volfiles, keyed by volfile ID. Volfiles
+// missing in old or new are diffed against empty volfiles.
+func DiffVolfiles(old, new map[string]string) (map[string]string, error) {
+	diffs := make(map[string]string)
+
+	ids := make(map[string]bool)
+	for id := range old {
+		ids[id] = true
+	}
+	for id := range new {
+		ids[id] = true
+	}
+
+	for id := range ids {
+		if old[id] == new[id] {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(old[id]),
+			B:        difflib.SplitLines(new[id]),
+			FromFile: "a/" + id + ".vol",
+			ToFile:   "b/" + id + ".vol",
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+		diffs[id] = diff
+	}
+
+	return diffs, nil
+}
@@ -0,0 +1,89 @@
+package volgen
+
+// This file contains helper functions to save the volfile templates in the
+// store, so that all the peers generate volfiles from the same templates
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gluster/glusterd2/glusterd2/store"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/coreos/etcd/clientv3"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	templatesPrefix string = "templates/"
+)
+
+// AddOrUpdateStoredTemplates saves the templates of the given namespace in
+// the store
+func AddOrUpdateStoredTemplates(namespace string, tmpls Templates) error {
+	data, err := json.Marshal(tmpls)
+	if err != nil {
+		return err
+	}
+
+	if _, err := store.Put(context.TODO(), templatesPrefix+namespace, string(data)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetStoredTemplates returns the templates of the given namespace saved in
+// the store
+func GetStoredTemplates(namespace string) (Templates, error) {
+	resp, err := store.Get(context.TODO(), templatesPrefix+namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Count != 1 {
+		return nil, gderrors.ErrTemplateNotFound
+	}
+
+	var tmpls Templates
+	if err := json.Unmarshal(resp.Kvs[0].Value, &tmpls); err != nil {
+		return nil, err
+	}
+	return tmpls, nil
+}
+
+// DeleteStoredTemplates deletes the templates of the given namespace from
+// the store
+func DeleteStoredTemplates(namespace string) error {
+	resp, err := store.Delete(context.TODO(), templatesPrefix+namespace)
+	if err != nil {
+		return err
+	}
+	if resp.Deleted != 1 {
+		return gderrors.ErrTemplateNotFound
+	}
+	return nil
+}
+
+// LoadStoredTemplates loads all the templates saved in the store. They take
+// precedence over the templates loaded from the local templates directory.
+func LoadStoredTemplates() error {
+	resp, err := store.Get(context.TODO(), templatesPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range resp.Kvs {
+		namespace := strings.TrimPrefix(string(kv.Key), templatesPrefix)
+
+		var tmpls Templates
+		if err := json.Unmarshal(kv.Value, &tmpls); err != nil {
+			log.WithError(err).WithField("namespace", namespace).Error("Failed to unmarshal volfile templates")
+			continue
+		}
+		SetTemplates(namespace, tmpls)
+	}
+
+	return nil
+}
@@ -1,5 +1,7 @@
 package volgen
 
+import "sync"
+
 // VolfileLevel is the level in which volfile need to be generated
 type VolfileLevel uint16
 
@@ -94,4 +96,7 @@ const (
 	DefaultTemplateNamespace = "default"
 )
 
-var namespaces = make(map[string]Templates)
+var (
+	namespacesMu sync.RWMutex
+	namespaces   = make(map[string]Templates)
+)
@@ -1,6 +1,10 @@
 package volgen
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+
 	"github.com/gluster/glusterd2/glusterd2/brick"
 	"github.com/gluster/glusterd2/glusterd2/volume"
 	gderrors "github.com/gluster/glusterd2/pkg/errors"
@@ -32,6 +36,9 @@ func (tmpl *Template) addEnabledXlator(volinfo *volume.Volinfo, xlist *[]Xlator,
 
 // GetTemplate gets template for the given namespace
 func GetTemplate(namespace string, name string) (*Template, error) {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+
 	tmpls, exists := namespaces[namespace]
 	if !exists {
 		return nil, gderrors.ErrInvalidVolFileTmplNamespace
@@ -45,15 +52,99 @@ func GetTemplate(namespace string, name string) (*Template, error) {
 	return &tmpl, nil
 }
 
-// GetTemplateFromVolinfo gets template from the namespace set in volinfo
-// If template namespace is not set in volinfo, gets the template
-// from default namespace
-func GetTemplateFromVolinfo(volinfo *volume.Volinfo, name string) (*Template, error) {
+// TemplateNamespace returns the template namespace set in volinfo, or the
+// default namespace if it is not set
+func TemplateNamespace(volinfo *volume.Volinfo) string {
 	tmplNamespace, exists := volinfo.Metadata[TemplateMetadataKey]
 	if !exists {
 		tmplNamespace = DefaultTemplateNamespace
 	}
-	return GetTemplate(tmplNamespace, name)
+	return tmplNamespace
+}
+
+// GetTemplateFromVolinfo gets template from the namespace set in volinfo
+// If template namespace is not set in volinfo, gets the template
+// from default namespace. Templates missing in the namespace set in
+// volinfo are also taken from the default namespace.
+func GetTemplateFromVolinfo(volinfo *volume.Volinfo, name string) (*Template, error) {
+	tmplNamespace := TemplateNamespace(volinfo)
+	tmpl, err := GetTemplate(tmplNamespace, name)
+	if err == gderrors.ErrInvalidVolFileTmplName && tmplNamespace != DefaultTemplateNamespace {
+		return GetTemplate(DefaultTemplateNamespace, name)
+	}
+	return tmpl, err
+}
+
+// GetTemplates returns the templates of the given namespace
+func GetTemplates(namespace string) (Templates, bool) {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+
+	tmpls, exists := namespaces[namespace]
+	return tmpls, exists
+}
+
+// TemplateLockID returns the ID of the cluster wide lock taken when the
+// templates of the namespace are changed or used to create a volume
+func TemplateLockID(namespace string) string {
+	return "templates/" + namespace
+}
+
+// TemplateNamespaces returns the names of all the template namespaces
+func TemplateNamespaces() []string {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetTemplates sets the templates of the given namespace, which are used for
+// all the volfiles generated afterwards
+func SetTemplates(namespace string, tmpls Templates) {
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+
+	namespaces[namespace] = tmpls
+}
+
+// RemoveTemplates removes the templates of the given namespace
+func RemoveTemplates(namespace string) {
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+
+	delete(namespaces, namespace)
+}
+
+// Validate checks that the templates can be used to generate volfiles. The
+// types of the xlators used in the templates have to be found in the xlator
+// registry, unless they are template variables resolved during volfile
+// generation.
+func (tmpls Templates) Validate() error {
+	for name, tmpl := range tmpls {
+		if name == "" {
+			return errors.New("volfile template name is required")
+		}
+		if tmpl.Name != name {
+			return fmt.Errorf("volfile template %s has a different name: %s", name, tmpl.Name)
+		}
+		if tmpl.Level.String() == "" {
+			return fmt.Errorf("volfile template %s has an invalid level", name)
+		}
+
+		for _, xlators := range [][]Xlator{tmpl.Xlators, tmpl.VolumeGraphXlators, tmpl.SubvolGraphXlators, tmpl.BrickGraphXlators} {
+			for _, xl := range xlators {
+				if err := xl.validate(); err != nil {
+					return fmt.Errorf("volfile template %s: %s", name, err)
+				}
+			}
+		}
+	}
+	return nil
 }
 
 // EnabledXlators returns list of xlators which are enabled in Volinfo or in template itself
@@ -1,6 +1,7 @@
 package volgen
 
 import (
+	"errors"
 	"path"
 	"strings"
 
@@ -62,6 +63,24 @@ func (xl *Xlator) suffix() string {
 	return path.Base(xl.Type)
 }
 
+// validate checks that the type of the xlator is known to the xlator
+// registry
+func (xl *Xlator) validate() error {
+	xltype := xl.Type
+	if xltype == "" {
+		xltype = xl.TypeTmpl
+	}
+	if xltype == "" {
+		return errors.New("xlator type is required")
+	}
+	// Types with template variables are only known during volfile generation
+	if isVarStr(xltype) {
+		return nil
+	}
+	_, err := xlator.Find(xltype)
+	return err
+}
+
 func (xl *Xlator) getOptions(tmplName string, volinfo *volume.Volinfo) (map[string]string, error) {
 	var optKey, optVal string
 
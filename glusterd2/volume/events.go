@@ -19,6 +19,12 @@ const (
 	EventVolumeStopped = "volume.stopped"
 	// EventVolumeDeleted represents Volume Delete event
 	EventVolumeDeleted = "volume.deleted"
+	// EventVolumeReshaped represents change of replica count of a Volume
+	EventVolumeReshaped = "volume.reshaped"
+	// EventVolumeRenamed represents Volume Rename event
+	EventVolumeRenamed = "volume.renamed"
+	// EventBrickRemoved represents bricks being removed on Volume Shrink
+	EventBrickRemoved = "brick.removed"
 )
 
 // NewEvent adds required details to event based on Volume info
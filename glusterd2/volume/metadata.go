@@ -9,6 +9,10 @@ const (
 	BlockHosting = "block-hosting"
 	// BlockPrefix is the prefix of the volume metadata which will contain BlockPrefix + blockname as the key and size of the block as value.
 	BlockPrefix = "block-vol:"
+	// BlockSnapPrefix is the prefix of the volume metadata which will contain BlockSnapPrefix + blockname/snapname as the key and size of the snapshot as value.
+	BlockSnapPrefix = "block-snap:"
+	// BlockClonePrefix is the prefix of the volume metadata which will contain BlockClonePrefix + clonename as the key and the block or blockname/snapname it was cloned from as value.
+	BlockClonePrefix = "block-clone:"
 	// BlockHostMarkedForPrune is a metadata that is set to indicate that the bhv is being deleted as it has no block volumes present
 	BlockHostMarkedForPrune = "_block-hosting-marked-for-prune"
 )
@@ -0,0 +1,84 @@
+package volume
+
+import (
+	"context"
+
+	"github.com/gluster/glusterd2/glusterd2/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	volumeStartedDesc = prometheus.NewDesc(
+		"glusterd2_volume_started",
+		"Whether the volume is started.",
+		[]string{"volume"}, nil,
+	)
+	brickOnlineDesc = prometheus.NewDesc(
+		"glusterd2_brick_online",
+		"Whether the process of a brick on this node is running.",
+		[]string{"volume", "brick"}, nil,
+	)
+	brickCapacityDesc = prometheus.NewDesc(
+		"glusterd2_brick_capacity_bytes",
+		"Size of the filesystem of a brick on this node.",
+		[]string{"volume", "brick"}, nil,
+	)
+	brickFreeDesc = prometheus.NewDesc(
+		"glusterd2_brick_free_bytes",
+		"Free space on the filesystem of a brick on this node.",
+		[]string{"volume", "brick"}, nil,
+	)
+)
+
+// collector gathers the state of the volumes and of the bricks on this
+// node when the metrics are scraped
+type collector struct{}
+
+func init() {
+	metrics.Register(collector{})
+}
+
+// Describe implements prometheus.Collector
+func (collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- volumeStartedDesc
+	ch <- brickOnlineDesc
+	ch <- brickCapacityDesc
+	ch <- brickFreeDesc
+}
+
+// Collect implements prometheus.Collector
+func (collector) Collect(ch chan<- prometheus.Metric) {
+	volumes, err := GetVolumes(context.TODO())
+	if err != nil {
+		log.WithError(err).Error("failed to get volumes for metrics")
+		return
+	}
+
+	for _, v := range volumes {
+		ch <- prometheus.MustNewConstMetric(volumeStartedDesc, prometheus.GaugeValue,
+			boolToFloat(v.State == VolStarted), v.Name)
+
+		for _, b := range v.GetLocalBricks() {
+			s, err := BrickStatus(b, nil)
+			if err != nil {
+				log.WithError(err).WithField("brick", b.String()).Error("failed to get brick status for metrics")
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(brickOnlineDesc, prometheus.GaugeValue,
+				boolToFloat(s.Online), v.Name, b.Path)
+			ch <- prometheus.MustNewConstMetric(brickCapacityDesc, prometheus.GaugeValue,
+				float64(s.Size.Capacity), v.Name, b.Path)
+			ch <- prometheus.MustNewConstMetric(brickFreeDesc, prometheus.GaugeValue,
+				float64(s.Size.Free), v.Name, b.Path)
+		}
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -247,6 +247,17 @@ func (v *Volinfo) IsAutoProvisioned() bool {
 	return (v.GetProvisionType().IsAutoProvisioned())
 }
 
+// HostsBlockVolumes returns true if block volumes, or snapshots or clones of
+// block volumes, are stored on the volume
+func (v *Volinfo) HostsBlockVolumes() bool {
+	for k := range v.Metadata {
+		if strings.HasPrefix(k, BlockPrefix) || strings.HasPrefix(k, BlockSnapPrefix) || strings.HasPrefix(k, BlockClonePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 //GetProvisionType will return true the type of provision state
 func (v *Volinfo) GetProvisionType() brick.ProvisionType {
 
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"time"
+)
+
+// AuditEntry represents a record of a REST request which modified the cluster
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user,omitempty"`
+	Route      string    `json:"route"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Volume     string    `json:"volume,omitempty"`
+	Peer       string    `json:"peer,omitempty"`
+	Snapshot   string    `json:"snapshot,omitempty"`
+	RequestID  string    `json:"request-id"`
+	Status     int       `json:"status"`
+	DurationMs int64     `json:"duration-ms"`
+}
+
+// AuditListResp is the response sent for an audit log request
+/*
+The client can request to filter audit entries using query parameters.
+Entries are listed oldest first. At most limit entries, 100 by default and 1000
+at most, are returned after skipping offset entries.
+Example:
+	- GET http://localhost:24007/v1/audit?user={username}
+	- GET http://localhost:24007/v1/audit?since={RFC3339 time}&until={RFC3339 time}
+	- GET http://localhost:24007/v1/audit?offset={offset}&limit={limit}
+*/
+type AuditListResp []AuditEntry
@@ -41,3 +41,20 @@ func (req *VolExpandReq) Nodes() ([]uuid.UUID, error) {
 	}
 	return nodes, nil
 }
+
+// Nodes extracts list of Peer IDs from Volume Reshape request
+func (req *VolReshapeReq) Nodes() ([]uuid.UUID, error) {
+	var nodesMap = make(map[string]bool)
+	var nodes []uuid.UUID
+	for _, brick := range req.Bricks {
+		if _, ok := nodesMap[brick.PeerID]; !ok {
+			nodesMap[brick.PeerID] = true
+			u := uuid.Parse(brick.PeerID)
+			if u == nil {
+				return nil, fmt.Errorf("failed to parse peer ID: %s", brick.PeerID)
+			}
+			nodes = append(nodes, u)
+		}
+	}
+	return nodes, nil
+}
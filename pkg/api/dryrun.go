@@ -0,0 +1,34 @@
+package api
+
+// PlannedBrick is a brick which a volume operation would provision or use.
+// For a volume expand which resizes the existing bricks, the sizes are the
+// growth of the brick.
+type PlannedBrick struct {
+	PeerID         string `json:"peer-id"`
+	Path           string `json:"path"`
+	Type           string `json:"type,omitempty"`
+	Device         string `json:"device,omitempty"`
+	VgName         string `json:"vg-name,omitempty"`
+	TpName         string `json:"thinpool-name,omitempty"`
+	LvName         string `json:"logical-volume,omitempty"`
+	Size           uint64 `json:"size,omitempty"`
+	TpSize         uint64 `json:"thinpool-size,omitempty"`
+	TpMetadataSize uint64 `json:"metadata-size,omitempty"`
+}
+
+// VolfileDiff is the change a volume operation would make to a volfile, as
+// a unified diff
+type VolfileDiff struct {
+	VolfileID string `json:"volfile-id"`
+	Diff      string `json:"diff"`
+}
+
+// VolumeDryRunResp is the response sent for volume create, expand, replace
+// brick, option set and option reset requests made with the dry-run=true
+// query parameter.
+// It has the bricks the request would provision, and the changes it would
+// make to the volfiles of the volume.
+type VolumeDryRunResp struct {
+	Bricks   []PlannedBrick `json:"bricks"`
+	Volfiles []VolfileDiff  `json:"volfiles"`
+}
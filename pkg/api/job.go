@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pborman/uuid"
+)
+
+// JobState is the state of an asynchronous job
+type JobState string
+
+const (
+	// JobRunning is the state of a job whose request is being processed
+	JobRunning JobState = "running"
+	// JobSucceeded is the state of a job whose request succeeded
+	JobSucceeded JobState = "succeeded"
+	// JobFailed is the state of a job whose request failed
+	JobFailed JobState = "failed"
+	// JobCancelled is the state of a job which was cancelled
+	JobCancelled JobState = "cancelled"
+)
+
+// JobStep is the progress of a transaction step run for a job
+type JobStep struct {
+	Name  string   `json:"name"`
+	State JobState `json:"state"`
+	Error string   `json:"error,omitempty"`
+}
+
+// Job represents a request processed asynchronously. A request is processed
+// asynchronously when it is sent with the "Prefer: respond-async" header.
+// Once the job completes, Status and Response hold the HTTP status code and
+// the body of the response the request would have got.
+type Job struct {
+	ID        uuid.UUID       `json:"id"`
+	Route     string          `json:"route"`
+	Method    string          `json:"method"`
+	Path      string          `json:"path"`
+	User      string          `json:"user,omitempty"`
+	PeerID    uuid.UUID       `json:"peer-id"`
+	RequestID string          `json:"request-id"`
+	State     JobState        `json:"state"`
+	Steps     []JobStep       `json:"steps,omitempty"`
+	Status    int             `json:"status,omitempty"`
+	Response  json.RawMessage `json:"response,omitempty"`
+	CreatedAt time.Time       `json:"created-at"`
+	UpdatedAt time.Time       `json:"updated-at"`
+}
+
+// JobGetResp is the response sent for a job get request, and for requests
+// which are processed asynchronously
+type JobGetResp Job
+
+// JobListResp is the response sent for a job list request
+type JobListResp []Job
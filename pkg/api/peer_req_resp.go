@@ -37,6 +37,13 @@ type PeerEditResp Peer
 // PeerGetResp is the response sent for a peer get request
 type PeerGetResp Peer
 
+// PeerTLSReloadResp is the response sent for a peer TLS reload request
+type PeerTLSReloadResp struct {
+	// RestartRequired lists the peers which have to be restarted for the
+	// embedded store to use a new CA certificate
+	RestartRequired []uuid.UUID `json:"restart-required"`
+}
+
 // PeerListResp is the response sent for a peer list request
 /*
 The client can request to filter peer listing based on metadata key/value using query parameters.
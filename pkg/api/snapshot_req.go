@@ -18,3 +18,50 @@ type SnapActivateReq struct {
 type SnapCloneReq struct {
 	CloneName string `json:"clonename"`
 }
+
+// SnapConfigReq represents a request to set snapshot configuration. When
+// VolName is empty the configuration applies to the whole cluster.
+type SnapConfigReq struct {
+	VolName string            `json:"volname,omitempty"`
+	Options map[string]string `json:"options"`
+}
+
+// SnapConfigResetReq represents a request to reset snapshot configuration
+// to defaults. All options are reset if Options is empty.
+type SnapConfigResetReq struct {
+	VolName string   `json:"volname,omitempty"`
+	Options []string `json:"options,omitempty"`
+}
+
+// SnapRetention describes which snapshots created by a schedule are kept.
+// Snapshots which are not kept by any of the rules are deleted. For the
+// hourly, daily, weekly and monthly rules the newest snapshot of each of the
+// given number of most recent periods is kept. Nothing is deleted if no rule
+// is set.
+type SnapRetention struct {
+	Last    int `json:"last,omitempty"`
+	Hourly  int `json:"hourly,omitempty"`
+	Daily   int `json:"daily,omitempty"`
+	Weekly  int `json:"weekly,omitempty"`
+	Monthly int `json:"monthly,omitempty"`
+}
+
+// SnapScheduleReq represents a request to create a snapshot schedule.
+// Schedule is a cron expression in the UTC timezone.
+type SnapScheduleReq struct {
+	Name        string        `json:"name"`
+	VolName     string        `json:"volname"`
+	Schedule    string        `json:"schedule"`
+	Retention   SnapRetention `json:"retention,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Disabled    bool          `json:"disabled,omitempty"`
+}
+
+// SnapScheduleEditReq represents a request to modify a snapshot schedule.
+// Fields which are not set are left unchanged.
+type SnapScheduleEditReq struct {
+	Schedule    string         `json:"schedule,omitempty"`
+	Retention   *SnapRetention `json:"retention,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Disabled    *bool          `json:"disabled,omitempty"`
+}
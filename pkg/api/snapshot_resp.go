@@ -13,6 +13,7 @@ type SnapInfo struct {
 	ParentVolName string     `json:"parentname"`
 	Description   string     `json:"description"`
 	CreatedAt     time.Time  `json:"created-at"`
+	Schedule      string     `json:"schedule,omitempty"`
 }
 
 //SnapList contains snapshots information of a volume.
@@ -61,3 +62,43 @@ type SnapshotDeactivateResp SnapInfo
 // SnapshotCloneResp is the response sent for a snapshot clone request.
 // Snapshot clone will create a regular volume
 type SnapshotCloneResp VolumeInfo
+
+// SnapConfig contains the snapshot configuration in effect for the cluster
+// or for a volume.
+type SnapConfig struct {
+	HardLimit        uint64 `json:"snap-max-hard-limit"`
+	SoftLimit        uint64 `json:"snap-max-soft-limit"`
+	AutoDelete       bool   `json:"auto-delete"`
+	ActivateOnCreate bool   `json:"activate-on-create"`
+}
+
+// SnapConfigResp is the response sent for a snapshot config get, set or
+// reset request.
+type SnapConfigResp struct {
+	VolName string `json:"volname,omitempty"`
+	SnapConfig
+}
+
+// SnapSchedule contains information about a snapshot schedule and its most
+// recent run
+type SnapSchedule struct {
+	Name         string        `json:"name"`
+	VolName      string        `json:"volname"`
+	Schedule     string        `json:"schedule"`
+	Retention    SnapRetention `json:"retention"`
+	Description  string        `json:"description,omitempty"`
+	Disabled     bool          `json:"disabled"`
+	CreatedAt    time.Time     `json:"created-at"`
+	LastRun      time.Time     `json:"last-run,omitempty"`
+	LastSnapshot string        `json:"last-snapshot,omitempty"`
+	LastError    string        `json:"last-error,omitempty"`
+	NextRun      time.Time     `json:"next-run,omitempty"`
+}
+
+// SnapScheduleResp is the response sent for a snapshot schedule create, get
+// or edit request
+type SnapScheduleResp SnapSchedule
+
+// SnapScheduleListResp is the response sent for a snapshot schedule list
+// request
+type SnapScheduleListResp []SnapSchedule
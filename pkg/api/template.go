@@ -0,0 +1,57 @@
+package api
+
+// TemplateXlator represents an xlator in a volfile template
+type TemplateXlator struct {
+	NameTmpl        string            `json:"name-tmpl,omitempty"`
+	Type            string            `json:"type,omitempty"`
+	TypeTmpl        string            `json:"type-tmpl,omitempty"`
+	OnlyLocalBricks bool              `json:"only-local-bricks,omitempty"`
+	Disabled        bool              `json:"disabled,omitempty"`
+	EnableByOption  bool              `json:"enable-by-option,omitempty"`
+	Options         map[string]string `json:"options,omitempty"`
+	IgnoreOptions   []string          `json:"ignore-options,omitempty"`
+}
+
+// VolfileTemplate represents the xlator graph of a volfile. Level is one of
+// brick, volume or cluster.
+type VolfileTemplate struct {
+	Level              string           `json:"level"`
+	Xlators            []TemplateXlator `json:"xlators"`
+	VolumeGraphXlators []TemplateXlator `json:"volume-graph-xlators,omitempty"`
+	SubvolGraphXlators []TemplateXlator `json:"subvol-graph-xlators,omitempty"`
+	BrickGraphXlators  []TemplateXlator `json:"brick-graph-xlators,omitempty"`
+}
+
+// Template represents a named set of volfile templates, keyed by volfile
+// template name (for example "brick" or "client"). Volfiles missing in a
+// template are generated from the default template. Volumes lists the
+// volumes using the template.
+type Template struct {
+	Name     string                     `json:"name"`
+	Volfiles map[string]VolfileTemplate `json:"volfiles"`
+	Volumes  []string                   `json:"volumes,omitempty"`
+}
+
+// TemplateCreateReq represents an incoming request to create a template
+type TemplateCreateReq struct {
+	Name     string                     `json:"name"`
+	Volfiles map[string]VolfileTemplate `json:"volfiles"`
+}
+
+// TemplateEditReq represents an incoming request to replace the volfile
+// templates of a template
+type TemplateEditReq struct {
+	Volfiles map[string]VolfileTemplate `json:"volfiles"`
+}
+
+// TemplateCreateResp is the success response sent to a TemplateCreateReq request
+type TemplateCreateResp Template
+
+// TemplateEditResp is the success response sent to a TemplateEditReq request
+type TemplateEditResp Template
+
+// TemplateGetResp is the response sent for a template get request
+type TemplateGetResp Template
+
+// TemplateListResp is the response sent for a template list request
+type TemplateListResp []TemplateGetResp
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"time"
+
+	"github.com/pborman/uuid"
+)
+
+// TxnStep is a step of a transaction
+type TxnStep struct {
+	DoFunc   string      `json:"do-func"`
+	UndoFunc string      `json:"undo-func,omitempty"`
+	Nodes    []uuid.UUID `json:"nodes"`
+	Skip     bool        `json:"skip,omitempty"`
+	Sync     bool        `json:"sync,omitempty"`
+}
+
+// TxnNodeStatus is the status of a transaction on a node
+type TxnNodeStatus struct {
+	PeerID uuid.UUID `json:"peer-id"`
+	State  string    `json:"state"`
+	Reason string    `json:"reason,omitempty"`
+	// LastExecutedStep is the index of the last step run on the node.
+	// It is -1 when no step was run, or when the steps were rolled back.
+	LastExecutedStep int  `json:"last-executed-step"`
+	RolledBack       bool `json:"rolled-back"`
+}
+
+// Rollback states of a failed transaction
+const (
+	TxnRollbackPending   = "pending"
+	TxnRollbackCompleted = "completed"
+)
+
+// Txn represents a transaction which is pending, or which is in the
+// transaction history
+type Txn struct {
+	ID              uuid.UUID       `json:"id"`
+	ReqID           uuid.UUID       `json:"req-id"`
+	State           string          `json:"state"`
+	Error           string          `json:"error,omitempty"`
+	Steps           []TxnStep       `json:"steps"`
+	Nodes           []TxnNodeStatus `json:"nodes"`
+	DisableRollback bool            `json:"disable-rollback,omitempty"`
+	Rollback        string          `json:"rollback,omitempty"`
+	StartTime       time.Time       `json:"start-time"`
+	EndTime         time.Time       `json:"end-time,omitempty"`
+}
+
+// TxnGetResp is the response sent for a transaction get request
+type TxnGetResp Txn
+
+// TxnListResp is the response sent for a transaction list request
+type TxnListResp []Txn
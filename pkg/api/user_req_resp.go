@@ -0,0 +1,42 @@
+package api
+
+// User represents a REST API user. The secret of a user is never returned
+// once the user has been created.
+type User struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// UserCreateReq represents an incoming request to create a REST API user. A
+// random secret is generated if Secret is not set, unless the user can only
+// authenticate with a client certificate.
+type UserCreateReq struct {
+	Name     string `json:"name"`
+	Secret   string `json:"secret,omitempty"`
+	Role     string `json:"role"`
+	CertOnly bool   `json:"cert-only,omitempty"`
+}
+
+// UserEditReq represents an incoming request to change the role or the
+// secret of a REST API user. Fields which are not set are left unchanged.
+type UserEditReq struct {
+	Secret string `json:"secret,omitempty"`
+	Role   string `json:"role,omitempty"`
+}
+
+// UserCreateResp is the success response sent to a UserCreateReq request. It
+// carries the secret the user has to sign its tokens with.
+type UserCreateResp struct {
+	Name   string `json:"name"`
+	Secret string `json:"secret,omitempty"`
+	Role   string `json:"role"`
+}
+
+// UserEditResp is the success response sent to a UserEditReq request
+type UserEditResp User
+
+// UserGetResp is the response sent for a user get request
+type UserGetResp User
+
+// UserListResp is the response sent for a user list request
+type UserListResp []UserGetResp
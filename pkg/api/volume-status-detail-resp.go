@@ -0,0 +1,115 @@
+package api
+
+// BrickClient is a client connected to a brick
+type BrickClient struct {
+	Hostname     string `json:"hostname"`
+	BytesRead    uint64 `json:"bytes-read"`
+	BytesWritten uint64 `json:"bytes-written"`
+	OpVersion    uint32 `json:"op-version"`
+}
+
+// BrickInode is an inode in an inode table of a brick
+type BrickInode struct {
+	GFID    string `json:"gfid"`
+	NLookup uint64 `json:"nlookup"`
+	Ref     uint32 `json:"ref"`
+	IAType  int    `json:"ia-type"`
+}
+
+// InodeTable is the inode table of a client connection to a brick
+type InodeTable struct {
+	ActiveSize uint32       `json:"active-size"`
+	LRUSize    uint32       `json:"lru-size"`
+	PurgeSize  uint32       `json:"purge-size"`
+	Active     []BrickInode `json:"active,omitempty"`
+	LRU        []BrickInode `json:"lru,omitempty"`
+	Purge      []BrickInode `json:"purge,omitempty"`
+}
+
+// BrickFd is an open fd of a brick
+type BrickFd struct {
+	Entry    int `json:"entry"`
+	Pid      int `json:"pid"`
+	RefCount int `json:"refcount"`
+	Flags    int `json:"flags"`
+}
+
+// FdTable is the fd table of a client connection to a brick
+type FdTable struct {
+	RefCount  int       `json:"refcount"`
+	MaxFds    uint32    `json:"max-fds"`
+	FirstFree int       `json:"first-free"`
+	OpenFds   int       `json:"open-fds"`
+	Fds       []BrickFd `json:"fds,omitempty"`
+}
+
+// MemPool holds the usage of a memory pool of a brick process
+type MemPool struct {
+	Name        string `json:"name"`
+	HotCount    int    `json:"hot-count"`
+	ColdCount   int    `json:"cold-count"`
+	PaddedSize  uint64 `json:"padded-size"`
+	AllocCount  uint64 `json:"alloc-count"`
+	MaxAlloc    int    `json:"max-alloc"`
+	PoolMisses  uint64 `json:"pool-misses"`
+	MaxStdAlloc int    `json:"max-std-alloc"`
+}
+
+// BrickMemInfo holds the memory usage of a brick process. Mallinfo holds the
+// fields of mallinfo(3).
+type BrickMemInfo struct {
+	Mallinfo map[string]uint64 `json:"mallinfo"`
+	MemPools []MemPool         `json:"mempools"`
+}
+
+// CallFrame is a frame of a pending call stack of a brick process
+type CallFrame struct {
+	RefCount   int    `json:"refcount"`
+	Translator string `json:"translator"`
+	Complete   bool   `json:"complete"`
+	Parent     string `json:"parent,omitempty"`
+	WindFrom   string `json:"wind-from,omitempty"`
+	WindTo     string `json:"wind-to,omitempty"`
+	UnwindFrom string `json:"unwind-from,omitempty"`
+	UnwindTo   string `json:"unwind-to,omitempty"`
+}
+
+// CallStack is a pending call stack of a brick process
+type CallStack struct {
+	UID    int         `json:"uid"`
+	GID    int         `json:"gid"`
+	PID    int         `json:"pid"`
+	Unique uint64      `json:"unique"`
+	Op     string      `json:"op"`
+	Type   int         `json:"type"`
+	Frames []CallFrame `json:"frames,omitempty"`
+}
+
+// BrickStatusDetail holds the status of a brick along with one of the
+// detailed views of the brick, as requested
+type BrickStatusDetail struct {
+	BrickStatus
+
+	// Inode counts and block size of the brick file system, for the
+	// detail view
+	BlockSize  uint64 `json:"block-size,omitempty"`
+	Inodes     uint64 `json:"inodes,omitempty"`
+	FreeInodes uint64 `json:"free-inodes,omitempty"`
+
+	Clients     []BrickClient `json:"clients,omitempty"`
+	InodeTables []InodeTable  `json:"inode-tables,omitempty"`
+	FdTables    []FdTable     `json:"fd-tables,omitempty"`
+	Memory      *BrickMemInfo `json:"memory,omitempty"`
+	CallPool    []CallStack   `json:"call-pool,omitempty"`
+
+	// Error is set if the view could not be fetched from the brick
+	Error string `json:"error,omitempty"`
+}
+
+// VolumeStatusDetailResp is the response sent for a detailed volume status
+// request
+type VolumeStatusDetailResp struct {
+	Volume string              `json:"volume"`
+	Option string              `json:"option"`
+	Bricks []BrickStatusDetail `json:"bricks"`
+}
@@ -0,0 +1,37 @@
+package api
+
+// TopEntry is a file or directory along with its value for a volume top
+// metric, ie. the number of calls, or the throughput in MBps for the
+// read-perf and write-perf metrics
+type TopEntry struct {
+	Brick    string  `json:"brick,omitempty"`
+	Filename string  `json:"filename"`
+	Value    float64 `json:"value"`
+	Time     string  `json:"time,omitempty"`
+}
+
+// BrickTopInfo holds the volume top metric of a brick
+type BrickTopInfo struct {
+	Brick   string     `json:"brick"`
+	Entries []TopEntry `json:"entries"`
+
+	// Open file descriptor counts, only for the open metric
+	CurrentOpen uint64 `json:"current-open,omitempty"`
+	MaxOpen     uint64 `json:"max-open,omitempty"`
+	MaxOpenTime string `json:"max-open-time,omitempty"`
+
+	// Result of the throughput test in MBps and seconds, only for the
+	// read-perf and write-perf metrics when a block size and count are
+	// given
+	Throughput float64 `json:"throughput,omitempty"`
+	Duration   float64 `json:"duration,omitempty"`
+}
+
+// VolumeTopResp is the response sent for a volume top request. Entries
+// holds the top entries across all the bricks.
+type VolumeTopResp struct {
+	Volume  string         `json:"volume"`
+	Metric  string         `json:"metric"`
+	Entries []TopEntry     `json:"entries"`
+	Bricks  []BrickTopInfo `json:"bricks"`
+}
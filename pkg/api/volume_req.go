@@ -64,6 +64,7 @@ type VolCreateReq struct {
 	SubvolZonesOverlap      bool              `json:"subvolume-zones-overlap,omitempty"`
 	SubvolType              string            `json:"subvolume-type,omitempty"`
 	ProvisionerType         string            `json:"provisioner"`
+	Template                string            `json:"template,omitempty"`
 	VolOptionReq
 }
 
@@ -104,6 +105,37 @@ type VolExpandReq struct {
 	DistributeCount int             `json:"distribute,omitempty"`
 }
 
+// VolShrinkReq represents a request to shrink the volume by removing bricks.
+// All the bricks of a replicate or disperse subvolume have to be removed
+// together. Data is migrated off the bricks unless Force is set, in which
+// case the bricks are removed right away.
+type VolShrinkReq struct {
+	Bricks []BrickReq `json:"bricks"`
+	Force  bool       `json:"force,omitempty"`
+}
+
+// VolShrinkCommitReq represents a request to commit a volume shrink. Force
+// commits even if data migration has not completed successfully.
+type VolShrinkCommitReq struct {
+	Force bool `json:"force,omitempty"`
+}
+
+// VolReshapeReq represents a request to change the replica count of a
+// volume. Exactly one brick is added to or removed from every subvolume, so
+// Bricks has one entry per subvolume, listed in subvolume order.
+type VolReshapeReq struct {
+	ReplicaCount int             `json:"replica"`
+	Arbiter      bool            `json:"arbiter,omitempty"`
+	Bricks       []BrickReq      `json:"bricks"`
+	Force        bool            `json:"force,omitempty"`
+	Flags        map[string]bool `json:"flags,omitempty"`
+}
+
+// VolRenameReq represents a request to rename a volume
+type VolRenameReq struct {
+	NewName string `json:"new-name"`
+}
+
 // VolumeOption represents an option that is part of a profile
 type VolumeOption struct {
 	Name    string `json:"name"`
@@ -162,6 +194,16 @@ type VolumeStartReq struct {
 	ForceStartBricks bool `json:"force-start-bricks,omitempty"`
 }
 
+// VolumeTopThroughputReq represents a request to run a throughput test on the
+// bricks of a volume before listing the top files for the read-perf or
+// write-perf metric. Count blocks of BlockSize bytes are read or written.
+type VolumeTopThroughputReq struct {
+	BlockSize uint64 `json:"block-size"`
+	Count     uint64 `json:"count"`
+	Brick     string `json:"brick,omitempty"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
 // MetadataSize returns the size of the volume metadata in VolCreateReq
 func (v *VolCreateReq) MetadataSize() int {
 	return mapSize(v.Metadata)
@@ -118,6 +118,40 @@ type ReplaceBrickResp VolumeInfo
 // VolumeExpandResp is the response sent for a volume expand request.
 type VolumeExpandResp VolumeInfo
 
+// VolumeShrinkResp is the response sent for a volume shrink commit or stop
+// request.
+type VolumeShrinkResp VolumeInfo
+
+// VolumeReshapeResp is the response sent for a volume reshape request.
+type VolumeReshapeResp VolumeInfo
+
+// VolumeRenameResp is the response sent for a volume rename request.
+type VolumeRenameResp VolumeInfo
+
+// ShrinkNodeStatus represents the progress of data migration off the bricks
+// being removed on a peer.
+type ShrinkNodeStatus struct {
+	PeerID        uuid.UUID `json:"peer-id"`
+	Status        string    `json:"status"`
+	MigratedFiles string    `json:"migrated-files"`
+	MigratedSize  string    `json:"migrated-size"`
+	ScannedFiles  string    `json:"scanned-files"`
+	SkippedFiles  string    `json:"skipped-files"`
+	Failures      string    `json:"failures"`
+	RunTime       string    `json:"run-time"`
+	TimeLeft      string    `json:"time-left"`
+}
+
+// VolumeShrinkStatusResp is the response sent for a volume shrink start or
+// status request.
+type VolumeShrinkStatusResp struct {
+	VolName     string             `json:"volume"`
+	RebalanceID uuid.UUID          `json:"rebalance-id"`
+	Bricks      []BrickInfo        `json:"bricks"`
+	Complete    bool               `json:"complete"`
+	Nodes       []ShrinkNodeStatus `json:"nodes"`
+}
+
 // VolumeStartResp is the response sent for a volume start request.
 type VolumeStartResp VolumeInfo
 
@@ -147,3 +181,17 @@ type VolumeEditResp VolumeInfo
 
 // VolumeOptionsGetResp is the response sent for a volume get request for all options
 type VolumeOptionsGetResp []VolumeOptionGetResp
+
+// Volfile is a volfile of a volume, as generated from its template and its
+// options
+type Volfile struct {
+	VolfileID string `json:"volfile-id"`
+	Content   string `json:"content"`
+}
+
+// VolfileGetResp is the response sent for a volume volfile get request
+type VolfileGetResp Volfile
+
+// VolfileListResp is the response sent for a volume volfiles request. It has
+// the client volfile and the volfiles of all the bricks of the volume.
+type VolfileListResp []VolfileGetResp
@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every",
+	} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestNext(t *testing.T) {
+	from := time.Date(2018, time.December, 31, 23, 58, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2018, time.December, 31, 23, 59, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2019, time.January, 1, 2, 30, 0, 0, time.UTC)},
+		{"*/15 9-17 * * 1-5", time.Date(2019, time.January, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2019, time.January, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// day of month and day of week are ORed when both are restricted
+		{"0 0 20 * 3", time.Date(2019, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.next, s.Next(from), tt.expr)
+	}
+}
@@ -82,14 +82,20 @@ func (ee *ElasticEtcd) newClientConfig() clientv3.Config {
 		tlsConfig = &tls.Config{
 			MinVersion: tls.VersionTLS12,
 		}
-		if ee.conf.ClntCertFile != "" && ee.conf.ClntKeyFile != "" {
-			tlsCert, err := tls.LoadX509KeyPair(ee.conf.ClntCertFile, ee.conf.ClntKeyFile)
-			if err != nil {
+		if certFile, keyFile := ee.conf.ClntCertFile, ee.conf.ClntKeyFile; certFile != "" && keyFile != "" {
+			if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
 				log.WithError(err).Error("failed to load certificate file")
 				return clientv3.Config{}
 			}
-			tlsConfig.Certificates = []tls.Certificate{tlsCert}
-			tlsConfig.ClientAuth = tls.RequestClientCert
+			// Like the embedded server, the certificate is loaded on
+			// every handshake so that it can be rotated
+			tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				tlsCert, err := tls.LoadX509KeyPair(certFile, keyFile)
+				if err != nil {
+					return nil, err
+				}
+				return &tlsCert, nil
+			}
 		}
 		if ee.conf.CAFile != "" {
 			caCert, err := ioutil.ReadFile(ee.conf.CAFile)
@@ -104,7 +110,7 @@ func (ee *ElasticEtcd) newClientConfig() clientv3.Config {
 	}
 
 	return clientv3.Config{
-		Endpoints:        ee.conf.Endpoints.StringSlice(),
+		Endpoints:        ee.conf.secureURLs(ee.conf.Endpoints).StringSlice(),
 		AutoSyncInterval: 30 * time.Second, // Update list of endpoints ever 30s.
 		DialTimeout:      5 * time.Second,
 		RejectOldCluster: true,
@@ -90,6 +90,21 @@ func NewConfig() *Config {
 	}
 }
 
+// secureURLs returns the URLs with the https scheme if TLS is used. The
+// embedded etcd server only uses TLS on https URLs.
+func (c *Config) secureURLs(urls types.URLs) types.URLs {
+	if !c.UseTLS {
+		return urls
+	}
+
+	secured := make(types.URLs, len(urls))
+	for i, u := range urls {
+		u.Scheme = "https"
+		secured[i] = u
+	}
+	return secured
+}
+
 func isDefaultCURL(urls types.URLs) bool {
 	return isDefaultURL(urls, DefaultCURL)
 }
@@ -0,0 +1,27 @@
+package elasticetcd
+
+import (
+	"testing"
+
+	"github.com/coreos/etcd/pkg/types"
+)
+
+func TestSecureURLs(t *testing.T) {
+	urls := types.MustNewURLs([]string{"http://10.0.0.1:2379", "https://10.0.0.2:2379"})
+
+	c := NewConfig()
+	if r := c.secureURLs(urls); r.String() != urls.String() {
+		t.Errorf("secureURLs(%v) without TLS: expected %v, got %v", urls, urls, r)
+	}
+
+	c.UseTLS = true
+	expected := "https://10.0.0.1:2379,https://10.0.0.2:2379"
+	if r := c.secureURLs(urls); r.String() != expected {
+		t.Errorf("secureURLs(%v) with TLS: expected %v, got %v", urls, expected, r)
+	}
+
+	// The given URLs are left untouched
+	if urls[0].Scheme != "http" {
+		t.Errorf("secureURLs(%v) modified the given URLs", urls)
+	}
+}
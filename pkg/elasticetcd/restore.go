@@ -0,0 +1,80 @@
+package elasticetcd
+
+import (
+	"os"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/pkg/types"
+	"github.com/coreos/etcd/snapshot"
+	"github.com/sirupsen/logrus"
+)
+
+// Restore replaces the data of the embedded etcd server with the data from
+// the etcd snapshot saved at snapshotPath. The restored server is a single
+// member etcd cluster, with the name and the advertised peer URLs given in
+// conf. The other members of the old cluster, if any, rejoin as nominees
+// when the ElasticEtcd is started.
+func Restore(conf *Config, snapshotPath string) error {
+	ee := new(ElasticEtcd)
+	ee.conf = conf
+	ee.initLogging()
+	defer ee.logFile.Close()
+
+	econf := ee.newEmbedConfig("")
+
+	ee.log.WithFields(logrus.Fields{
+		"snapshot":       snapshotPath,
+		"datadir":        econf.Dir,
+		"initialcluster": econf.InitialCluster,
+	}).Debug("restoring embedded etcd from snapshot")
+
+	// The snapshot manager refuses to restore into an existing data dir
+	if err := os.RemoveAll(econf.Dir); err != nil {
+		return err
+	}
+
+	initialCluster, err := types.NewURLsMap(econf.InitialCluster)
+	if err != nil {
+		return err
+	}
+
+	err = snapshot.NewV3(nil, nil).Restore(snapshotPath, snapshot.RestoreConfig{
+		Name:                econf.Name,
+		OutputDataDir:       econf.Dir,
+		PeerURLs:            types.URLs(econf.APUrls),
+		InitialCluster:      initialCluster,
+		InitialClusterToken: econf.InitialClusterToken,
+	})
+	if err != nil {
+		ee.log.WithError(err).Error("failed to restore etcd snapshot")
+		return err
+	}
+
+	// The restored data has the volunteers and nominees of the old cluster,
+	// which are not members of the restored cluster. Start the restored
+	// server once to clear them.
+	ee.lock.Lock()
+	defer ee.lock.Unlock()
+
+	if err := ee.startServer(""); err != nil {
+		return err
+	}
+	defer ee.stopServer()
+
+	ee.conf.Endpoints = ee.server.srv.Config().ACUrls
+	cli, err := clientv3.New(ee.newClientConfig())
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	for _, prefix := range []string{nomineePrefix, volunteerPrefix} {
+		if _, err := cli.Delete(cli.Ctx(), prefix, clientv3.WithPrefix()); err != nil {
+			ee.log.WithError(err).WithField("prefix", prefix).Error("failed to clear restored keys")
+			return err
+		}
+	}
+
+	ee.log.Debug("restored embedded etcd from snapshot")
+	return nil
+}
@@ -109,6 +109,11 @@ func (ee *ElasticEtcd) newEmbedConfig(initialCluster string) *embed.Config {
 		conf.APUrls = defaultAPURLs
 	}
 
+	conf.LCUrls = ee.conf.secureURLs(conf.LCUrls)
+	conf.ACUrls = ee.conf.secureURLs(conf.ACUrls)
+	conf.LPUrls = ee.conf.secureURLs(conf.LPUrls)
+	conf.APUrls = ee.conf.secureURLs(conf.APUrls)
+
 	if initialCluster != "" {
 		conf.InitialCluster = initialCluster
 		conf.ClusterState = embed.ClusterStateFlagExisting
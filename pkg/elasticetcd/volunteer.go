@@ -13,9 +13,9 @@ func (ee *ElasticEtcd) volunteerSelf() error {
 	// Need to set advertisable PURLs here as the initial cluster lists for new
 	// servers will be formed from this, the default PURL is not advertisable.
 	if isDefaultPURL(ee.conf.PURLs) {
-		val = defaultAPURLs.String()
+		val = ee.conf.secureURLs(defaultAPURLs).String()
 	} else {
-		val = ee.conf.PURLs.String()
+		val = ee.conf.secureURLs(ee.conf.PURLs).String()
 	}
 
 	_, err := ee.cli.Put(ee.cli.Ctx(), key, val, clientv3.WithLease(ee.session.Lease()))
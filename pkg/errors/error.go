@@ -74,5 +74,30 @@ var (
 	ErrConnectingHost                  = errors.New("could not connect to host. Make sure host address is valid, network connection is active and gd2 is up and running")
 	ErrBlockVolNotFound                = errors.New("block volume not found")
 	ErrBlockHostVolNotFound            = errors.New("block hosting volume not found")
+	ErrBlockVolExists                  = errors.New("block volume already exists")
+	ErrBlockSnapNotFound               = errors.New("block volume snapshot not found")
+	ErrBlockSnapExists                 = errors.New("block volume snapshot already exists")
+	ErrBlockVolHasSnapshots            = errors.New("block volume has snapshots, delete its snapshots first")
+	ErrBlockSnapNotSupported           = errors.New("block volume snapshots and clones are not supported by the block provider")
 	ErrSnapNotSupported                = errors.New("snapshot not supported")
+	ErrInvalidSnapConfig               = errors.New("invalid snapshot config")
+	ErrClusterOnlySnapConfig           = errors.New("snapshot config can only be set cluster-wide")
+	ErrSnapScheduleNotFound            = errors.New("snapshot schedule not found")
+	ErrSnapScheduleExists              = errors.New("snapshot schedule already exists")
+	ErrSnapHardLimitReached            = errors.New("snapshot hard limit reached for the volume, delete snapshots or enable auto-delete")
+	ErrVolShrinkInProgress             = errors.New("volume shrink in progress")
+	ErrVolShrinkNotStarted             = errors.New("volume shrink not started")
+	ErrVolShrinkIncomplete             = errors.New("data migration off the bricks being removed has not completed successfully")
+	ErrRebalanceInProgress             = errors.New("rebalance in progress")
+	ErrVolNotStopped                   = errors.New("volume must be in stopped state")
+	ErrUserNotFound                    = errors.New("user not found")
+	ErrUserExists                      = errors.New("user already exists")
+	ErrInvalidRole                     = errors.New("invalid role, supported roles are read-only, volume-admin and cluster-admin")
+	ErrPermissionDenied                = errors.New("permission denied")
+	ErrTemplateNotFound                = errors.New("volfile template not found")
+	ErrTemplateExists                  = errors.New("volfile template already exists")
+	ErrTemplateInUse                   = errors.New("volfile template is in use by volumes")
+	ErrVolfileNotFound                 = errors.New("volfile not found")
+	ErrBrickNotFound                   = errors.New("brick not found in the volume")
+	ErrVolHostsBlockVolumes            = errors.New("volume hosts block volumes")
 )
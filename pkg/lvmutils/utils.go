@@ -68,16 +68,28 @@ func RemovePV(device string) error {
 	return utils.ExecuteCommandRun("pvremove", device)
 }
 
-// GetVgAvailableSize gets available size of given Vg
-func GetVgAvailableSize(vgname string) (uint64, uint64, error) {
-	out, err := exec.Command("vgdisplay", "-c", "--readonly", vgname).Output()
+// ResizePV grows the physical volume to the current size of the device
+func ResizePV(device string) error {
+	return utils.ExecuteCommandRun("pvresize", device)
+}
+
+// vgDisplay returns the output of vgdisplay for the given Vg, in the colon
+// separated format
+var vgDisplay = func(vgname string) ([]byte, error) {
+	return exec.Command("vgdisplay", "-c", "--readonly", vgname).Output()
+}
+
+// vgExtents returns the extent size of the given Vg in bytes, along with
+// its number of extents at the given index of the vgdisplay output
+func vgExtents(vgname string, index int) (uint64, uint64, error) {
+	out, err := vgDisplay(vgname)
 	if err != nil {
 		return 0, 0, err
 	}
-	vgdata := strings.Split(strings.TrimRight(string(out), "\n"), ":")
+	vgdata := strings.Split(strings.TrimSpace(string(out)), ":")
 
 	if len(vgdata) != 17 {
-		return 0, 0, errors.New("failed to get free size of VG: " + vgname)
+		return 0, 0, errors.New("failed to get size of VG: " + vgname)
 	}
 
 	// Physical extent size index is 12
@@ -86,16 +98,36 @@ func GetVgAvailableSize(vgname string) (uint64, uint64, error) {
 		return 0, 0, err
 	}
 
+	extents, err := strconv.ParseUint(vgdata[index], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return extentSize * utils.KiB, extents, nil
+}
+
+// GetVgAvailableSize gets available size of given Vg
+func GetVgAvailableSize(vgname string) (uint64, uint64, error) {
 	// Free Extents index is 15
-	freeExtents, err := strconv.ParseUint(vgdata[15], 10, 64)
+	extentSize, freeExtents, err := vgExtents(vgname, 15)
 	if err != nil {
 		return 0, 0, err
 	}
-	extentSize = extentSize * utils.KiB
 
 	return extentSize * freeExtents, extentSize, nil
 }
 
+// GetVgTotalSize gets total size of given Vg
+func GetVgTotalSize(vgname string) (uint64, uint64, error) {
+	// Total Extents index is 13
+	extentSize, totalExtents, err := vgExtents(vgname, 13)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return extentSize * totalExtents, extentSize, nil
+}
+
 // GetPoolMetadataSize calculates the thin pool metadata size based on the given thin pool size
 func GetPoolMetadataSize(poolsize uint64) uint64 {
 	// https://access.redhat.com/documentation/en-us/red_hat_gluster_storage/3.3/html-single/administration_guide/#Brick_Configuration
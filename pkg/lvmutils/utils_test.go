@@ -0,0 +1,45 @@
+package lvmutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetVgSize validates GetVgTotalSize() and GetVgAvailableSize()
+func TestGetVgSize(t *testing.T) {
+	defer func(f func(string) ([]byte, error)) { vgDisplay = f }(vgDisplay)
+
+	// 4MiB extents, 2559 in total of which 2303 are free
+	out := "  vg1:r/w:772:-1:0:1:1:-1:0:1:1:10481664:4096:2559:256:2303:Xm3dcO-oZ8C-HbJp\n"
+	vgDisplay = func(vgname string) ([]byte, error) {
+		return []byte(out), nil
+	}
+
+	total, extentSize, err := GetVgTotalSize("vg1")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4<<20), extentSize)
+	assert.Equal(t, uint64(2559*4<<20), total)
+
+	available, extentSize, err := GetVgAvailableSize("vg1")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4<<20), extentSize)
+	assert.Equal(t, uint64(2303*4<<20), available)
+
+	// The VG has grown after its PV was resized
+	out = "  vg1:r/w:772:-1:0:1:1:-1:0:1:1:20967424:4096:5119:256:4863:Xm3dcO-oZ8C-HbJp\n"
+	total, _, err = GetVgTotalSize("vg1")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5119*4<<20), total)
+
+	out = "  vg1:r/w:772\n"
+	_, _, err = GetVgTotalSize("vg1")
+	assert.NotNil(t, err)
+
+	vgDisplay = func(vgname string) ([]byte, error) {
+		return nil, errors.New("volume group not found")
+	}
+	_, _, err = GetVgTotalSize("vg1")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,42 @@
+package restclient
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+// AuditList returns the audit log entries of the given user recorded between
+// since and until. Empty user and zero times match all entries. The first
+// offset matching entries are skipped, and at most limit entries are returned.
+// A zero limit returns the default number of entries set by the server.
+func (c *Client) AuditList(user string, since, until time.Time, offset, limit int) (api.AuditListResp, error) {
+	query := url.Values{}
+	if user != "" {
+		query.Set("user", user)
+	}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		query.Set("until", until.Format(time.RFC3339))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := "/v1/audit"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var resp api.AuditListResp
+	err := c.get(path, nil, http.StatusOK, &resp)
+	return resp, err
+}
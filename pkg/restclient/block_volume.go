@@ -36,3 +36,49 @@ func (c *Client) BlockVolumeDelete(provider string, blockVolname string) error {
 	url := fmt.Sprintf("/v1/blockvolumes/%s/%s", provider, blockVolname)
 	return c.del(url, nil, http.StatusNoContent, nil)
 }
+
+// BlockVolumeResize grows a Gluster Block Volume to the requested size
+func (c *Client) BlockVolumeResize(provider string, blockVolname string, req api.BlockVolumeResizeRequest) (api.BlockVolumeResizeResp, error) {
+	var vol api.BlockVolumeResizeResp
+	url := fmt.Sprintf("/v1/blockvolumes/%s/%s", provider, blockVolname)
+	err := c.patch(url, req, http.StatusOK, &vol)
+	return vol, err
+}
+
+// BlockSnapshotCreate creates a snapshot of a Gluster Block Volume
+func (c *Client) BlockSnapshotCreate(provider string, blockVolname string, req api.BlockSnapshotCreateRequest) (api.BlockSnapshotCreateResp, error) {
+	var snap api.BlockSnapshotCreateResp
+	url := fmt.Sprintf("/v1/blockvolumes/%s/%s/snapshot", provider, blockVolname)
+	err := c.post(url, req, http.StatusCreated, &snap)
+	return snap, err
+}
+
+// BlockSnapshotList lists the snapshots of a Gluster Block Volume
+func (c *Client) BlockSnapshotList(provider string, blockVolname string) (api.BlockSnapshotListResp, error) {
+	var snaps api.BlockSnapshotListResp
+	url := fmt.Sprintf("/v1/blockvolumes/%s/%s/snapshot", provider, blockVolname)
+	err := c.get(url, nil, http.StatusOK, &snaps)
+	return snaps, err
+}
+
+// BlockSnapshotGet gets info about a snapshot of a Gluster Block Volume
+func (c *Client) BlockSnapshotGet(provider string, blockVolname string, snapname string) (api.BlockSnapshotGetResp, error) {
+	var snap api.BlockSnapshotGetResp
+	url := fmt.Sprintf("/v1/blockvolumes/%s/%s/snapshot/%s", provider, blockVolname, snapname)
+	err := c.get(url, nil, http.StatusOK, &snap)
+	return snap, err
+}
+
+// BlockSnapshotDelete deletes a snapshot of a Gluster Block Volume
+func (c *Client) BlockSnapshotDelete(provider string, blockVolname string, snapname string) error {
+	url := fmt.Sprintf("/v1/blockvolumes/%s/%s/snapshot/%s", provider, blockVolname, snapname)
+	return c.del(url, nil, http.StatusNoContent, nil)
+}
+
+// BlockVolumeClone creates a new Gluster Block Volume from a Gluster Block Volume or its snapshot
+func (c *Client) BlockVolumeClone(provider string, blockVolname string, req api.BlockCloneCreateRequest) (api.BlockCloneCreateResp, error) {
+	var vol api.BlockCloneCreateResp
+	url := fmt.Sprintf("/v1/blockvolumes/%s/%s/clone", provider, blockVolname)
+	err := c.post(url, req, http.StatusCreated, &vol)
+	return vol, err
+}
@@ -181,6 +181,10 @@ func (c *Client) put(url string, data interface{}, expectStatusCode int, output
 	return c.do("PUT", url, data, expectStatusCode, output)
 }
 
+func (c *Client) patch(url string, data interface{}, expectStatusCode int, output interface{}) error {
+	return c.do("PATCH", url, data, expectStatusCode, output)
+}
+
 func (c *Client) get(url string, data interface{}, expectStatusCode int, output interface{}) error {
 	return c.do("GET", url, data, expectStatusCode, output)
 }
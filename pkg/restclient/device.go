@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/gluster/glusterd2/pkg/api"
 	deviceapi "github.com/gluster/glusterd2/plugins/device/api"
 )
 
@@ -44,3 +45,29 @@ func (c *Client) DeviceEdit(peerid, device, state string) error {
 	err := c.post(url, req, http.StatusOK, nil)
 	return err
 }
+
+// DeviceResize updates the size of device after the underlying disk is grown
+func (c *Client) DeviceResize(peerid, device string) (deviceapi.ResizeDeviceResp, error) {
+	var deviceinfo deviceapi.ResizeDeviceResp
+	device = strings.TrimLeft(device, "/")
+	url := fmt.Sprintf("/v1/devices/%s/%s/resize", peerid, device)
+	err := c.post(url, nil, http.StatusOK, &deviceinfo)
+	return deviceinfo, err
+}
+
+// DeviceDelete removes device
+func (c *Client) DeviceDelete(peerid, device string) error {
+	device = strings.TrimLeft(device, "/")
+	url := fmt.Sprintf("/v1/devices/%s/%s", peerid, device)
+	return c.del(url, nil, http.StatusNoContent, nil)
+}
+
+// DeviceEvacuate starts a job which moves the bricks provisioned on the
+// device to other devices, and removes the device
+func (c *Client) DeviceEvacuate(peerid, device string) (api.JobGetResp, error) {
+	var job api.JobGetResp
+	device = strings.TrimLeft(device, "/")
+	url := fmt.Sprintf("/v1/devices/%s/%s?evacuate=true", peerid, device)
+	err := c.del(url, nil, http.StatusAccepted, &job)
+	return job, err
+}
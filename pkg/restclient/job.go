@@ -0,0 +1,28 @@
+package restclient
+
+import (
+	"net/http"
+
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+// JobList returns the jobs of the requests processed asynchronously
+func (c *Client) JobList() (api.JobListResp, error) {
+	var resp api.JobListResp
+	err := c.get("/v1/jobs", nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// JobGet returns the job with the given ID
+func (c *Client) JobGet(jobid string) (api.JobGetResp, error) {
+	var resp api.JobGetResp
+	err := c.get("/v1/jobs/"+jobid, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// JobCancel cancels the running job with the given ID
+func (c *Client) JobCancel(jobid string) (api.JobGetResp, error) {
+	var resp api.JobGetResp
+	err := c.del("/v1/jobs/"+jobid, nil, http.StatusOK, &resp)
+	return resp, err
+}
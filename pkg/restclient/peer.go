@@ -37,3 +37,11 @@ func (c *Client) Peers(filterParams ...map[string]string) (api.PeerListResp, err
 	err := c.get("/v1/peers"+queryString, nil, http.StatusOK, &peers)
 	return peers, err
 }
+
+// PeerTLSReload reloads the peer TLS certificates on all the peers of the
+// Cluster
+func (c *Client) PeerTLSReload() (api.PeerTLSReloadResp, error) {
+	var resp api.PeerTLSReloadResp
+	err := c.post("/v1/peers/tls/reload", nil, http.StatusOK, &resp)
+	return resp, err
+}
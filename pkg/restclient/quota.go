@@ -3,6 +3,8 @@ package restclient
 import (
 	"fmt"
 	"net/http"
+
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
 )
 
 // QuotaEnable starts a Gluster Volume
@@ -10,3 +12,27 @@ func (c *Client) QuotaEnable(volname string) error {
 	url := fmt.Sprintf("/v1/quota/%s", volname)
 	return c.post(url, nil, http.StatusOK, nil)
 }
+
+// QuotaList returns the limits set on directories of a volume along with
+// their usage
+func (c *Client) QuotaList(volname string) (quotaapi.ListResp, error) {
+	var resp quotaapi.ListResp
+	url := fmt.Sprintf("/v1/quota/%s/limit", volname)
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// QuotaLimit sets a usage limit on a directory of a volume
+func (c *Client) QuotaLimit(volname string, req quotaapi.SetLimitReq) (quotaapi.DirLimit, error) {
+	var resp quotaapi.DirLimit
+	url := fmt.Sprintf("/v1/quota/%s/limit", volname)
+	err := c.post(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// QuotaRemove removes the usage limit set on a directory of a volume
+func (c *Client) QuotaRemove(volname string, dirpath string) error {
+	url := fmt.Sprintf("/v1/quota/%s/limit", volname)
+	req := quotaapi.RemoveLimitReq{Path: dirpath}
+	return c.del(url, req, http.StatusNoContent, nil)
+}
@@ -79,3 +79,71 @@ func (c *Client) SnapshotClone(snapname string, req api.SnapCloneReq) (api.Volum
 	err := c.post(url, req, http.StatusCreated, &vol)
 	return vol, err
 }
+
+// SnapshotConfigGet returns the snapshot configuration of the cluster, or of
+// a volume if volname is not empty
+func (c *Client) SnapshotConfigGet(volname string) (api.SnapConfigResp, error) {
+	var resp api.SnapConfigResp
+	url := "/v1/snapshots/config"
+	if volname != "" {
+		url = fmt.Sprintf("/v1/snapshots/config?volume=%s", volname)
+	}
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// SnapshotConfigSet sets snapshot configuration of the cluster or a volume
+func (c *Client) SnapshotConfigSet(req api.SnapConfigReq) (api.SnapConfigResp, error) {
+	var resp api.SnapConfigResp
+	err := c.post("/v1/snapshots/config", req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// SnapshotConfigReset resets snapshot configuration of the cluster or a
+// volume to defaults
+func (c *Client) SnapshotConfigReset(req api.SnapConfigResetReq) (api.SnapConfigResp, error) {
+	var resp api.SnapConfigResp
+	err := c.del("/v1/snapshots/config", req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// SnapshotScheduleCreate creates a snapshot schedule
+func (c *Client) SnapshotScheduleCreate(req api.SnapScheduleReq) (api.SnapScheduleResp, error) {
+	var resp api.SnapScheduleResp
+	err := c.post("/v1/snapshots/schedules", req, http.StatusCreated, &resp)
+	return resp, err
+}
+
+// SnapshotScheduleList returns all snapshot schedules or the schedules of a
+// volume
+func (c *Client) SnapshotScheduleList(volname string) (api.SnapScheduleListResp, error) {
+	var resp api.SnapScheduleListResp
+	url := "/v1/snapshots/schedules"
+	if volname != "" {
+		url = fmt.Sprintf("/v1/snapshots/schedules?volume=%s", volname)
+	}
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// SnapshotScheduleInfo returns information about a snapshot schedule
+func (c *Client) SnapshotScheduleInfo(name string) (api.SnapScheduleResp, error) {
+	var resp api.SnapScheduleResp
+	url := fmt.Sprintf("/v1/snapshots/schedules/%s", name)
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// SnapshotScheduleEdit modifies a snapshot schedule
+func (c *Client) SnapshotScheduleEdit(name string, req api.SnapScheduleEditReq) (api.SnapScheduleResp, error) {
+	var resp api.SnapScheduleResp
+	url := fmt.Sprintf("/v1/snapshots/schedules/%s/edit", name)
+	err := c.post(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// SnapshotScheduleDelete deletes a snapshot schedule
+func (c *Client) SnapshotScheduleDelete(name string) error {
+	url := fmt.Sprintf("/v1/snapshots/schedules/%s", name)
+	return c.del(url, nil, http.StatusNoContent, nil)
+}
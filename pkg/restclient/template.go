@@ -0,0 +1,44 @@
+package restclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+// TemplateCreate creates a volfile template
+func (c *Client) TemplateCreate(req api.TemplateCreateReq) (api.TemplateCreateResp, error) {
+	var resp api.TemplateCreateResp
+	err := c.post("/v1/templates", req, http.StatusCreated, &resp)
+	return resp, err
+}
+
+// TemplateEdit replaces the volfile templates of a template
+func (c *Client) TemplateEdit(name string, req api.TemplateEditReq) (api.TemplateEditResp, error) {
+	var resp api.TemplateEditResp
+	url := fmt.Sprintf("/v1/templates/%s", name)
+	err := c.post(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// TemplateDelete deletes a volfile template
+func (c *Client) TemplateDelete(name string) error {
+	url := fmt.Sprintf("/v1/templates/%s", name)
+	return c.del(url, nil, http.StatusNoContent, nil)
+}
+
+// TemplateGet returns a volfile template
+func (c *Client) TemplateGet(name string) (api.TemplateGetResp, error) {
+	var resp api.TemplateGetResp
+	url := fmt.Sprintf("/v1/templates/%s", name)
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// Templates returns the list of volfile templates
+func (c *Client) Templates() (api.TemplateListResp, error) {
+	var resp api.TemplateListResp
+	err := c.get("/v1/templates", nil, http.StatusOK, &resp)
+	return resp, err
+}
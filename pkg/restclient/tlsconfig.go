@@ -11,6 +11,10 @@ import (
 type TLSOptions struct {
 	CaCertFile         string
 	InsecureSkipVerify bool
+	// CertFile and KeyFile are the client certificate and its key, used
+	// to authenticate with glusterd2 instead of a token
+	CertFile string
+	KeyFile  string
 }
 
 // NewTLSConfig returns TLS configuration meant to be used by GD2 client
@@ -29,5 +33,12 @@ func NewTLSConfig(opts *TLSOptions) (*tls.Config, error) {
 		}
 		tlsConfig.RootCAs = caCertPool
 	}
+	if opts.CertFile != "" && opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
 	return tlsConfig, nil
 }
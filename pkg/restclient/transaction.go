@@ -0,0 +1,22 @@
+package restclient
+
+import (
+	"net/http"
+
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+// TransactionList returns the pending transactions and the transaction
+// history
+func (c *Client) TransactionList() (api.TxnListResp, error) {
+	var resp api.TxnListResp
+	err := c.get("/v1/transactions", nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// TransactionGet returns the transaction with the given ID
+func (c *Client) TransactionGet(txnid string) (api.TxnGetResp, error) {
+	var resp api.TxnGetResp
+	err := c.get("/v1/transactions/"+txnid, nil, http.StatusOK, &resp)
+	return resp, err
+}
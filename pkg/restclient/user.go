@@ -0,0 +1,44 @@
+package restclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gluster/glusterd2/pkg/api"
+)
+
+// UserCreate creates a REST API user
+func (c *Client) UserCreate(req api.UserCreateReq) (api.UserCreateResp, error) {
+	var resp api.UserCreateResp
+	err := c.post("/v1/users", req, http.StatusCreated, &resp)
+	return resp, err
+}
+
+// UserEdit changes the role or the secret of a REST API user
+func (c *Client) UserEdit(username string, req api.UserEditReq) (api.UserEditResp, error) {
+	var resp api.UserEditResp
+	url := fmt.Sprintf("/v1/users/%s", username)
+	err := c.post(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// UserDelete deletes a REST API user
+func (c *Client) UserDelete(username string) error {
+	url := fmt.Sprintf("/v1/users/%s", username)
+	return c.del(url, nil, http.StatusNoContent, nil)
+}
+
+// UserGet returns information about a REST API user
+func (c *Client) UserGet(username string) (api.UserGetResp, error) {
+	var resp api.UserGetResp
+	url := fmt.Sprintf("/v1/users/%s", username)
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// Users returns the list of REST API users
+func (c *Client) Users() (api.UserListResp, error) {
+	var resp api.UserListResp
+	err := c.get("/v1/users", nil, http.StatusOK, &resp)
+	return resp, err
+}
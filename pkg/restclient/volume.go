@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/gluster/glusterd2/pkg/api"
 )
@@ -89,6 +90,20 @@ func (c *Client) VolumeStatus(volname string) (api.VolumeStatusResp, error) {
 	return volStatus, err
 }
 
+// VolumeStatusDetail returns a detailed view of the status of the bricks of a
+// Gluster volume, ie. detail, clients, mem, inode, fd or callpool. The status
+// can be limited to a brick.
+func (c *Client) VolumeStatusDetail(volname, option, brick string) (api.VolumeStatusDetailResp, error) {
+	path := fmt.Sprintf("/v1/volumes/%s/status/%s", volname, option)
+	if brick != "" {
+		path += "?" + url.Values{"brick": {brick}}.Encode()
+	}
+
+	var resp api.VolumeStatusDetailResp
+	err := c.get(path, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
 // VolumeStart starts a Gluster Volume
 func (c *Client) VolumeStart(volname string, force bool) error {
 	req := api.VolumeStartReq{
@@ -145,6 +160,55 @@ func (c *Client) VolumeExpand(volname string, req api.VolExpandReq) (api.VolumeE
 	return vol, err
 }
 
+// VolumeShrink starts removing bricks from a Gluster Volume
+func (c *Client) VolumeShrink(volname string, req api.VolShrinkReq) (api.VolumeShrinkStatusResp, error) {
+	var resp api.VolumeShrinkStatusResp
+	url := fmt.Sprintf("/v1/volumes/%s/shrink", volname)
+	err := c.post(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeShrinkStatus returns the progress of a volume shrink
+func (c *Client) VolumeShrinkStatus(volname string) (api.VolumeShrinkStatusResp, error) {
+	var resp api.VolumeShrinkStatusResp
+	url := fmt.Sprintf("/v1/volumes/%s/shrink", volname)
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeShrinkCommit removes the bricks being removed from a Gluster Volume
+func (c *Client) VolumeShrinkCommit(volname string, req api.VolShrinkCommitReq) (api.VolumeShrinkResp, error) {
+	var vol api.VolumeShrinkResp
+	url := fmt.Sprintf("/v1/volumes/%s/shrink/commit", volname)
+	err := c.post(url, req, http.StatusOK, &vol)
+	return vol, err
+}
+
+// VolumeShrinkStop stops a volume shrink, retaining the bricks being removed
+func (c *Client) VolumeShrinkStop(volname string) (api.VolumeShrinkResp, error) {
+	var vol api.VolumeShrinkResp
+	url := fmt.Sprintf("/v1/volumes/%s/shrink/stop", volname)
+	err := c.post(url, nil, http.StatusOK, &vol)
+	return vol, err
+}
+
+// VolumeReshape changes the replica count of a volume by adding or removing
+// one brick per subvolume
+func (c *Client) VolumeReshape(volname string, req api.VolReshapeReq) (api.VolumeReshapeResp, error) {
+	var vol api.VolumeReshapeResp
+	url := fmt.Sprintf("/v1/volumes/%s/reshape", volname)
+	err := c.post(url, req, http.StatusOK, &vol)
+	return vol, err
+}
+
+// VolumeRename renames a stopped volume
+func (c *Client) VolumeRename(volname string, req api.VolRenameReq) (api.VolumeRenameResp, error) {
+	var vol api.VolumeRenameResp
+	url := fmt.Sprintf("/v1/volumes/%s/rename", volname)
+	err := c.post(url, req, http.StatusOK, &vol)
+	return vol, err
+}
+
 // ReplaceBrick replaces the old brick in volume with a new one
 func (c *Client) ReplaceBrick(volname string, req api.ReplaceBrickReq) (api.ReplaceBrickResp, error) {
 	var resp api.ReplaceBrickResp
@@ -199,3 +263,96 @@ func (c *Client) VolumeProfileInfo(volname string, option string) ([]api.BrickPr
 	err := c.get(url, nil, http.StatusOK, &volumeProfileInfo)
 	return volumeProfileInfo, err
 }
+
+// VolumeTop returns the top files of a volume for a metric. The results can
+// be limited to a brick, and to a number of entries. Empty or zero values are
+// not sent.
+func (c *Client) VolumeTop(volname, metric, brick string, limit int) (api.VolumeTopResp, error) {
+	query := url.Values{}
+	if brick != "" {
+		query.Set("brick", brick)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := fmt.Sprintf("/v1/volumes/%s/top/%s", volname, metric)
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var resp api.VolumeTopResp
+	err := c.get(path, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeTopThroughput runs a throughput test on the bricks of a volume, and
+// returns the top files of the volume for the read-perf or write-perf metric
+func (c *Client) VolumeTopThroughput(volname, metric string, req api.VolumeTopThroughputReq) (api.VolumeTopResp, error) {
+	var resp api.VolumeTopResp
+	path := fmt.Sprintf("/v1/volumes/%s/top/%s/throughput", volname, metric)
+	err := c.post(path, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeCreateDryRun returns the bricks and the volfiles which a volume
+// create request would provision, without creating the volume
+func (c *Client) VolumeCreateDryRun(req api.VolCreateReq) (api.VolumeDryRunResp, error) {
+	var resp api.VolumeDryRunResp
+	err := c.post("/v1/volumes?dry-run=true", req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeExpandDryRun returns the bricks and the volfile changes which a
+// volume expand request would make, without expanding the volume
+func (c *Client) VolumeExpandDryRun(volname string, req api.VolExpandReq) (api.VolumeDryRunResp, error) {
+	var resp api.VolumeDryRunResp
+	url := fmt.Sprintf("/v1/volumes/%s/expand?dry-run=true", volname)
+	err := c.post(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// ReplaceBrickDryRun returns the new brick and the volfile changes which a
+// replace brick request would make, without replacing the brick
+func (c *Client) ReplaceBrickDryRun(volname string, req api.ReplaceBrickReq) (api.VolumeDryRunResp, error) {
+	var resp api.VolumeDryRunResp
+	url := fmt.Sprintf("/v1/volumes/%s/replacebrick?dry-run=true", volname)
+	err := c.post(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeSetDryRun returns the volfile changes which setting the volume
+// options would make, without setting them
+func (c *Client) VolumeSetDryRun(volname string, req api.VolOptionReq) (api.VolumeDryRunResp, error) {
+	var resp api.VolumeDryRunResp
+	url := fmt.Sprintf("/v1/volumes/%s/options?dry-run=true", volname)
+	err := c.post(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeResetDryRun returns the volfile changes which resetting the volume
+// options would make, without resetting them
+func (c *Client) VolumeResetDryRun(volname string, req api.VolOptionResetReq) (api.VolumeDryRunResp, error) {
+	var resp api.VolumeDryRunResp
+	url := fmt.Sprintf("/v1/volumes/%s/options?dry-run=true", volname)
+	err := c.del(url, req, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeVolfiles returns the client volfile and the brick volfiles of a
+// volume, generated from its current options
+func (c *Client) VolumeVolfiles(volname string) (api.VolfileListResp, error) {
+	var resp api.VolfileListResp
+	url := fmt.Sprintf("/v1/volumes/%s/volfiles", volname)
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
+
+// VolumeVolfile returns a volfile of a volume, generated from its current
+// options
+func (c *Client) VolumeVolfile(volname string, volfileID string) (api.VolfileGetResp, error) {
+	var resp api.VolfileGetResp
+	url := fmt.Sprintf("/v1/volumes/%s/volfiles/%s", volname, volfileID)
+	err := c.get(url, nil, http.StatusOK, &resp)
+	return resp, err
+}
@@ -0,0 +1,244 @@
+// Package tlsutils implements the verification of client certificates
+// against a set of trusted issuers and their certificate revocation lists
+package tlsutils
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCertRevoked is returned when a certificate of a verified chain
+	// has been revoked by its issuer
+	ErrCertRevoked = errors.New("certificate has been revoked")
+	// ErrCRLExpired is returned when the CRL of the issuer of a certificate
+	// of a verified chain is past its next update time
+	ErrCRLExpired = errors.New("certificate revocation list has expired")
+)
+
+// LoadCerts returns the PEM encoded certificates in the given files
+func LoadCerts(files ...string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", file, err)
+			}
+			certs = append(certs, cert)
+			found = true
+		}
+		if !found {
+			return nil, fmt.Errorf("%s: no certificates found", file)
+		}
+	}
+	return certs, nil
+}
+
+// NewCertPool returns a pool of the certificates
+func NewCertPool(certs []*x509.Certificate) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, cert := range certs {
+		pool.AddCert(cert)
+	}
+	return pool
+}
+
+// parseCRLs returns the PEM or DER encoded CRLs in the data
+func parseCRLs(data []byte) ([]*pkix.CertificateList, error) {
+	if block, _ := pem.Decode(data); block == nil {
+		crl, err := x509.ParseDERCRL(data)
+		if err != nil {
+			return nil, err
+		}
+		return []*pkix.CertificateList{crl}, nil
+	}
+
+	var crls []*pkix.CertificateList
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "X509 CRL" {
+			continue
+		}
+		crl, err := x509.ParseDERCRL(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		crls = append(crls, crl)
+	}
+	return crls, nil
+}
+
+// CRLVerifier checks the certificates of verified chains against the
+// revocation lists of their issuers. The revocation lists are reloaded when
+// their files are modified. Certificates whose issuer has a revocation list
+// past its next update time are rejected, as revocations published since
+// then are not known.
+type CRLVerifier struct {
+	issuers []*x509.Certificate
+	files   []string
+
+	mu       sync.RWMutex
+	modTimes map[string]time.Time
+	// revoked has the revoked serial numbers, keyed by issuer
+	revoked map[string]map[string]bool
+	// nextUpdates has the time by which a newer CRL is due, keyed by issuer
+	nextUpdates map[string]time.Time
+}
+
+// NewCRLVerifier returns a CRLVerifier of the CRLs in the given files. Each
+// CRL has to be signed by one of the issuers.
+func NewCRLVerifier(issuers []*x509.Certificate, files []string) (*CRLVerifier, error) {
+	v := &CRLVerifier{
+		issuers: issuers,
+		files:   files,
+	}
+	if err := v.load(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (v *CRLVerifier) issuer(name pkix.RDNSequence) *x509.Certificate {
+	for _, issuer := range v.issuers {
+		if issuer.Subject.ToRDNSequence().String() == name.String() {
+			return issuer
+		}
+	}
+	return nil
+}
+
+func (v *CRLVerifier) load() error {
+	modTimes := make(map[string]time.Time)
+	revoked := make(map[string]map[string]bool)
+	nextUpdates := make(map[string]time.Time)
+
+	for _, file := range v.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		crls, err := parseCRLs(data)
+		if err != nil {
+			return fmt.Errorf("%s: %s", file, err)
+		}
+
+		for _, crl := range crls {
+			issuer := v.issuer(crl.TBSCertList.Issuer)
+			if issuer == nil {
+				return fmt.Errorf("%s: CRL issuer %s is not trusted", file, crl.TBSCertList.Issuer)
+			}
+			if err := issuer.CheckCRLSignature(crl); err != nil {
+				return fmt.Errorf("%s: %s", file, err)
+			}
+
+			name := crl.TBSCertList.Issuer.String()
+			if revoked[name] == nil {
+				revoked[name] = make(map[string]bool)
+			}
+			for _, cert := range crl.TBSCertList.RevokedCertificates {
+				revoked[name][cert.SerialNumber.String()] = true
+			}
+
+			// The latest of the CRLs of an issuer decides when it
+			// expires. The next update time is optional.
+			nextUpdate := crl.TBSCertList.NextUpdate
+			if !nextUpdate.IsZero() && nextUpdate.After(nextUpdates[name]) {
+				nextUpdates[name] = nextUpdate
+			}
+		}
+	}
+
+	v.mu.Lock()
+	v.modTimes = modTimes
+	v.revoked = revoked
+	v.nextUpdates = nextUpdates
+	v.mu.Unlock()
+	return nil
+}
+
+// reloadIfModified reloads the CRLs if any of their files were modified
+func (v *CRLVerifier) reloadIfModified() error {
+	v.mu.RLock()
+	modified := false
+	for _, file := range v.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			v.mu.RUnlock()
+			return err
+		}
+		if !info.ModTime().Equal(v.modTimes[file]) {
+			modified = true
+			break
+		}
+	}
+	v.mu.RUnlock()
+
+	if !modified {
+		return nil
+	}
+	return v.load()
+}
+
+// IsRevoked returns true if the certificate has been revoked by its issuer
+func (v *CRLVerifier) IsRevoked(cert *x509.Certificate) bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	serials := v.revoked[cert.Issuer.ToRDNSequence().String()]
+	return serials[cert.SerialNumber.String()]
+}
+
+// IsExpired returns true if the CRL of the issuer of the certificate is past
+// its next update time at the given time
+func (v *CRLVerifier) IsExpired(cert *x509.Certificate, now time.Time) bool {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	nextUpdate, ok := v.nextUpdates[cert.Issuer.ToRDNSequence().String()]
+	return ok && now.After(nextUpdate)
+}
+
+// VerifyPeerCertificate can be set as tls.Config.VerifyPeerCertificate. It
+// fails the handshake if a certificate of any of the verified chains has
+// been revoked or has an expired CRL, or if a modified CRL can not be
+// loaded.
+func (v *CRLVerifier) VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	if err := v.reloadIfModified(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, chain := range verifiedChains {
+		for _, cert := range chain {
+			if v.IsRevoked(cert) {
+				return ErrCertRevoked
+			}
+			if v.IsExpired(cert, now) {
+				return ErrCRLExpired
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package tlsutils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) issue(t *testing.T, name string, serial int64) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+	return cert
+}
+
+func (ca *testCA) writeCRL(t *testing.T, file string, serials ...int64) {
+	ca.writeCRLUntil(t, file, time.Now().Add(time.Hour), serials...)
+}
+
+func (ca *testCA) writeCRLUntil(t *testing.T, file string, nextUpdate time.Time, serials ...int64) {
+	var revoked []pkix.RevokedCertificate
+	for _, serial := range serials {
+		revoked = append(revoked, pkix.RevokedCertificate{
+			SerialNumber:   big.NewInt(serial),
+			RevocationTime: time.Now(),
+		})
+	}
+	der, err := ca.cert.CreateCRL(rand.Reader, ca.key, revoked, time.Now(), nextUpdate)
+	require.Nil(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+	require.Nil(t, ioutil.WriteFile(file, data, 0600))
+}
+
+func TestLoadCerts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutils")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ca1 := newTestCA(t, "ca1")
+	ca2 := newTestCA(t, "ca2")
+	file := filepath.Join(dir, "ca.pem")
+	data := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca1.cert.Raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca2.cert.Raw})...)
+	require.Nil(t, ioutil.WriteFile(file, data, 0600))
+
+	certs, err := LoadCerts(file)
+	assert.Nil(t, err)
+	assert.Len(t, certs, 2)
+
+	empty := filepath.Join(dir, "empty.pem")
+	require.Nil(t, ioutil.WriteFile(empty, nil, 0600))
+	_, err = LoadCerts(file, empty)
+	assert.NotNil(t, err)
+
+	_, err = LoadCerts(filepath.Join(dir, "missing.pem"))
+	assert.NotNil(t, err)
+}
+
+func TestCRLVerifier(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutils")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ca1 := newTestCA(t, "ca1")
+	ca2 := newTestCA(t, "ca2")
+	issuers := []*x509.Certificate{ca1.cert, ca2.cert}
+
+	good := ca1.issue(t, "good", 10)
+	revoked := ca1.issue(t, "revoked", 11)
+	// Same serial as the revoked certificate, but from another issuer
+	other := ca2.issue(t, "other", 11)
+
+	crl := filepath.Join(dir, "ca1.crl")
+	ca1.writeCRL(t, crl, 11)
+
+	v, err := NewCRLVerifier(issuers, []string{crl})
+	require.Nil(t, err)
+
+	assert.False(t, v.IsRevoked(good))
+	assert.True(t, v.IsRevoked(revoked))
+	assert.False(t, v.IsRevoked(other))
+
+	assert.Nil(t, v.VerifyPeerCertificate(nil, [][]*x509.Certificate{{good, ca1.cert}}))
+	assert.Equal(t, ErrCertRevoked, v.VerifyPeerCertificate(nil, [][]*x509.Certificate{{revoked, ca1.cert}}))
+
+	// The CRL is reloaded once it is modified
+	ca1.writeCRL(t, crl, 10)
+	future := time.Now().Add(time.Minute)
+	require.Nil(t, os.Chtimes(crl, future, future))
+	assert.Equal(t, ErrCertRevoked, v.VerifyPeerCertificate(nil, [][]*x509.Certificate{{good, ca1.cert}}))
+	assert.Nil(t, v.VerifyPeerCertificate(nil, [][]*x509.Certificate{{revoked, ca1.cert}}))
+
+	// Certificates are rejected once the CRL of their issuer expires,
+	// until a newer CRL is loaded
+	assert.False(t, v.IsExpired(good, time.Now()))
+	assert.True(t, v.IsExpired(good, time.Now().Add(2*time.Hour)))
+	assert.False(t, v.IsExpired(other, time.Now().Add(2*time.Hour)))
+
+	ca1.writeCRLUntil(t, crl, time.Now().Add(-time.Minute))
+	future = future.Add(time.Minute)
+	require.Nil(t, os.Chtimes(crl, future, future))
+	assert.Equal(t, ErrCRLExpired, v.VerifyPeerCertificate(nil, [][]*x509.Certificate{{good, ca1.cert}}))
+	assert.Nil(t, v.VerifyPeerCertificate(nil, [][]*x509.Certificate{{other, ca2.cert}}))
+
+	ca1.writeCRL(t, crl)
+	future = future.Add(time.Minute)
+	require.Nil(t, os.Chtimes(crl, future, future))
+	assert.Nil(t, v.VerifyPeerCertificate(nil, [][]*x509.Certificate{{good, ca1.cert}}))
+
+	// A CRL of an issuer which is not trusted is rejected
+	ca3 := newTestCA(t, "ca3")
+	untrusted := filepath.Join(dir, "ca3.crl")
+	ca3.writeCRL(t, untrusted)
+	_, err = NewCRLVerifier(issuers, []string{untrusted})
+	assert.NotNil(t, err)
+}
@@ -51,3 +51,48 @@ type BlockVolumeGetResp struct {
 	GBID     string   `json:"gbid"`
 	Password string   `json:"password,omitempty"`
 }
+
+// BlockVolumeResizeRequest represents req Body for Block vol resize req
+type BlockVolumeResizeRequest struct {
+	// Size represents the new Block Volume size in bytes
+	Size uint64 `json:"size"`
+}
+
+// BlockVolumeResizeResp represents resp body for a Block Vol Resize req
+type BlockVolumeResizeResp BlockVolumeInfo
+
+// BlockSnapshotCreateRequest represents req Body for Block vol snapshot create req
+type BlockSnapshotCreateRequest struct {
+	SnapName string `json:"snapname"`
+}
+
+// BlockSnapshotInfo represents block volume snapshot info
+type BlockSnapshotInfo struct {
+	// Name represents Block Volume snapshot name
+	Name string `json:"name"`
+	// BlockVolume represents the name of the snapshotted Block Volume
+	BlockVolume   string `json:"blockvolume"`
+	HostingVolume string `json:"hostingvolume"`
+	// Size represents Block Volume snapshot size in bytes
+	Size uint64 `json:"size,omitempty"`
+}
+
+// BlockSnapshotCreateResp represents resp body for a Block Vol Snapshot Create req
+type BlockSnapshotCreateResp BlockSnapshotInfo
+
+// BlockSnapshotGetResp represents resp body for a Block Vol Snapshot Get req
+type BlockSnapshotGetResp BlockSnapshotInfo
+
+// BlockSnapshotListResp represents resp body for a Block Vol Snapshot List req
+type BlockSnapshotListResp []BlockSnapshotInfo
+
+// BlockCloneCreateRequest represents req Body for Block vol clone req
+type BlockCloneCreateRequest struct {
+	// CloneName represents the name of the new Block Volume
+	CloneName string `json:"clonename"`
+	// SnapName is optional, the Block Volume is cloned from this snapshot if set
+	SnapName string `json:"snapname,omitempty"`
+}
+
+// BlockCloneCreateResp represents resp body for a Block Vol Clone req
+type BlockCloneCreateResp BlockVolumeInfo
@@ -21,6 +21,12 @@ type Provider interface {
 	CreateBlockVolume(name string, size uint64, hostVolume string, options ...BlockVolOption) (BlockVolume, error)
 	DeleteBlockVolume(name string, options ...BlockVolOption) error
 	GetAndDeleteBlockVolume(name string, options ...BlockVolOption) (BlockVolume, error)
+	ResizeBlockVolume(name string, size uint64) (BlockVolume, error)
+	CreateBlockSnapshot(name string, snapName string) (BlockVolume, error)
+	GetBlockSnapshot(name string, snapName string) (BlockVolume, error)
+	BlockSnapshots(name string) ([]BlockVolume, error)
+	DeleteBlockSnapshot(name string, snapName string) error
+	CloneBlockVolume(name string, snapName string, cloneName string) (BlockVolume, error)
 	GetBlockVolume(id string) (BlockVolume, error)
 	BlockVolumes() []BlockVolume
 	ProviderName() string
@@ -148,6 +148,56 @@ func (g *GlusterBlock) deleteBlock(name string, options ...blockprovider.BlockVo
 	return blockInfo, err
 }
 
+// ResizeBlockVolume grows a gluster block volume to the given size
+func (g *GlusterBlock) ResizeBlockVolume(name string, size uint64) (blockprovider.BlockVolume, error) {
+	blockInfo, err := g.GetBlockVolume(name)
+	if err != nil || blockInfo == nil {
+		return nil, errors.ErrBlockVolNotFound
+	}
+
+	logger := log.WithFields(log.Fields{
+		"block_name":           name,
+		"hostvol":              blockInfo.HostVolume(),
+		"requested_block_size": size,
+	})
+
+	req := &api.BlockVolumeModifyReq{
+		Size: size,
+	}
+
+	if err := g.client.ModifyBlockVolume(blockInfo.HostVolume(), name, req); err != nil {
+		logger.WithError(err).Error("failed in resizing gluster block volume")
+		return nil, err
+	}
+
+	return g.GetBlockVolume(name)
+}
+
+// CreateBlockSnapshot is not supported by gluster-block
+func (g *GlusterBlock) CreateBlockSnapshot(name string, snapName string) (blockprovider.BlockVolume, error) {
+	return nil, errors.ErrBlockSnapNotSupported
+}
+
+// GetBlockSnapshot is not supported by gluster-block
+func (g *GlusterBlock) GetBlockSnapshot(name string, snapName string) (blockprovider.BlockVolume, error) {
+	return nil, errors.ErrBlockSnapNotSupported
+}
+
+// BlockSnapshots is not supported by gluster-block
+func (g *GlusterBlock) BlockSnapshots(name string) ([]blockprovider.BlockVolume, error) {
+	return nil, errors.ErrBlockSnapNotSupported
+}
+
+// DeleteBlockSnapshot is not supported by gluster-block
+func (g *GlusterBlock) DeleteBlockSnapshot(name string, snapName string) error {
+	return errors.ErrBlockSnapNotSupported
+}
+
+// CloneBlockVolume is not supported by gluster-block
+func (g *GlusterBlock) CloneBlockVolume(name string, snapName string, cloneName string) (blockprovider.BlockVolume, error) {
+	return nil, errors.ErrBlockSnapNotSupported
+}
+
 // GetBlockVolume gives info about a gluster block volume
 func (g *GlusterBlock) GetBlockVolume(name string) (blockprovider.BlockVolume, error) {
 	var (
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 
@@ -147,6 +148,16 @@ func (g *GlusterVirtBlk) deleteBlock(name string, options ...blockprovider.Block
 		return nil, errors.ErrBlockVolNotFound
 	}
 
+	// Snapshots are stored on the bhv along with the block volume, they
+	// have to be deleted first so that their space is released
+	snaps, err := g.BlockSnapshots(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(snaps) > 0 {
+		return nil, errors.ErrBlockVolHasSnapshots
+	}
+
 	hostName := blkVol.HostVolume()
 	hostDir, err := mountHost(g, hostName)
 	if err != nil {
@@ -161,9 +172,219 @@ func (g *GlusterVirtBlk) deleteBlock(name string, options ...blockprovider.Block
 		return nil, err
 	}
 
+	// Remove the leftovers of snapshots which failed to be created
+	snapDirName := snapshotDirName(hostDir, name)
+	if err := os.RemoveAll(snapDirName); err != nil {
+		log.WithError(err).Warnf("error removing block snapshot directory :%s", snapDirName)
+	}
+
 	return blkVol, nil
 }
 
+// ResizeBlockVolume grows the backing file of a gluster block volume to the given size
+func (g *GlusterVirtBlk) ResizeBlockVolume(name string, size uint64) (blockprovider.BlockVolume, error) {
+	blkVol, err := g.GetBlockVolume(name)
+	if err != nil || blkVol == nil {
+		return nil, errors.ErrBlockVolNotFound
+	}
+
+	hostName := blkVol.HostVolume()
+	hostDir, err := mountHost(g, hostName)
+	if err != nil {
+		log.WithError(err).Errorf("error mounting block hosting volume :%s", hostName)
+		return nil, err
+	}
+
+	blockFileName := hostDir + "/" + name
+	err = utils.ExecuteCommandRun("truncate", fmt.Sprintf("-s %d", size), blockFileName) //nolint: gosec
+	if err != nil {
+		log.WithError(err).Errorf("failed to truncate block file %s", blockFileName)
+		return nil, err
+	}
+
+	return &BlockVolume{
+		hostVolume: hostName,
+		name:       name,
+		size:       size,
+	}, nil
+}
+
+// snapshotDirName returns the path of the directory holding the snapshots of a block volume
+func snapshotDirName(hostDir string, name string) string {
+	return path.Join(hostDir, ".snapshots", name)
+}
+
+// snapshotFileName returns the path of the file backing a snapshot of a block volume
+func snapshotFileName(hostDir string, name string, snapName string) string {
+	return path.Join(snapshotDirName(hostDir, name), snapName)
+}
+
+// copyBlockFile copies a block file, sharing the data blocks with the source if
+// the filesystem supports reflinks and keeping the copy sparse otherwise
+func copyBlockFile(src string, dst string) error {
+	return utils.ExecuteCommandRun("cp", "--reflink=auto", "--sparse=always", src, dst) //nolint: gosec
+}
+
+// CreateBlockSnapshot creates a point in time copy of a gluster block volume.
+// Writes to the block volume while the copy is made may or may not be part of
+// the snapshot, the block volume should be frozen by its user for a consistent snapshot.
+func (g *GlusterVirtBlk) CreateBlockSnapshot(name string, snapName string) (blockprovider.BlockVolume, error) {
+	blkVol, err := g.GetBlockVolume(name)
+	if err != nil || blkVol == nil {
+		return nil, errors.ErrBlockVolNotFound
+	}
+
+	hostName := blkVol.HostVolume()
+	hostDir, err := mountHost(g, hostName)
+	if err != nil {
+		log.WithError(err).Errorf("error mounting block hosting volume :%s", hostName)
+		return nil, err
+	}
+
+	snapFileName := snapshotFileName(hostDir, name, snapName)
+	if err := os.MkdirAll(path.Dir(snapFileName), os.ModeDir|os.ModePerm); err != nil {
+		log.WithError(err).Error("failed to create snapshot directory")
+		return nil, err
+	}
+
+	if err := copyBlockFile(hostDir+"/"+name, snapFileName); err != nil {
+		log.WithError(err).Errorf("failed to create block snapshot %s", snapFileName)
+		return nil, err
+	}
+
+	return &BlockVolume{
+		hostVolume: hostName,
+		name:       snapName,
+		size:       blkVol.Size(),
+	}, nil
+}
+
+// GetBlockSnapshot gives info about a snapshot of a gluster block volume
+func (g *GlusterVirtBlk) GetBlockSnapshot(name string, snapName string) (blockprovider.BlockVolume, error) {
+	volumes, err := volume.GetVolumes(context.Background())
+	if err != nil {
+		return nil, errors.ErrBlockHostVolNotFound
+	}
+	volumes = volume.ApplyFilters(volumes, volume.BlockHosted)
+
+	for _, vols := range volumes {
+		v, found := vols.Metadata[volume.BlockSnapPrefix+name+"/"+snapName]
+		if !found {
+			continue
+		}
+		size, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &BlockVolume{
+			name:       snapName,
+			size:       size,
+			hostVolume: vols.Name,
+		}, nil
+	}
+
+	return nil, errors.ErrBlockSnapNotFound
+}
+
+// BlockSnapshots returns all the snapshots of a gluster block volume
+func (g *GlusterVirtBlk) BlockSnapshots(name string) ([]blockprovider.BlockVolume, error) {
+	var snaps = []blockprovider.BlockVolume{}
+
+	volumes, err := volume.GetVolumes(context.Background())
+	if err != nil {
+		return nil, errors.ErrBlockHostVolNotFound
+	}
+	volumes = volume.ApplyFilters(volumes, volume.BlockHosted)
+
+	prefix := volume.BlockSnapPrefix + name + "/"
+	for _, vols := range volumes {
+		for k, v := range vols.Metadata {
+			if !strings.HasPrefix(k, prefix) {
+				continue
+			}
+			size, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			snaps = append(snaps, &BlockVolume{
+				name:       strings.TrimPrefix(k, prefix),
+				size:       size,
+				hostVolume: vols.Name,
+			})
+		}
+	}
+
+	return snaps, nil
+}
+
+// DeleteBlockSnapshot deletes a snapshot of a gluster block volume
+func (g *GlusterVirtBlk) DeleteBlockSnapshot(name string, snapName string) error {
+	snap, err := g.GetBlockSnapshot(name, snapName)
+	if err != nil {
+		return err
+	}
+
+	hostName := snap.HostVolume()
+	hostDir, err := mountHost(g, hostName)
+	if err != nil {
+		log.WithError(err).Errorf("error mounting block hosting volume :%s", hostName)
+		return err
+	}
+
+	snapFileName := snapshotFileName(hostDir, name, snapName)
+	if err := os.Remove(snapFileName); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).Errorf("error removing block snapshot :%s", snapFileName)
+		return err
+	}
+
+	// Fails as long as the block volume has other snapshots
+	_ = os.Remove(snapshotDirName(hostDir, name))
+
+	return nil
+}
+
+// CloneBlockVolume creates a new gluster block volume from a gluster block
+// volume, or from one of its snapshots if snapName is not empty
+func (g *GlusterVirtBlk) CloneBlockVolume(name string, snapName string, cloneName string) (blockprovider.BlockVolume, error) {
+	var (
+		src blockprovider.BlockVolume
+		err error
+	)
+
+	if snapName != "" {
+		src, err = g.GetBlockSnapshot(name, snapName)
+	} else {
+		src, err = g.GetBlockVolume(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hostName := src.HostVolume()
+	hostDir, err := mountHost(g, hostName)
+	if err != nil {
+		log.WithError(err).Errorf("error mounting block hosting volume :%s", hostName)
+		return nil, err
+	}
+
+	srcFileName := hostDir + "/" + name
+	if snapName != "" {
+		srcFileName = snapshotFileName(hostDir, name, snapName)
+	}
+
+	cloneFileName := hostDir + "/" + cloneName
+	if err := copyBlockFile(srcFileName, cloneFileName); err != nil {
+		log.WithError(err).Errorf("failed to clone block file %s", srcFileName)
+		return nil, err
+	}
+
+	return &BlockVolume{
+		hostVolume: hostName,
+		name:       cloneName,
+		size:       src.Size(),
+	}, nil
+}
+
 // GetBlockVolume gives info about a gluster block volume
 func (g *GlusterVirtBlk) GetBlockVolume(name string) (blockprovider.BlockVolume, error) {
 	volumes, err := volume.GetVolumes(context.Background())
@@ -0,0 +1,51 @@
+package glustervirtblock
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSnapshotFileName validates snapshotDirName() and snapshotFileName()
+func TestSnapshotFileName(t *testing.T) {
+	hostDir := "/var/run/gluster/blockvolume/hostvol1"
+	assert.Equal(t, hostDir+"/.snapshots/blk1", snapshotDirName(hostDir, "blk1"))
+	assert.Equal(t, hostDir+"/.snapshots/blk1/snap1", snapshotFileName(hostDir, "blk1", "snap1"))
+}
+
+// TestCopyBlockFile validates copyBlockFile()
+func TestCopyBlockFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "virtblock")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	src := path.Join(dir, "blk1")
+	data := []byte("block volume data")
+	assert.Nil(t, ioutil.WriteFile(src, data, 0600))
+	// Keep a sparse tail, as a truncated block file has
+	assert.Nil(t, os.Truncate(src, 1<<20))
+
+	snap := snapshotFileName(dir, "blk1", "snap1")
+	assert.Nil(t, os.MkdirAll(path.Dir(snap), os.ModeDir|os.ModePerm))
+	assert.Nil(t, copyBlockFile(src, snap))
+
+	// The snapshot is a point in time copy, later writes to the block
+	// file are not seen in it
+	assert.Nil(t, ioutil.WriteFile(src, []byte("overwritten"), 0600))
+
+	content, err := ioutil.ReadFile(snap)
+	assert.Nil(t, err)
+	assert.Len(t, content, 1<<20)
+	assert.Equal(t, data, content[:len(data)])
+
+	clone := path.Join(dir, "clone1")
+	assert.Nil(t, copyBlockFile(snap, clone))
+	cloneContent, err := ioutil.ReadFile(clone)
+	assert.Nil(t, err)
+	assert.Equal(t, content, cloneContent)
+
+	assert.NotNil(t, copyBlockFile(path.Join(dir, "nonexistent"), clone))
+}
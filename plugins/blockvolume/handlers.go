@@ -2,8 +2,10 @@ package blockvolume
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
 	"github.com/gluster/glusterd2/plugins/blockvolume/api"
 	"github.com/gluster/glusterd2/plugins/blockvolume/blockprovider"
 
@@ -76,7 +78,10 @@ func (b *BlockVolume) DeleteVolume(w http.ResponseWriter, r *http.Request) {
 	}
 
 	blkVol, err := blockProvider.GetAndDeleteBlockVolume(pathParams["name"])
-	if err != nil {
+	if err == gderrors.ErrBlockVolHasSnapshots {
+		utils.SendHTTPError(r.Context(), w, http.StatusConflict, err)
+		return
+	} else if err != nil {
 		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
 		return
 	}
@@ -90,6 +95,280 @@ func (b *BlockVolume) DeleteVolume(w http.ResponseWriter, r *http.Request) {
 	utils.SendHTTPResponse(r.Context(), w, http.StatusNoContent, nil)
 }
 
+// ResizeVolume is a http Handler for growing a block volume
+func (b *BlockVolume) ResizeVolume(w http.ResponseWriter, r *http.Request) {
+	var (
+		req        = &api.BlockVolumeResizeRequest{}
+		resp       = &api.BlockVolumeResizeResp{}
+		pathParams = mux.Vars(r)
+	)
+
+	if err := utils.UnmarshalRequest(r, req); err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusBadRequest, err)
+		return
+	}
+
+	blockProvider, err := blockprovider.GetBlockProvider(pathParams["provider"])
+	if err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	blockVol, err := blockProvider.GetBlockVolume(pathParams["name"])
+	if err == gderrors.ErrBlockVolNotFound {
+		utils.SendHTTPError(r.Context(), w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if req.Size <= blockVol.Size() {
+		utils.SendHTTPError(r.Context(), w, http.StatusBadRequest, "block volume can only be grown, requested size must be greater than the current size")
+		return
+	}
+
+	oldSize, err := b.hostVolManager.ResizeBlockInfoInBHV(blockVol.HostVolume(), blockVol.Name(), req.Size)
+	if err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resizedVol, err := blockProvider.ResizeBlockVolume(blockVol.Name(), req.Size)
+	if err != nil {
+		_, _ = b.hostVolManager.ResizeBlockInfoInBHV(blockVol.HostVolume(), blockVol.Name(), oldSize)
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	{
+		resp.Name = resizedVol.Name()
+		resp.HostingVolume = resizedVol.HostVolume()
+		resp.Size = resizedVol.Size()
+		resp.HaCount = resizedVol.HaCount()
+	}
+
+	utils.SendHTTPResponse(r.Context(), w, http.StatusOK, resp)
+}
+
+// CreateSnapshot is a http Handler for creating a snapshot of a block volume
+func (b *BlockVolume) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	var (
+		req        = &api.BlockSnapshotCreateRequest{}
+		resp       = &api.BlockSnapshotCreateResp{}
+		pathParams = mux.Vars(r)
+	)
+
+	if err := utils.UnmarshalRequest(r, req); err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.SnapName == "" || strings.Contains(req.SnapName, "/") {
+		utils.SendHTTPError(r.Context(), w, http.StatusBadRequest, "invalid snapshot name")
+		return
+	}
+
+	blockProvider, err := blockprovider.GetBlockProvider(pathParams["provider"])
+	if err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	blockVol, err := blockProvider.GetBlockVolume(pathParams["name"])
+	if err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	err = b.hostVolManager.AddBlockSnapshotToBHV(blockVol.HostVolume(), blockVol.Name(), req.SnapName, blockVol.Size())
+	if err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	snap, err := blockProvider.CreateBlockSnapshot(blockVol.Name(), req.SnapName)
+	if err != nil {
+		_ = b.hostVolManager.DeleteBlockSnapshotFromBHV(blockVol.HostVolume(), blockVol.Name(), req.SnapName, blockVol.Size())
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	{
+		resp.Name = snap.Name()
+		resp.BlockVolume = blockVol.Name()
+		resp.HostingVolume = snap.HostVolume()
+		resp.Size = snap.Size()
+	}
+
+	utils.SendHTTPResponse(r.Context(), w, http.StatusCreated, resp)
+}
+
+// GetSnapshot is a http Handler for getting info about a snapshot of a block volume
+func (b *BlockVolume) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	var (
+		pathParams = mux.Vars(r)
+		resp       = &api.BlockSnapshotGetResp{}
+	)
+
+	blockProvider, err := blockprovider.GetBlockProvider(pathParams["provider"])
+	if err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	snap, err := blockProvider.GetBlockSnapshot(pathParams["name"], pathParams["snapname"])
+	if err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	{
+		resp.Name = snap.Name()
+		resp.BlockVolume = pathParams["name"]
+		resp.HostingVolume = snap.HostVolume()
+		resp.Size = snap.Size()
+	}
+
+	utils.SendHTTPResponse(r.Context(), w, http.StatusOK, resp)
+}
+
+// ListSnapshots is a http Handler for listing the snapshots of a block volume
+func (b *BlockVolume) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	var (
+		pathParams = mux.Vars(r)
+		resp       = api.BlockSnapshotListResp{}
+	)
+
+	blockProvider, err := blockprovider.GetBlockProvider(pathParams["provider"])
+	if err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	snaps, err := blockProvider.BlockSnapshots(pathParams["name"])
+	if err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	for _, snap := range snaps {
+		resp = append(resp, api.BlockSnapshotInfo{
+			Name:          snap.Name(),
+			BlockVolume:   pathParams["name"],
+			HostingVolume: snap.HostVolume(),
+			Size:          snap.Size(),
+		})
+	}
+
+	utils.SendHTTPResponse(r.Context(), w, http.StatusOK, resp)
+}
+
+// DeleteSnapshot is a http Handler for deleting a snapshot of a block volume
+func (b *BlockVolume) DeleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	pathParams := mux.Vars(r)
+	blockProvider, err := blockprovider.GetBlockProvider(pathParams["provider"])
+	if err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	snap, err := blockProvider.GetBlockSnapshot(pathParams["name"], pathParams["snapname"])
+	if err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	if err := blockProvider.DeleteBlockSnapshot(pathParams["name"], pathParams["snapname"]); err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	err = b.hostVolManager.DeleteBlockSnapshotFromBHV(snap.HostVolume(), pathParams["name"], pathParams["snapname"], snap.Size())
+	if err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	utils.SendHTTPResponse(r.Context(), w, http.StatusNoContent, nil)
+}
+
+// CloneVolume is a http Handler for creating a block volume from a block
+// volume or from one of its snapshots
+func (b *BlockVolume) CloneVolume(w http.ResponseWriter, r *http.Request) {
+	var (
+		req        = &api.BlockCloneCreateRequest{}
+		resp       = &api.BlockCloneCreateResp{}
+		pathParams = mux.Vars(r)
+		src        blockprovider.BlockVolume
+	)
+
+	if err := utils.UnmarshalRequest(r, req); err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.CloneName == "" || strings.Contains(req.CloneName, "/") {
+		utils.SendHTTPError(r.Context(), w, http.StatusBadRequest, "invalid clone name")
+		return
+	}
+
+	blockProvider, err := blockprovider.GetBlockProvider(pathParams["provider"])
+	if err != nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if _, err := blockProvider.GetBlockVolume(req.CloneName); err == nil {
+		utils.SendHTTPError(r.Context(), w, http.StatusConflict, gderrors.ErrBlockVolExists)
+		return
+	}
+
+	source := pathParams["name"]
+	if req.SnapName != "" {
+		src, err = blockProvider.GetBlockSnapshot(pathParams["name"], req.SnapName)
+		source += "/" + req.SnapName
+	} else {
+		src, err = blockProvider.GetBlockVolume(pathParams["name"])
+	}
+	if err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	err = b.hostVolManager.AddBlockCloneToBHV(src.HostVolume(), req.CloneName, source, src.Size())
+	if err != nil {
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	cloneVol, err := blockProvider.CloneBlockVolume(pathParams["name"], req.SnapName, req.CloneName)
+	if err != nil {
+		_ = b.hostVolManager.DeleteBlockInfoFromBHV(src.HostVolume(), req.CloneName, src.Size())
+		status, err := utils.ErrToStatusCode(err)
+		utils.SendHTTPError(r.Context(), w, status, err)
+		return
+	}
+
+	{
+		resp.Name = cloneVol.Name()
+		resp.HostingVolume = cloneVol.HostVolume()
+		resp.Size = cloneVol.Size()
+	}
+
+	utils.SendHTTPResponse(r.Context(), w, http.StatusCreated, resp)
+}
+
 // ListBlockVolumes is a http handler for listing all available block volumes
 func (b *BlockVolume) ListBlockVolumes(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -0,0 +1,247 @@
+package blockvolume
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+	"github.com/gluster/glusterd2/plugins/blockvolume/api"
+	"github.com/gluster/glusterd2/plugins/blockvolume/blockprovider"
+	"github.com/gluster/glusterd2/plugins/blockvolume/hostvol"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBlockVolume struct {
+	blockprovider.BlockVolume
+	name    string
+	hostVol string
+	size    uint64
+	haCount int
+}
+
+func (f *fakeBlockVolume) Name() string       { return f.name }
+func (f *fakeBlockVolume) HostVolume() string { return f.hostVol }
+func (f *fakeBlockVolume) Size() uint64       { return f.size }
+func (f *fakeBlockVolume) HaCount() int       { return f.haCount }
+
+// fakeProvider implements only the parts of blockprovider.Provider used by
+// the handlers under test
+type fakeProvider struct {
+	blockprovider.Provider
+	vols  map[string]*fakeBlockVolume
+	snaps map[string]*fakeBlockVolume
+	// snapErr is returned by all snapshot and clone operations if set
+	snapErr error
+}
+
+func (f *fakeProvider) GetBlockVolume(name string) (blockprovider.BlockVolume, error) {
+	vol, ok := f.vols[name]
+	if !ok {
+		return nil, gderrors.ErrBlockVolNotFound
+	}
+	return vol, nil
+}
+
+func (f *fakeProvider) ResizeBlockVolume(name string, size uint64) (blockprovider.BlockVolume, error) {
+	vol, ok := f.vols[name]
+	if !ok {
+		return nil, gderrors.ErrBlockVolNotFound
+	}
+	vol.size = size
+	return vol, nil
+}
+
+func (f *fakeProvider) CreateBlockSnapshot(name string, snapName string) (blockprovider.BlockVolume, error) {
+	if f.snapErr != nil {
+		return nil, f.snapErr
+	}
+	vol := f.vols[name]
+	snap := &fakeBlockVolume{name: snapName, hostVol: vol.hostVol, size: vol.size}
+	f.snaps[name+"/"+snapName] = snap
+	return snap, nil
+}
+
+func (f *fakeProvider) GetBlockSnapshot(name string, snapName string) (blockprovider.BlockVolume, error) {
+	if f.snapErr != nil {
+		return nil, f.snapErr
+	}
+	snap, ok := f.snaps[name+"/"+snapName]
+	if !ok {
+		return nil, gderrors.ErrBlockSnapNotFound
+	}
+	return snap, nil
+}
+
+func (f *fakeProvider) DeleteBlockSnapshot(name string, snapName string) error {
+	if f.snapErr != nil {
+		return f.snapErr
+	}
+	delete(f.snaps, name+"/"+snapName)
+	return nil
+}
+
+func (f *fakeProvider) CloneBlockVolume(name string, snapName string, cloneName string) (blockprovider.BlockVolume, error) {
+	if f.snapErr != nil {
+		return nil, f.snapErr
+	}
+	vol := f.vols[name]
+	clone := &fakeBlockVolume{name: cloneName, hostVol: vol.hostVol, size: vol.size}
+	f.vols[cloneName] = clone
+	return clone, nil
+}
+
+type fakeHostVolManager struct {
+	hostvol.HostingVolumeManager
+	sizes map[string]uint64
+}
+
+func (f *fakeHostVolManager) ResizeBlockInfoInBHV(hostVol string, blkName string, size uint64) (uint64, error) {
+	oldSize := f.sizes[blkName]
+	f.sizes[blkName] = size
+	return oldSize, nil
+}
+
+func (f *fakeHostVolManager) AddBlockSnapshotToBHV(hostVol string, blkName string, snapName string, size uint64) error {
+	f.sizes[blkName+"/"+snapName] = size
+	return nil
+}
+
+func (f *fakeHostVolManager) DeleteBlockSnapshotFromBHV(hostVol string, blkName string, snapName string, size uint64) error {
+	delete(f.sizes, blkName+"/"+snapName)
+	return nil
+}
+
+func (f *fakeHostVolManager) AddBlockCloneToBHV(hostVol string, cloneName string, source string, size uint64) error {
+	f.sizes[cloneName] = size
+	return nil
+}
+
+func (f *fakeHostVolManager) DeleteBlockInfoFromBHV(hostVol string, blkName string, size uint64) error {
+	delete(f.sizes, blkName)
+	return nil
+}
+
+// registerFakeProvider registers a fake block provider under the given name
+// with a single block volume "blk1" of 1GiB hosted on "hostvol1"
+func registerFakeProvider(name string) *fakeProvider {
+	p := &fakeProvider{
+		vols: map[string]*fakeBlockVolume{
+			"blk1": {name: "blk1", hostVol: "hostvol1", size: 1 << 30, haCount: 1},
+		},
+		snaps: map[string]*fakeBlockVolume{},
+	}
+	blockprovider.RegisterBlockProvider(name, func() (blockprovider.Provider, error) {
+		return p, nil
+	})
+	return p
+}
+
+func serveBlockRequest(handler http.HandlerFunc, method, pattern, url string, body interface{}) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	router := mux.NewRouter()
+	router.HandleFunc(pattern, handler).Methods(method)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, url, bytes.NewReader(data)))
+	return w
+}
+
+// TestResizeVolume validates the block volume resize handler
+func TestResizeVolume(t *testing.T) {
+	p := registerFakeProvider("fake-resize")
+	hostVolManager := &fakeHostVolManager{sizes: map[string]uint64{"blk1": 1 << 30}}
+	b := &BlockVolume{hostVolManager: hostVolManager}
+
+	const pattern = "/blockvolumes/{provider}/{name}"
+
+	// Shrinking is rejected and nothing is changed
+	w := serveBlockRequest(b.ResizeVolume, http.MethodPatch, pattern,
+		"/blockvolumes/fake-resize/blk1", api.BlockVolumeResizeRequest{Size: 1 << 29})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, uint64(1<<30), p.vols["blk1"].size)
+	assert.Equal(t, uint64(1<<30), hostVolManager.sizes["blk1"])
+
+	// Resizing to the current size is rejected too
+	w = serveBlockRequest(b.ResizeVolume, http.MethodPatch, pattern,
+		"/blockvolumes/fake-resize/blk1", api.BlockVolumeResizeRequest{Size: 1 << 30})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serveBlockRequest(b.ResizeVolume, http.MethodPatch, pattern,
+		"/blockvolumes/fake-resize/nonexistent", api.BlockVolumeResizeRequest{Size: 1 << 31})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serveBlockRequest(b.ResizeVolume, http.MethodPatch, pattern,
+		"/blockvolumes/fake-resize/blk1", api.BlockVolumeResizeRequest{Size: 1 << 31})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp api.BlockVolumeResizeResp
+	assert.Nil(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "blk1", resp.Name)
+	assert.Equal(t, "hostvol1", resp.HostingVolume)
+	assert.Equal(t, uint64(1<<31), resp.Size)
+	assert.Equal(t, uint64(1<<31), hostVolManager.sizes["blk1"])
+}
+
+// TestSnapshotStatusCodes validates the status codes of the block snapshot and clone handlers
+func TestSnapshotStatusCodes(t *testing.T) {
+	p := registerFakeProvider("fake-snap")
+	hostVolManager := &fakeHostVolManager{sizes: map[string]uint64{"blk1": 1 << 30}}
+	b := &BlockVolume{hostVolManager: hostVolManager}
+
+	const (
+		snapPattern     = "/blockvolumes/{provider}/{name}/snapshot"
+		snapNamePattern = "/blockvolumes/{provider}/{name}/snapshot/{snapname}"
+		clonePattern    = "/blockvolumes/{provider}/{name}/clone"
+	)
+
+	w := serveBlockRequest(b.CreateSnapshot, http.MethodPost, snapPattern,
+		"/blockvolumes/fake-snap/nonexistent/snapshot", api.BlockSnapshotCreateRequest{SnapName: "snap1"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serveBlockRequest(b.CreateSnapshot, http.MethodPost, snapPattern,
+		"/blockvolumes/fake-snap/blk1/snapshot", api.BlockSnapshotCreateRequest{SnapName: "snap1"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, uint64(1<<30), hostVolManager.sizes["blk1/snap1"])
+
+	w = serveBlockRequest(b.CloneVolume, http.MethodPost, clonePattern,
+		"/blockvolumes/fake-snap/blk1/clone", api.BlockCloneCreateRequest{CloneName: "clone1", SnapName: "snap1"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, uint64(1<<30), hostVolManager.sizes["clone1"])
+
+	w = serveBlockRequest(b.DeleteSnapshot, http.MethodDelete, snapNamePattern,
+		"/blockvolumes/fake-snap/blk1/snapshot/snap1", nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	// Not found is reported the same way by get and delete
+	w = serveBlockRequest(b.GetSnapshot, http.MethodGet, snapNamePattern,
+		"/blockvolumes/fake-snap/blk1/snapshot/snap1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = serveBlockRequest(b.DeleteSnapshot, http.MethodDelete, snapNamePattern,
+		"/blockvolumes/fake-snap/blk1/snapshot/snap1", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Providers without snapshot support
+	p.snapErr = gderrors.ErrBlockSnapNotSupported
+
+	w = serveBlockRequest(b.CreateSnapshot, http.MethodPost, snapPattern,
+		"/blockvolumes/fake-snap/blk1/snapshot", api.BlockSnapshotCreateRequest{SnapName: "snap2"})
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+	// The space reserved for the snapshot is released
+	_, found := hostVolManager.sizes["blk1/snap2"]
+	assert.False(t, found)
+
+	w = serveBlockRequest(b.DeleteSnapshot, http.MethodDelete, snapNamePattern,
+		"/blockvolumes/fake-snap/blk1/snapshot/snap2", nil)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+
+	w = serveBlockRequest(b.CloneVolume, http.MethodPost, clonePattern,
+		"/blockvolumes/fake-snap/blk1/clone", api.BlockCloneCreateRequest{CloneName: "clone2"})
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+	_, found = hostVolManager.sizes["clone2"]
+	assert.False(t, found)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/gluster/glusterd2/glusterd2/peer"
 	"github.com/gluster/glusterd2/glusterd2/transaction"
 	"github.com/gluster/glusterd2/glusterd2/volume"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
 	"github.com/gluster/glusterd2/plugins/blockvolume/api"
 	config "github.com/spf13/viper"
 
@@ -30,6 +31,10 @@ type HostingVolumeManager interface {
 	GetHostingVolumesInUse() []*volume.Volinfo
 	GetOrCreateHostingVolume(name string, blkName string, minSizeLimit uint64, hostVolumeInfo *api.HostVolumeInfo) (*volume.Volinfo, error)
 	DeleteBlockInfoFromBHV(hostVol string, blkName string, size uint64) error
+	ResizeBlockInfoInBHV(hostVol string, blkName string, size uint64) (uint64, error)
+	AddBlockSnapshotToBHV(hostVol string, blkName string, snapName string, size uint64) error
+	DeleteBlockSnapshotFromBHV(hostVol string, blkName string, snapName string, size uint64) error
+	AddBlockCloneToBHV(hostVol string, cloneName string, source string, size uint64) error
 }
 
 // GlusterVolManager is a concrete implementation of HostingVolumeManager
@@ -199,6 +204,28 @@ func (g *GlusterVolManager) updateBhvInfoAndSize(hostVolume string, blkName stri
 	return volInfo, nil
 }
 
+// deleteBlockEntries deletes the entries of block blkName from the metadata of
+// the bhv. It returns the size of the snapshot entries deleted along with it.
+func deleteBlockEntries(volInfo *volume.Volinfo, blkName string) uint64 {
+	var snapsSize uint64
+
+	for k, v := range volInfo.Metadata {
+		if k == (volume.BlockPrefix+blkName) || k == (volume.BlockClonePrefix+blkName) {
+			delete(volInfo.Metadata, k)
+		}
+		// The snapshots of a block are deleted before the block, release
+		// the space of any snapshot entry left behind along with the block
+		if strings.HasPrefix(k, volume.BlockSnapPrefix+blkName+"/") {
+			if snapSize, err := strconv.ParseUint(v, 10, 64); err == nil {
+				snapsSize += snapSize
+			}
+			delete(volInfo.Metadata, k)
+		}
+	}
+
+	return snapsSize
+}
+
 // DeleteBlockInfoFromBHV resets the available space on the bhv and also deletes the block entry in the metadata of the bhv
 // In this function, if the bhv is empty i.e. there are no blocks, then the bhv delete is initiated
 func (g *GlusterVolManager) DeleteBlockInfoFromBHV(hostVol string, blkName string, size uint64) error {
@@ -218,11 +245,7 @@ func (g *GlusterVolManager) DeleteBlockInfoFromBHV(hostVol string, blkName strin
 		return err
 	}
 
-	for k := range volInfo.Metadata {
-		if k == (volume.BlockPrefix + blkName) {
-			delete(volInfo.Metadata, k)
-		}
-	}
+	size += deleteBlockEntries(volInfo, blkName)
 
 	resizeFunc := func(blockHostingAvailableSize, blockSize uint64) uint64 { return blockHostingAvailableSize + blockSize }
 	if err = UpdateBlockHostingVolumeSize(volInfo, size, resizeFunc); err != nil {
@@ -260,6 +283,171 @@ func (g *GlusterVolManager) DeleteBlockInfoFromBHV(hostVol string, blkName strin
 	return nil
 }
 
+// ResizeBlockInfoInBHV updates the size of the block entry in the metadata of the bhv and
+// reserves or releases the difference in the available space on the bhv. It returns the old size of the block.
+func (g *GlusterVolManager) ResizeBlockInfoInBHV(hostVol string, blkName string, size uint64) (uint64, error) {
+	var (
+		clusterLocks = transaction.Locks{}
+		key          = volume.BlockPrefix + blkName
+	)
+
+	if err := clusterLocks.Lock(hostVol); err != nil {
+		log.WithError(err).Error("error in acquiring cluster lock")
+		return 0, err
+	}
+	defer clusterLocks.UnLock(context.Background())
+
+	volInfo, err := volume.GetVolume(hostVol)
+	if err != nil {
+		log.WithError(err).Errorf("failed to get host volume info %s", hostVol)
+		return 0, err
+	}
+
+	if _, found := volInfo.Metadata[key]; !found {
+		return 0, fmt.Errorf("block %s not found in host volume %s", blkName, hostVol)
+	}
+
+	oldSize, err := strconv.ParseUint(volInfo.Metadata[key], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	availableSizeInBytes, err := strconv.ParseUint(volInfo.Metadata[volume.BlockHostingAvailableSize], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if size > oldSize && availableSizeInBytes < size-oldSize {
+		return 0, fmt.Errorf("available size is less than requested size,request size: %d, available size: %d", size-oldSize, availableSizeInBytes)
+	}
+
+	resizeFunc := func(blockHostingAvailableSize, blockSize uint64) uint64 {
+		return blockHostingAvailableSize + oldSize - blockSize
+	}
+	if err = UpdateBlockHostingVolumeSize(volInfo, size, resizeFunc); err != nil {
+		log.WithError(err).Error("failed in updating hostvolume _block-hosting-available-size metadata")
+		return 0, err
+	}
+	volInfo.Metadata[key] = strconv.FormatUint(size, 10)
+
+	if err := volume.AddOrUpdateVolume(volInfo); err != nil {
+		log.WithError(err).Error("failed in updating volume info to store")
+		return 0, err
+	}
+
+	return oldSize, nil
+}
+
+// updateBHVMetadata applies updateFunc on the volinfo of the bhv and saves it to the store, with the bhv locked
+func updateBHVMetadata(hostVol string, updateFunc func(volInfo *volume.Volinfo) error) error {
+	clusterLocks := transaction.Locks{}
+
+	if err := clusterLocks.Lock(hostVol); err != nil {
+		log.WithError(err).Error("error in acquiring cluster lock")
+		return err
+	}
+	defer clusterLocks.UnLock(context.Background())
+
+	volInfo, err := volume.GetVolume(hostVol)
+	if err != nil {
+		log.WithError(err).Errorf("failed to get host volume info %s", hostVol)
+		return err
+	}
+
+	if err := updateFunc(volInfo); err != nil {
+		return err
+	}
+
+	if err := volume.AddOrUpdateVolume(volInfo); err != nil {
+		log.WithError(err).Error("failed in updating volume info to store")
+		return err
+	}
+	return nil
+}
+
+// reserveBlockSize reserves size bytes of the available space on the bhv
+func reserveBlockSize(volInfo *volume.Volinfo, size uint64) error {
+	availableSizeInBytes, err := strconv.ParseUint(volInfo.Metadata[volume.BlockHostingAvailableSize], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	if availableSizeInBytes < size {
+		return fmt.Errorf("available size is less than requested size,request size: %d, available size: %d", size, availableSizeInBytes)
+	}
+
+	resizeFunc := func(blockHostingAvailableSize, blockSize uint64) uint64 { return blockHostingAvailableSize - blockSize }
+	return UpdateBlockHostingVolumeSize(volInfo, size, resizeFunc)
+}
+
+// addBlockSnapshotInfo adds the entry of snapshot snapName of block blkName to
+// the metadata of the bhv and reserves size bytes of its available space
+func addBlockSnapshotInfo(volInfo *volume.Volinfo, blkName string, snapName string, size uint64) error {
+	key := volume.BlockSnapPrefix + blkName + "/" + snapName
+	if _, found := volInfo.Metadata[key]; found {
+		return gderrors.ErrBlockSnapExists
+	}
+
+	if err := reserveBlockSize(volInfo, size); err != nil {
+		return err
+	}
+
+	volInfo.Metadata[key] = strconv.FormatUint(size, 10)
+	return nil
+}
+
+// deleteBlockSnapshotInfo deletes the entry of snapshot snapName of block blkName
+// from the metadata of the bhv and releases size bytes of its available space
+func deleteBlockSnapshotInfo(volInfo *volume.Volinfo, blkName string, snapName string, size uint64) error {
+	key := volume.BlockSnapPrefix + blkName + "/" + snapName
+	if _, found := volInfo.Metadata[key]; !found {
+		return gderrors.ErrBlockSnapNotFound
+	}
+	delete(volInfo.Metadata, key)
+
+	resizeFunc := func(blockHostingAvailableSize, blockSize uint64) uint64 { return blockHostingAvailableSize + blockSize }
+	return UpdateBlockHostingVolumeSize(volInfo, size, resizeFunc)
+}
+
+// addBlockCloneInfo adds the entry of block cloneName cloned from source to the
+// metadata of the bhv and reserves size bytes of its available space
+func addBlockCloneInfo(volInfo *volume.Volinfo, cloneName string, source string, size uint64) error {
+	key := volume.BlockPrefix + cloneName
+	if _, found := volInfo.Metadata[key]; found {
+		return gderrors.ErrBlockVolExists
+	}
+
+	if err := reserveBlockSize(volInfo, size); err != nil {
+		return err
+	}
+
+	volInfo.Metadata[key] = strconv.FormatUint(size, 10)
+	volInfo.Metadata[volume.BlockClonePrefix+cloneName] = source
+	return nil
+}
+
+// AddBlockSnapshotToBHV adds the snapshot entry of a block in the metadata of the bhv and reserves the space used by the snapshot
+func (g *GlusterVolManager) AddBlockSnapshotToBHV(hostVol string, blkName string, snapName string, size uint64) error {
+	return updateBHVMetadata(hostVol, func(volInfo *volume.Volinfo) error {
+		return addBlockSnapshotInfo(volInfo, blkName, snapName, size)
+	})
+}
+
+// DeleteBlockSnapshotFromBHV deletes the snapshot entry of a block from the metadata of the bhv and releases the space used by the snapshot
+func (g *GlusterVolManager) DeleteBlockSnapshotFromBHV(hostVol string, blkName string, snapName string, size uint64) error {
+	return updateBHVMetadata(hostVol, func(volInfo *volume.Volinfo) error {
+		return deleteBlockSnapshotInfo(volInfo, blkName, snapName, size)
+	})
+}
+
+// AddBlockCloneToBHV adds the entry of a block cloned from source in the metadata of the bhv and reserves the space used by the clone.
+// The clone is deleted like any other block using DeleteBlockInfoFromBHV
+func (g *GlusterVolManager) AddBlockCloneToBHV(hostVol string, cloneName string, source string, size uint64) error {
+	return updateBHVMetadata(hostVol, func(volInfo *volume.Volinfo) error {
+		return addBlockCloneInfo(volInfo, cloneName, source, size)
+	})
+}
+
 // RegisterBHVstepFunctions registers the functions for the transaction
 func RegisterBHVstepFunctions() {
 	var sfs = []struct {
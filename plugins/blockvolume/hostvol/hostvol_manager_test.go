@@ -0,0 +1,71 @@
+package hostvol
+
+import (
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBHV() *volume.Volinfo {
+	return &volume.Volinfo{
+		Name: "hostvol1",
+		Metadata: map[string]string{
+			volume.BlockHostingAvailableSize: "1000",
+			volume.BlockPrefix + "blk1":      "100",
+		},
+	}
+}
+
+// TestBlockSnapshotInfo validates addBlockSnapshotInfo() and deleteBlockSnapshotInfo()
+func TestBlockSnapshotInfo(t *testing.T) {
+	volInfo := newBHV()
+
+	assert.Nil(t, addBlockSnapshotInfo(volInfo, "blk1", "snap1", 100))
+	assert.Equal(t, "100", volInfo.Metadata[volume.BlockSnapPrefix+"blk1/snap1"])
+	assert.Equal(t, "900", volInfo.Metadata[volume.BlockHostingAvailableSize])
+
+	assert.Equal(t, gderrors.ErrBlockSnapExists, addBlockSnapshotInfo(volInfo, "blk1", "snap1", 100))
+	assert.Equal(t, "900", volInfo.Metadata[volume.BlockHostingAvailableSize])
+
+	// Not enough space left for the snapshot
+	assert.NotNil(t, addBlockSnapshotInfo(volInfo, "blk1", "snap2", 1000))
+	_, found := volInfo.Metadata[volume.BlockSnapPrefix+"blk1/snap2"]
+	assert.False(t, found)
+
+	assert.Nil(t, deleteBlockSnapshotInfo(volInfo, "blk1", "snap1", 100))
+	_, found = volInfo.Metadata[volume.BlockSnapPrefix+"blk1/snap1"]
+	assert.False(t, found)
+	assert.Equal(t, "1000", volInfo.Metadata[volume.BlockHostingAvailableSize])
+
+	assert.Equal(t, gderrors.ErrBlockSnapNotFound, deleteBlockSnapshotInfo(volInfo, "blk1", "snap1", 100))
+	assert.Equal(t, "1000", volInfo.Metadata[volume.BlockHostingAvailableSize])
+}
+
+// TestBlockCloneInfo validates addBlockCloneInfo() and deleteBlockEntries()
+func TestBlockCloneInfo(t *testing.T) {
+	volInfo := newBHV()
+
+	assert.Nil(t, addBlockSnapshotInfo(volInfo, "blk1", "snap1", 100))
+	assert.Nil(t, addBlockCloneInfo(volInfo, "clone1", "blk1/snap1", 100))
+	assert.Equal(t, "100", volInfo.Metadata[volume.BlockPrefix+"clone1"])
+	assert.Equal(t, "blk1/snap1", volInfo.Metadata[volume.BlockClonePrefix+"clone1"])
+	assert.Equal(t, "800", volInfo.Metadata[volume.BlockHostingAvailableSize])
+
+	assert.Equal(t, gderrors.ErrBlockVolExists, addBlockCloneInfo(volInfo, "blk1", "clone1", 100))
+	assert.Equal(t, "800", volInfo.Metadata[volume.BlockHostingAvailableSize])
+
+	// Deleting the clone leaves its source and the source's snapshot alone
+	assert.Equal(t, uint64(0), deleteBlockEntries(volInfo, "clone1"))
+	_, found := volInfo.Metadata[volume.BlockPrefix+"clone1"]
+	assert.False(t, found)
+	_, found = volInfo.Metadata[volume.BlockClonePrefix+"clone1"]
+	assert.False(t, found)
+	assert.Equal(t, "100", volInfo.Metadata[volume.BlockSnapPrefix+"blk1/snap1"])
+
+	// The snapshot entries left behind are deleted along with the block
+	assert.Equal(t, uint64(100), deleteBlockEntries(volInfo, "blk1"))
+	assert.Len(t, volInfo.Metadata, 1)
+}
@@ -42,6 +42,56 @@ func (b *BlockVolume) RestRoutes() route.Routes {
 			Version:     1,
 			HandlerFunc: b.DeleteVolume,
 		},
+		{
+			Name:         "BlockResize",
+			Method:       http.MethodPatch,
+			Pattern:      "/blockvolumes/{provider}/{name}",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.BlockVolumeResizeRequest)(nil)),
+			ResponseType: utils.GetTypeString((*api.BlockVolumeResizeResp)(nil)),
+			HandlerFunc:  b.ResizeVolume,
+		},
+		{
+			Name:         "BlockSnapshotCreate",
+			Method:       http.MethodPost,
+			Pattern:      "/blockvolumes/{provider}/{name}/snapshot",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.BlockSnapshotCreateRequest)(nil)),
+			ResponseType: utils.GetTypeString((*api.BlockSnapshotCreateResp)(nil)),
+			HandlerFunc:  b.CreateSnapshot,
+		},
+		{
+			Name:         "BlockSnapshotList",
+			Method:       http.MethodGet,
+			Pattern:      "/blockvolumes/{provider}/{name}/snapshot",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.BlockSnapshotListResp)(nil)),
+			HandlerFunc:  b.ListSnapshots,
+		},
+		{
+			Name:         "BlockSnapshotGet",
+			Method:       http.MethodGet,
+			Pattern:      "/blockvolumes/{provider}/{name}/snapshot/{snapname}",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*api.BlockSnapshotGetResp)(nil)),
+			HandlerFunc:  b.GetSnapshot,
+		},
+		{
+			Name:        "BlockSnapshotDelete",
+			Method:      http.MethodDelete,
+			Pattern:     "/blockvolumes/{provider}/{name}/snapshot/{snapname}",
+			Version:     1,
+			HandlerFunc: b.DeleteSnapshot,
+		},
+		{
+			Name:         "BlockClone",
+			Method:       http.MethodPost,
+			Pattern:      "/blockvolumes/{provider}/{name}/clone",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*api.BlockCloneCreateRequest)(nil)),
+			ResponseType: utils.GetTypeString((*api.BlockCloneCreateResp)(nil)),
+			HandlerFunc:  b.CloneVolume,
+		},
 		{
 			Name:        "BlockList",
 			Method:      http.MethodGet,
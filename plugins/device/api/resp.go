@@ -27,5 +27,8 @@ func (info *Info) VgName() string {
 // AddDeviceResp is the success response sent to a AddDeviceReq request
 type AddDeviceResp Info
 
+// ResizeDeviceResp is the success response sent to a ResizeDevice request
+type ResizeDeviceResp Info
+
 // ListDeviceResp is the success response sent to a ListDevice request
 type ListDeviceResp []Info
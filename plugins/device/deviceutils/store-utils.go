@@ -98,6 +98,19 @@ func AddOrUpdateDevice(device deviceapi.Info) error {
 	return nil
 }
 
+// DeleteDevice removes the device of the specified peer from the store
+func DeleteDevice(peerID, deviceName string) error {
+	resp, err := store.Delete(context.TODO(), devicePrefix+peerID+"/"+deviceName)
+	if err != nil {
+		return err
+	}
+
+	if resp.Deleted != 1 {
+		return gderrors.ErrDeviceNotFound
+	}
+	return nil
+}
+
 // UpdateDeviceFreeSize updates the actual available size of VG
 func UpdateDeviceFreeSize(peerID, device string) error {
 	dev, err := GetDevice(peerID, device)
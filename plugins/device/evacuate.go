@@ -0,0 +1,258 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	volumecommands "github.com/gluster/glusterd2/glusterd2/commands/volumes"
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/snapshot"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/api"
+	"github.com/gluster/glusterd2/plugins/glustershd"
+	glustershdapi "github.com/gluster/glusterd2/plugins/glustershd/api"
+
+	"github.com/pborman/uuid"
+)
+
+const (
+	// healCheckInterval is the interval at which the pending heal entries
+	// of the new brick are checked while a brick is evacuated
+	healCheckInterval = 10 * time.Second
+
+	// healTimeout is how long the new brick is waited for to be healed
+	// before the evacuation is given up, leaving the old brick on the
+	// device
+	healTimeout = 6 * time.Hour
+)
+
+// bricksOnDevice returns the bricks of all the volumes, and the bricks of all
+// the snapshots, which are provisioned on the given device of the peer
+func bricksOnDevice(ctx context.Context, peerID, device string) ([]brick.Brickinfo, []brick.Brickinfo, error) {
+	volumes, err := volume.GetVolumes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	snapVolumes, err := snapshot.GetSnapshotVolumes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	onDevice := func(vols []*volume.Volinfo) []brick.Brickinfo {
+		var bricks []brick.Brickinfo
+		for _, v := range vols {
+			for _, b := range v.GetBricks() {
+				if b.PeerID.String() == peerID && b.RootDevice == device {
+					bricks = append(bricks, b)
+				}
+			}
+		}
+		return bricks
+	}
+
+	return onDevice(volumes), onDevice(snapVolumes), nil
+}
+
+// checkDeviceBricks checks if the device can be deleted with the given bricks
+// provisioned on it. Bricks of volumes are moved off the device only when it
+// is evacuated. Snapshot bricks are thin snapshots of bricks in the volume
+// group of the device, they can not be moved to another device.
+func checkDeviceBricks(bricks, snapBricks []brick.Brickinfo, evacuate bool) error {
+	if len(snapBricks) > 0 {
+		return fmt.Errorf("device has %d snapshot bricks provisioned on it, delete the snapshots before deleting the device", len(snapBricks))
+	}
+	if len(bricks) > 0 && !evacuate {
+		return fmt.Errorf("device has %d bricks provisioned on it, evacuate the device to move them", len(bricks))
+	}
+	return nil
+}
+
+// brickSubvol returns the index of the subvolume of the volume holding the
+// brick
+func brickSubvol(volinfo *volume.Volinfo, b brick.Brickinfo) (int, error) {
+	for idx, sv := range volinfo.Subvols {
+		for _, sb := range sv.Bricks {
+			if uuid.Equal(sb.PeerID, b.PeerID) && sb.Path == b.Path {
+				return idx, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("brick %s is not part of volume %s", b.String(), volinfo.Name)
+}
+
+// validateEvacuation checks that the brick can be replaced without losing
+// data, ie. it is part of a replicate or disperse subvolume of a started
+// volume which is healed by the self-heal daemon, and none of the bricks of
+// the subvolume have entries pending heal
+func validateEvacuation(volinfo *volume.Volinfo, subvolIndex int) error {
+	subvol := volinfo.Subvols[subvolIndex]
+
+	if subvol.Type != volume.SubvolReplicate && subvol.Type != volume.SubvolDisperse {
+		return fmt.Errorf("subvolume %s of volume %s holds the only copy of its data, remove it by shrinking the volume", subvol.Name, volinfo.Name)
+	}
+
+	if !glustershd.IsSelfHealDaemonNeeded(volinfo) {
+		return fmt.Errorf("volume %s must be started with self-heal enabled to evacuate its bricks", volinfo.Name)
+	}
+
+	pending, err := glustershd.PendingHealEntries(volinfo.Name, subvol.Bricks)
+	if err != nil {
+		return err
+	}
+	if pending != 0 {
+		return fmt.Errorf("subvolume %s of volume %s has %d entries pending heal", subvol.Name, volinfo.Name, pending)
+	}
+	return nil
+}
+
+// evacuateBrick replaces the brick with a new brick chosen by the bricks
+// planner, waits for the new brick to be healed, then stops the old brick
+// and removes its LV from the device
+func evacuateBrick(ctx context.Context, b brick.Brickinfo) error {
+	logger := gdctx.GetReqLogger(ctx)
+
+	volinfo, err := volume.GetVolume(b.VolumeName)
+	if err != nil {
+		return err
+	}
+
+	subvolIndex, err := brickSubvol(volinfo, b)
+	if err != nil {
+		return err
+	}
+
+	if err := validateEvacuation(volinfo, subvolIndex); err != nil {
+		return err
+	}
+
+	req := api.ReplaceBrickReq{
+		SrcPeerID:    b.PeerID.String(),
+		SrcBrickPath: b.Path,
+	}
+	if _, _, err := volumecommands.ReplaceBrick(ctx, b.VolumeName, req); err != nil {
+		return fmt.Errorf("failed to replace brick %s of volume %s: %s", b.String(), b.VolumeName, err)
+	}
+
+	if err := healReplacedBrick(ctx, volinfo.Name, subvolIndex); err != nil {
+		logger.WithError(err).WithField("brick", b.String()).Error("new brick was not healed, old brick is left on the device")
+		return err
+	}
+
+	// Volinfo holding only the replaced brick, the steps used to remove
+	// bricks while shrinking a volume act on it
+	removed := volume.Volinfo{
+		ID:              volinfo.ID,
+		Name:            volinfo.Name,
+		VolfileID:       volinfo.VolfileID,
+		ProvisionerType: volinfo.ProvisionerType,
+		Subvols: []volume.Subvol{
+			{Bricks: []brick.Brickinfo{b}},
+		},
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volinfo.Name)
+	if err != nil {
+		return err
+	}
+	defer txn.Done()
+
+	nodes := []uuid.UUID{b.PeerID}
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "vol-shrink.StopBricks",
+			Nodes:  nodes,
+		},
+		{
+			DoFunc: "vol-shrink.CleanBricks",
+			Nodes:  nodes,
+		},
+	}
+
+	if err := txn.Ctx.Set("removed-volinfo", &removed); err != nil {
+		return err
+	}
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("brick", b.String()).Error("failed to remove evacuated brick")
+		return err
+	}
+
+	logger.WithField("volume-name", volinfo.Name).WithField("brick", b.String()).Info("brick evacuated")
+	return nil
+}
+
+// healReplacedBrick triggers a full heal of the volume once a brick of the
+// subvolume has been replaced, and waits until none of the bricks of the
+// subvolume have entries pending heal
+func healReplacedBrick(ctx context.Context, volname string, subvolIndex int) error {
+	volinfo, err := startFullHeal(ctx, volname)
+	if err != nil {
+		return err
+	}
+	subvol := volinfo.Subvols[subvolIndex]
+
+	timeout := time.After(healTimeout)
+	ticker := time.NewTicker(healCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		pending, err := glustershd.PendingHealEntries(volname, subvol.Bricks)
+		if err == nil && pending == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			if err != nil {
+				return fmt.Errorf("timed out waiting for subvolume %s of volume %s to be healed: %s", subvol.Name, volname, err)
+			}
+			return fmt.Errorf("timed out waiting for subvolume %s of volume %s to be healed, %d entries are pending heal", subvol.Name, volname, pending)
+		case <-ticker.C:
+		}
+	}
+}
+
+// startFullHeal reloads the self-heal daemon with the current bricks of the
+// volume and triggers a full heal. The volinfo the heal was started with is
+// returned.
+func startFullHeal(ctx context.Context, volname string) (*volume.Volinfo, error) {
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Done()
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		return nil, err
+	}
+
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "selfheal.ReloadDaemon",
+			Nodes:  volinfo.Nodes(),
+		},
+		{
+			DoFunc: "selfheal.Heal",
+			Nodes:  volinfo.Nodes(),
+		},
+	}
+
+	if err := txn.Ctx.Set("volinfo", volinfo); err != nil {
+		return nil, err
+	}
+	if err := txn.Ctx.Set("healType", glustershdapi.HealFull); err != nil {
+		return nil, err
+	}
+
+	if err := txn.Do(); err != nil {
+		return nil, err
+	}
+	return volinfo, nil
+}
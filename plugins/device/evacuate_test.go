@@ -0,0 +1,50 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheckDeviceBricks validates checkDeviceBricks()
+func TestCheckDeviceBricks(t *testing.T) {
+	bricks := []brick.Brickinfo{{Path: "/bricks/b1"}, {Path: "/bricks/b2"}}
+
+	// A device without bricks is deleted with or without evacuation
+	assert.Nil(t, checkDeviceBricks(nil, nil, false))
+	assert.Nil(t, checkDeviceBricks(nil, nil, true))
+
+	// Bricks of volumes are moved off the device only when it is evacuated
+	err := checkDeviceBricks(bricks, nil, false)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "device has 2 bricks provisioned on it")
+	assert.Nil(t, checkDeviceBricks(bricks, nil, true))
+
+	// Snapshot bricks can not be evacuated
+	err = checkDeviceBricks(bricks, bricks[:1], true)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "device has 1 snapshot bricks provisioned on it")
+}
+
+// TestBrickSubvol validates brickSubvol()
+func TestBrickSubvol(t *testing.T) {
+	p1, p2 := uuid.NewRandom(), uuid.NewRandom()
+	v := &volume.Volinfo{
+		Name: "vol",
+		Subvols: []volume.Subvol{
+			{Bricks: []brick.Brickinfo{{PeerID: p1, Path: "/b1"}, {PeerID: p2, Path: "/b1"}}},
+			{Bricks: []brick.Brickinfo{{PeerID: p1, Path: "/b2"}, {PeerID: p2, Path: "/b2"}}},
+		},
+	}
+
+	idx, err := brickSubvol(v, brick.Brickinfo{PeerID: p2, Path: "/b2"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, idx)
+
+	_, err = brickSubvol(v, brick.Brickinfo{PeerID: p2, Path: "/b3"})
+	assert.NotNil(t, err)
+}
@@ -41,6 +41,15 @@ func (p *Plugin) RestRoutes() route.Routes {
 			Version:      1,
 			ResponseType: utils.GetTypeString((*deviceapi.ListDeviceResp)(nil)),
 			HandlerFunc:  deviceListHandler},
+		// DeviceResize is registered before DeviceEdit as the device
+		// pattern of DeviceEdit matches the resize suffix too
+		route.Route{
+			Name:         "DeviceResize",
+			Method:       "POST",
+			Pattern:      "/devices/{peerid}/{device:.*}/resize",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*deviceapi.ResizeDeviceResp)(nil)),
+			HandlerFunc:  deviceResizeHandler},
 		route.Route{
 			Name:        "DeviceEdit",
 			Method:      "POST",
@@ -48,6 +57,12 @@ func (p *Plugin) RestRoutes() route.Routes {
 			Version:     1,
 			RequestType: utils.GetTypeString((*deviceapi.EditDeviceReq)(nil)),
 			HandlerFunc: deviceEditHandler},
+		route.Route{
+			Name:        "DeviceDelete",
+			Method:      "DELETE",
+			Pattern:     "/devices/{peerid}/{device:.*}",
+			Version:     1,
+			HandlerFunc: deviceDeleteHandler},
 		route.Route{
 			Name:         "DevicesList",
 			Method:       "GET",
@@ -62,4 +77,6 @@ func (p *Plugin) RestRoutes() route.Routes {
 // Glusterd Transaction framework
 func (p *Plugin) RegisterStepFuncs() {
 	transaction.RegisterStepFunc(txnPrepareDevice, "prepare-device")
+	transaction.RegisterStepFunc(txnRemoveDevice, "remove-device")
+	transaction.RegisterStepFunc(txnResizeDevice, "resize-device")
 }
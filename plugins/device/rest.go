@@ -1,8 +1,10 @@
 package device
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
@@ -199,3 +201,205 @@ func deviceEditHandler(w http.ResponseWriter, r *http.Request) {
 
 	restutils.SendHTTPResponse(ctx, w, http.StatusOK, nil)
 }
+
+func deviceDeleteHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	peerID := mux.Vars(r)["peerid"]
+	if uuid.Parse(peerID) == nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "invalid peer-id passed in url")
+		return
+	}
+
+	device := mux.Vars(r)["device"]
+	if device == "" {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "device not provided in URL")
+		return
+	}
+
+	// Adding prefix (/) to device
+	device = "/" + device
+
+	evacuate := false
+	if value := r.URL.Query().Get("evacuate"); value != "" {
+		var err error
+		if evacuate, err = strconv.ParseBool(value); err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "invalid value for evacuate passed in url")
+			return
+		}
+	}
+
+	if _, err := deviceutils.GetDevice(peerID, device); err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	bricks, snapBricks, err := bricksOnDevice(ctx, peerID, device)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := checkDeviceBricks(bricks, snapBricks, evacuate); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, err)
+		return
+	}
+
+	if len(bricks) > 0 {
+		// The bricks planner skips disabled devices, so no new bricks
+		// are provisioned on the device while it is evacuated
+		if err := disableDevice(ctx, peerID, device); err != nil {
+			status, err := restutils.ErrToStatusCode(err)
+			restutils.SendHTTPError(ctx, w, status, err)
+			return
+		}
+
+		for _, b := range bricks {
+			if err := evacuateBrick(ctx, b); err != nil {
+				logger.WithError(err).WithField("device", device).Error("failed to evacuate device, device is left disabled")
+				restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, peerID+device)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	deviceInfo, err := deviceutils.GetDevice(peerID, device)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	// Bricks may have been provisioned on the device before it was locked
+	bricks, snapBricks, err = bricksOnDevice(ctx, peerID, device)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	if len(bricks) > 0 || len(snapBricks) > 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusConflict, "device has bricks provisioned on it")
+		return
+	}
+
+	txn.Nodes = []uuid.UUID{deviceInfo.PeerID}
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "remove-device",
+			Nodes:  txn.Nodes,
+		},
+	}
+
+	err = txn.Ctx.Set("device", deviceInfo)
+	if err != nil {
+		logger.WithError(err).WithField("key", "device").Error("Failed to set key in transaction context")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = txn.Do()
+	if err != nil {
+		logger.WithError(err).Error("Transaction to remove device failed")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, "transaction to remove device failed")
+		return
+	}
+
+	logger.WithField("peerid", peerID).WithField("device", device).Info("device removed")
+	restutils.SendHTTPResponse(ctx, w, http.StatusNoContent, nil)
+}
+
+// disableDevice marks the device as disabled in the store
+func disableDevice(ctx context.Context, peerID, device string) error {
+	txn, err := transaction.NewTxnWithLocks(ctx, peerID+device)
+	if err != nil {
+		return err
+	}
+	defer txn.Done()
+
+	return deviceutils.SetDeviceState(peerID, device, deviceapi.DeviceDisabled)
+}
+
+func deviceResizeHandler(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+	logger := gdctx.GetReqLogger(ctx)
+	peerID := mux.Vars(r)["peerid"]
+	if uuid.Parse(peerID) == nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "invalid peer-id passed in url")
+		return
+	}
+
+	device := mux.Vars(r)["device"]
+	if device == "" {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "device not provided in URL")
+		return
+	}
+
+	// Adding prefix (/) to device
+	device = "/" + device
+
+	// Serializes the resize with the other device operations and with the
+	// reservations of the free size of the device made by AddDeviceFreeSize
+	// and ReduceDeviceFreeSize. The free size set from the VG when bricks
+	// are created or removed is not covered by this lock.
+	txn, err := transaction.NewTxnWithLocks(ctx, peerID+device)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	deviceInfo, err := deviceutils.GetDevice(peerID, device)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if deviceInfo.ProvisionerType == api.ProvisionerTypeLoop {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "resize is supported only for lvm devices")
+		return
+	}
+
+	txn.Nodes = []uuid.UUID{deviceInfo.PeerID}
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "resize-device",
+			Nodes:  txn.Nodes,
+		},
+	}
+
+	err = txn.Ctx.Set("device", deviceInfo)
+	if err != nil {
+		logger.WithError(err).WithField("key", "device").Error("Failed to set key in transaction context")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	err = txn.Do()
+	if err != nil {
+		logger.WithError(err).Error("Transaction to resize device failed")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, "transaction to resize device failed")
+		return
+	}
+
+	deviceInfo, err = deviceutils.GetDevice(peerID, device)
+	if err != nil {
+		logger.WithError(err).WithField("peerid", peerID).Error("Failed to get device from store")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, "failed to get device from store")
+		return
+	}
+
+	logger.WithField("device", device).WithField("total-size", deviceInfo.TotalSize).Info("device resized")
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, deviceInfo)
+}
@@ -1,6 +1,7 @@
 package device
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/gluster/glusterd2/glusterd2/transaction"
@@ -106,3 +107,102 @@ func txnPrepareDeviceLoop(req deviceapi.AddDeviceReq, c transaction.TxnCtx) erro
 	}
 	return nil
 }
+
+func txnRemoveDevice(c transaction.TxnCtx) error {
+	var deviceInfo deviceapi.Info
+	if err := c.Get("device", &deviceInfo); err != nil {
+		c.Logger().WithError(err).WithField("key", "device").Error("Failed to get key from transaction context")
+		return err
+	}
+
+	// Loop devices are directories, nothing is created on them while
+	// preparing the device
+	if deviceInfo.ProvisionerType != api.ProvisionerTypeLoop {
+		err := lvmutils.RemoveVG(deviceInfo.VgName())
+		if err != nil {
+			c.Logger().WithError(err).WithField("device", deviceInfo.Device).Error("Failed to remove volume group")
+			return err
+		}
+		err = lvmutils.RemovePV(deviceInfo.Device)
+		if err != nil {
+			c.Logger().WithError(err).WithField("device", deviceInfo.Device).Error("Failed to remove physical volume")
+			return err
+		}
+	}
+
+	err := deviceutils.DeleteDevice(deviceInfo.PeerID.String(), deviceInfo.Device)
+	if err != nil {
+		c.Logger().WithError(err).WithField("device", deviceInfo.Device).Error("Couldn't remove deviceinfo from store")
+		return err
+	}
+	return nil
+}
+
+// resizedDevice returns the device info updated for its VG having been
+// resized from totalSizeBefore to totalSizeAfter. The extents gained or lost
+// by the VG are added to or taken from the available size. The device can not
+// be resized below the size reserved for the bricks on it.
+func resizedDevice(deviceInfo deviceapi.Info, totalSizeBefore, totalSizeAfter, extentSize uint64) (deviceapi.Info, error) {
+	if totalSizeAfter < totalSizeBefore {
+		shrunk := totalSizeBefore - totalSizeAfter
+		if shrunk > deviceInfo.AvailableSize {
+			return deviceInfo, fmt.Errorf("device has shrunk by %d bytes, below its used size %d", shrunk, deviceInfo.UsedSize)
+		}
+		deviceInfo.AvailableSize -= shrunk
+	} else {
+		deviceInfo.AvailableSize += totalSizeAfter - totalSizeBefore
+	}
+	deviceInfo.TotalSize = totalSizeAfter
+	if deviceInfo.AvailableSize > deviceInfo.TotalSize {
+		deviceInfo.AvailableSize = deviceInfo.TotalSize
+	}
+	deviceInfo.UsedSize = deviceInfo.TotalSize - deviceInfo.AvailableSize
+	deviceInfo.ExtentSize = extentSize
+	return deviceInfo, nil
+}
+
+// txnResizeDevice grows the PV to the size of the underlying disk and sets
+// the size of the device in the store to the size of the VG. The extents
+// gained by the VG are added to the available size, the sizes reserved for
+// the bricks on the device are retained. The device info is not updated if
+// the VG has shrunk below the size reserved for the bricks.
+func txnResizeDevice(c transaction.TxnCtx) error {
+	var deviceInfo deviceapi.Info
+	if err := c.Get("device", &deviceInfo); err != nil {
+		c.Logger().WithError(err).WithField("key", "device").Error("Failed to get key from transaction context")
+		return err
+	}
+
+	totalSizeBefore, _, err := lvmutils.GetVgTotalSize(deviceInfo.VgName())
+	if err != nil {
+		c.Logger().WithError(err).WithField("device", deviceInfo.Device).Error("Failed to get size of volume group")
+		return err
+	}
+
+	err = lvmutils.ResizePV(deviceInfo.Device)
+	if err != nil {
+		c.Logger().WithError(err).WithField("device", deviceInfo.Device).Error("Failed to resize physical volume")
+		return err
+	}
+
+	// The total extents of the VG, unlike its free extents, are not
+	// changed by bricks being provisioned on the device meanwhile
+	totalSizeAfter, extentSize, err := lvmutils.GetVgTotalSize(deviceInfo.VgName())
+	if err != nil {
+		c.Logger().WithError(err).WithField("device", deviceInfo.Device).Error("Failed to get size of volume group")
+		return err
+	}
+
+	deviceInfo, err = resizedDevice(deviceInfo, totalSizeBefore, totalSizeAfter, extentSize)
+	if err != nil {
+		c.Logger().WithError(err).WithField("device", deviceInfo.Device).Error("Failed to resize device")
+		return err
+	}
+
+	err = deviceutils.AddOrUpdateDevice(deviceInfo)
+	if err != nil {
+		c.Logger().WithError(err).WithField("device", deviceInfo.Device).Error("Couldn't update deviceinfo in store")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package device
+
+import (
+	"testing"
+
+	deviceapi "github.com/gluster/glusterd2/plugins/device/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResizedDevice validates resizedDevice()
+func TestResizedDevice(t *testing.T) {
+	deviceInfo := deviceapi.Info{
+		Device:        "/dev/sdb",
+		TotalSize:     1000,
+		AvailableSize: 600,
+		UsedSize:      400,
+		ExtentSize:    10,
+	}
+
+	// The space gained is added to the available size, the used size is
+	// retained
+	resized, err := resizedDevice(deviceInfo, 1000, 3000, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3000), resized.TotalSize)
+	assert.Equal(t, uint64(2600), resized.AvailableSize)
+	assert.Equal(t, uint64(400), resized.UsedSize)
+	assert.Equal(t, uint64(10), resized.ExtentSize)
+
+	// Nothing changes if the disk has not grown
+	resized, err = resizedDevice(deviceInfo, 1000, 1000, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, deviceInfo, resized)
+
+	// The device can shrink down to its used size
+	resized, err = resizedDevice(deviceInfo, 1000, 400, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(400), resized.TotalSize)
+	assert.Equal(t, uint64(0), resized.AvailableSize)
+	assert.Equal(t, uint64(400), resized.UsedSize)
+
+	// but not below it
+	resized, err = resizedDevice(deviceInfo, 1000, 390, 10)
+	assert.NotNil(t, err)
+	assert.Equal(t, deviceInfo, resized)
+}
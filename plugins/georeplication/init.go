@@ -141,4 +141,5 @@ func (p *Plugin) RegisterStepFuncs() {
 	transaction.RegisterStepFunc(txnGeorepConfigFilegen, "georeplication-configfilegen.Commit")
 	transaction.RegisterStepFunc(txnSSHKeysGenerate, "georeplication-ssh-keygen.Commit")
 	transaction.RegisterStepFunc(txnSSHKeysPush, "georeplication-ssh-keypush.Commit")
+	transaction.RegisterStepFunc(txnGeorepRename, "georeplication-rename.Commit")
 }
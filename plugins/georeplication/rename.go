@@ -0,0 +1,112 @@
+package georeplication
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	georepapi "github.com/gluster/glusterd2/plugins/georeplication/api"
+
+	"github.com/pborman/uuid"
+	config "github.com/spf13/viper"
+)
+
+// MasterSessions returns the geo-replication sessions which have the given
+// volume as the master volume
+func MasterSessions(volid uuid.UUID) ([]georepapi.GeorepSession, error) {
+	sessions, err := getSessionList()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []georepapi.GeorepSession
+	for _, s := range *sessions {
+		if uuid.Equal(s.MasterID, volid) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// CheckMasterVolumeRename returns an error if the volume is the master of a
+// geo-replication session which is not stopped
+func CheckMasterVolumeRename(volid uuid.UUID) error {
+	sessions, err := MasterSessions(volid)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		if s.Status == georepapi.GeorepStatusStarted || s.Status == georepapi.GeorepStatusPaused {
+			return fmt.Errorf("geo-replication session to %s::%s must be stopped", s.RemoteHosts[0].Hostname, s.RemoteVol)
+		}
+	}
+	return nil
+}
+
+// RenameMasterVolume updates the geo-replication sessions and the SSH public
+// keys of a master volume in the store after it is renamed
+func RenameMasterVolume(volid uuid.UUID, oldname, newname string) error {
+	sessions, err := MasterSessions(volid)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		s.MasterVol = newname
+		if err := addOrUpdateSession(&s); err != nil {
+			return err
+		}
+	}
+
+	sshkeys, err := getSSHPublicKeys(oldname)
+	if err != nil {
+		// No SSH keys are generated for the volume
+		return nil
+	}
+
+	for _, sshkey := range sshkeys {
+		if err := addOrUpdateSSHKey(newname, sshkey); err != nil {
+			return err
+		}
+	}
+
+	return deleteSSHKeys(oldname)
+}
+
+// txnGeorepRename regenerates the gsyncd config files of the sessions of a
+// renamed master volume and removes the config directories of the old name
+func txnGeorepRename(c transaction.TxnCtx) error {
+	var volinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		return err
+	}
+
+	var oldvolinfo volume.Volinfo
+	if err := c.Get("oldvolinfo", &oldvolinfo); err != nil {
+		return err
+	}
+
+	sessions, err := MasterSessions(volinfo.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		if err := configFileGenerate(&s); err != nil {
+			return err
+		}
+
+		olddir := path.Join(
+			config.GetString("localstatedir"),
+			"geo-replication",
+			fmt.Sprintf("%s_%s_%s", oldvolinfo.Name, s.RemoteHosts[0].Hostname, s.RemoteVol),
+		)
+		if err := os.RemoveAll(olddir); err != nil {
+			c.Logger().WithError(err).WithField("dir", olddir).Warn("failed to remove geo-replication config directory")
+		}
+	}
+	return nil
+}
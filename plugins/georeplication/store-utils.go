@@ -105,7 +105,7 @@ func addOrUpdateSSHKey(volname string, sshkey georepapi.GeorepSSHPublicKey) erro
 
 // getSSHPublicKeys returns list of SSH public keys
 func getSSHPublicKeys(volname string) ([]georepapi.GeorepSSHPublicKey, error) {
-	resp, e := store.Get(context.TODO(), georepSSHKeysPrefix+volname, clientv3.WithPrefix())
+	resp, e := store.Get(context.TODO(), georepSSHKeysPrefix+volname+"/", clientv3.WithPrefix())
 	if e != nil {
 		log.WithError(e).WithField("volname", volname).Error("Couldn't retrive SSH Key from the node")
 		return nil, e
@@ -126,3 +126,13 @@ func getSSHPublicKeys(volname string) ([]georepapi.GeorepSSHPublicKey, error) {
 	}
 	return sshkeys, nil
 }
+
+// deleteSSHKeys deletes the SSH public keys of a volume from the store
+func deleteSSHKeys(volname string) error {
+	_, e := store.Delete(context.TODO(), georepSSHKeysPrefix+volname+"/", clientv3.WithPrefix())
+	if e != nil {
+		log.WithError(e).WithField("volname", volname).Error("Couldn't delete SSH public keys from store")
+		return e
+	}
+	return nil
+}
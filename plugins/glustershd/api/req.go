@@ -1,5 +1,15 @@
 package api
 
+// HealType represents the type of heal to be triggered on a volume
+type HealType int8
+
+const (
+	// HealIndex heals only the files marked as pending in the index
+	HealIndex HealType = 1 + iota
+	// HealFull crawls the whole volume and heals every file
+	HealFull
+)
+
 // SplitBrainReq represents details needed to resolve split brain
 type SplitBrainReq struct {
 	FileName  string `json:"filename,omitempty"`
@@ -53,4 +53,5 @@ func (p *Plugin) RestRoutes() route.Routes {
 // Glusterd Transaction framework
 func (p *Plugin) RegisterStepFuncs() {
 	transaction.RegisterStepFunc(txnSelfHeal, "selfheal.Heal")
+	transaction.RegisterStepFunc(txnReloadSelfHealDaemon, "selfheal.ReloadDaemon")
 }
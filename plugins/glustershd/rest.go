@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/gluster/glusterd2/glusterd2/brick"
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
 	"github.com/gluster/glusterd2/glusterd2/peer"
 	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
@@ -23,13 +24,6 @@ import (
 	config "github.com/spf13/viper"
 )
 
-type healTypes int8
-
-const (
-	indexHeal healTypes = 1 + iota
-	fullHeal
-)
-
 func runGlfshealBin(volname string, args []string) (string, error) {
 	var out bytes.Buffer
 	var buffer bytes.Buffer
@@ -66,6 +60,46 @@ func getHealInfo(volname string, option string) (string, error) {
 	return runGlfshealBin(volname, options)
 }
 
+// PendingHealEntries returns the number of entries pending heal on the given
+// bricks of the volume, as reported by heal info. An error is returned if
+// the count is not known for any of the bricks.
+func PendingHealEntries(volname string, bricks []brick.Brickinfo) (int64, error) {
+	healInfoOutput, err := getHealInfo(volname, "info-summary")
+	if err != nil {
+		return 0, err
+	}
+
+	var info glustershdapi.HealInfo
+	if err = xml.Unmarshal([]byte(healInfoOutput), &info); err != nil {
+		return 0, err
+	}
+
+	info, err = filterHealInfo(info)
+	if err != nil {
+		return 0, err
+	}
+
+	var pending int64
+	for _, b := range bricks {
+		found := false
+		for _, hb := range info.Bricks {
+			if hb.HostID != b.PeerID.String() || !strings.HasSuffix(hb.Name, ":"+b.Path) {
+				continue
+			}
+			if hb.Status != "Connected" || hb.TotalEntries == nil || *hb.TotalEntries < 0 {
+				return 0, fmt.Errorf("pending heal entries of brick %s are not known", b.String())
+			}
+			pending += *hb.TotalEntries
+			found = true
+		}
+		if !found {
+			return 0, fmt.Errorf("brick %s not found in heal info", b.String())
+		}
+	}
+
+	return pending, nil
+}
+
 func convertToInt(value string) (int64, error) {
 	if value == "-" {
 		return -1, nil
@@ -202,13 +236,13 @@ func selfHealHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := gdctx.GetReqLogger(ctx)
 
-	healType := indexHeal
+	healType := glustershdapi.HealIndex
 	if heal, ok := r.URL.Query()["type"]; ok {
 		switch heal[0] {
 		case "index":
-			healType = indexHeal
+			healType = glustershdapi.HealIndex
 		case "full":
-			healType = fullHeal
+			healType = glustershdapi.HealFull
 		default:
 			restutils.SendHTTPError(ctx, w, http.StatusBadRequest, "heal type can only be either index or full")
 			return
@@ -10,12 +10,14 @@ import (
 	"github.com/gluster/glusterd2/glusterd2/gdctx"
 	"github.com/gluster/glusterd2/glusterd2/servers/sunrpc/dict"
 	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volgen"
 	"github.com/gluster/glusterd2/glusterd2/volume"
+	gderrors "github.com/gluster/glusterd2/pkg/errors"
 )
 
 func getHxlChildrenCount(volinfo *volume.Volinfo) (int, string) {
 	if volinfo.Type == volume.Replicate || volinfo.Type == volume.DistReplicate {
-		return volinfo.Subvols[0].ReplicaCount, "replicate"
+		return volinfo.Subvols[0].ReplicaCount + volinfo.Subvols[0].ArbiterCount, "replicate"
 	}
 	return volinfo.Subvols[0].DisperseCount, "disperse"
 }
@@ -111,3 +113,47 @@ func txnSelfHeal(c transaction.TxnCtx) error {
 
 	return nil
 }
+
+// txnReloadSelfHealDaemon regenerates the glustershd volfile after the bricks
+// of a volume have changed. The daemon is started if the volume now needs it
+// and stopped if no started volume needs it any longer.
+func txnReloadSelfHealDaemon(c transaction.TxnCtx) error {
+
+	var volinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		return err
+	}
+
+	glustershDaemon, err := newGlustershd()
+	if err != nil {
+		return err
+	}
+
+	if !IsSelfHealDaemonNeeded(&volinfo) {
+		isVolRunning, err := volume.AreReplicateVolumesRunning(volinfo.ID)
+		if err != nil {
+			return err
+		}
+		if !isVolRunning {
+			err := daemon.Stop(glustershDaemon, true, c.Logger())
+			if err != nil && err != gderrors.ErrPidFileNotFound {
+				return err
+			}
+			return nil
+		}
+	}
+
+	err = volgen.ClusterVolfileToFile(&volinfo, glustershDaemon.VolfileID, "glustershd")
+	if err != nil {
+		c.Logger().WithError(err).WithField(
+			"volume", volinfo.Name).Error("failed to generate glustershd volfile")
+		return err
+	}
+
+	err = daemon.Start(glustershDaemon, true, c.Logger())
+	if err != nil && err != gderrors.ErrProcessAlreadyRunning {
+		return err
+	}
+
+	return nil
+}
@@ -28,3 +28,13 @@ func isHealEnabled(v *volume.Volinfo) bool {
 	}
 	return false
 }
+
+// IsSelfHealDaemonNeeded returns true if the volume needs the self-heal daemon
+// to be running, i.e. it is a started replicate or disperse volume which does
+// not have the self-heal daemon turned off.
+func IsSelfHealDaemonNeeded(v *volume.Volinfo) bool {
+	if v.State != volume.VolStarted || !isVolReplicate(v.Type) {
+		return false
+	}
+	return v.Options[shdKey] != "off"
+}
@@ -73,21 +73,28 @@ func (actor *quotadActor) Do(v *volume.Volinfo, key, value string, volOp xlator.
 	} else {
 		// Quotad must be restarted whenever quota is enabled or disabled
 		// for a volume.
-		err = daemon.Stop(quotadDaemon, true, logger)
-		if err == errors.ErrPidFileNotFound {
-			logger.Info("quotad stop failed as pidfile missing")
-		} else if err != nil {
-			logger.Warn("quotad stop failed")
-		} else {
-			logger.Info("quotad stopped for restart")
-		}
-		err = volgen.ClusterVolfileToFile(v, quotadDaemon.VolfileID, "quotad")
-		if err != nil {
-			return err
-		}
-		if err = daemon.Start(quotadDaemon, true, logger); err != nil {
-			logger.WithError(err).Error("quotad start failed")
-		}
+		err = restartQuotad(quotadDaemon, v, logger)
+	}
+	return err
+}
+
+// restartQuotad stops quotad if running, regenerates its volfile and starts
+// it again so that it picks up the latest volume configuration.
+func restartQuotad(quotadDaemon *Quotad, v *volume.Volinfo, logger log.FieldLogger) error {
+	err := daemon.Stop(quotadDaemon, true, logger)
+	if err == errors.ErrPidFileNotFound {
+		logger.Info("quotad stop failed as pidfile missing")
+	} else if err != nil {
+		logger.Warn("quotad stop failed")
+	} else {
+		logger.Info("quotad stopped for restart")
+	}
+	err = volgen.ClusterVolfileToFile(v, quotadDaemon.VolfileID, "quotad")
+	if err != nil {
+		return err
+	}
+	if err = daemon.Start(quotadDaemon, true, logger); err != nil {
+		logger.WithError(err).Error("quotad start failed")
 	}
 	return err
 }
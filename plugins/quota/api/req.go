@@ -1,13 +1,17 @@
 package api
 
-// SetLimitReq represents REST API request to Limit Usage/objects of a directory
+// SetLimitReq represents REST API request to set a usage limit on a
+// directory of a volume
 type SetLimitReq struct {
-	Path             string `json:"path"`
-	SizeUsageLimit   int    `json:"size-usage-limit,omitempty"`
-	ObjectCountLimit int    `json:"object-count-limit,omitempty"`
+	Path      string `json:"path"`
+	HardLimit uint64 `json:"hard-limit"`
+	// SoftLimit is a percentage of the hard limit. When not set, the
+	// value of "quota.default-soft-limit" volume option is used.
+	SoftLimit uint64 `json:"soft-limit,omitempty"`
 }
 
-// RemoveLimitReq represents REST API request to Remove Usage/objects of a directory
+// RemoveLimitReq represents REST API request to remove a usage limit
+// set on a directory of a volume
 type RemoveLimitReq struct {
 	Path string `json:"path"`
 }
@@ -0,0 +1,18 @@
+package quota
+
+import (
+	"errors"
+)
+
+var (
+	// errQuotaLimitNotFound : No limit is set on the given directory
+	errQuotaLimitNotFound = errors.New("quota limit not set on the given path")
+	// errQuotaNotEnabled : Quota is not enabled on the volume
+	errQuotaNotEnabled = errors.New("quota is not enabled on the volume")
+	// errInvalidQuotaPath : Path is not an absolute path within the volume
+	errInvalidQuotaPath = errors.New("path should be an absolute path in volume, should start with / notation")
+	// errInvalidHardLimit : Hard limit is zero
+	errInvalidHardLimit = errors.New("hard limit should be greater than zero")
+	// errInvalidSoftLimit : Soft limit percentage is out of range
+	errInvalidSoftLimit = errors.New("soft limit should be a percentage between 1 and 100, or 0 to use the default soft limit of the volume")
+)
@@ -2,6 +2,9 @@ package quota
 
 import (
 	"github.com/gluster/glusterd2/glusterd2/servers/rest/route"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/pkg/utils"
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
 )
 
 const name = "quota"
@@ -19,22 +22,26 @@ func (p *Plugin) Name() string {
 func (p *Plugin) RestRoutes() route.Routes {
 	return route.Routes{
 		route.Route{
-			Name:        "QuotaList",
-			Method:      "GET",
-			Pattern:     "/quota/{volname}/limit",
-			Version:     1,
-			HandlerFunc: quotaListHandler},
+			Name:         "QuotaList",
+			Method:       "GET",
+			Pattern:      "/quota/{volname}/limit",
+			Version:      1,
+			ResponseType: utils.GetTypeString((*quotaapi.ListResp)(nil)),
+			HandlerFunc:  quotaListHandler},
 		route.Route{
-			Name:        "QuotaLimit",
-			Method:      "POST",
-			Pattern:     "/quota/{volname}/limit",
-			Version:     1,
-			HandlerFunc: quotaLimitHandler},
+			Name:         "QuotaLimit",
+			Method:       "POST",
+			Pattern:      "/quota/{volname}/limit",
+			Version:      1,
+			RequestType:  utils.GetTypeString((*quotaapi.SetLimitReq)(nil)),
+			ResponseType: utils.GetTypeString((*quotaapi.DirLimit)(nil)),
+			HandlerFunc:  quotaLimitHandler},
 		route.Route{
 			Name:        "QuotaRemove",
 			Method:      "DELETE",
 			Pattern:     "/quota/{volname}/limit",
 			Version:     1,
+			RequestType: utils.GetTypeString((*quotaapi.RemoveLimitReq)(nil)),
 			HandlerFunc: quotaRemoveHandler},
 	}
 }
@@ -42,5 +49,15 @@ func (p *Plugin) RestRoutes() route.Routes {
 // RegisterStepFuncs registers transaction step functions with
 // Glusterd Transaction framework
 func (p *Plugin) RegisterStepFuncs() {
+	transaction.RegisterStepFunc(txnQuotaSetLimit, "quota-limit.SetXattr")
+	transaction.RegisterStepFunc(txnQuotaUndoSetLimit, "quota-limit.SetXattr.Undo")
+	transaction.RegisterStepFunc(txnQuotaStoreLimit, "quota-limit.Store")
+	transaction.RegisterStepFunc(txnQuotaUndoStoreLimit, "quota-limit.Store.Undo")
+	transaction.RegisterStepFunc(txnQuotaRemoveLimit, "quota-remove.RemoveXattr")
+	transaction.RegisterStepFunc(txnQuotaUndoRemoveLimit, "quota-remove.RemoveXattr.Undo")
+	transaction.RegisterStepFunc(txnQuotaDeleteLimit, "quota-remove.Store")
+	transaction.RegisterStepFunc(txnQuotaUndoDeleteLimit, "quota-remove.Store.Undo")
+	transaction.RegisterStepFunc(txnQuotaRestartQuotad, "quota.RestartQuotad")
+	transaction.RegisterStepFunc(txnQuotaUsage, "quota-list.Usage")
 	return
 }
@@ -2,24 +2,359 @@ package quota
 
 import (
 	"net/http"
+	"path"
+	"sort"
+	"strconv"
 
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
 	restutils "github.com/gluster/glusterd2/glusterd2/servers/rest/utils"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	"github.com/gluster/glusterd2/pkg/errors"
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
+
+	"github.com/gorilla/mux"
+	"github.com/pborman/uuid"
+)
+
+const (
+	quotaDefaultSoftLimitKey = "quota.default-soft-limit"
 )
 
+// validateQuotaVolume checks that the volume is started and has quota enabled
+func validateQuotaVolume(volinfo *volume.Volinfo) (int, error) {
+	if volinfo.State != volume.VolStarted {
+		return http.StatusBadRequest, errors.ErrVolNotStarted
+	}
+	if !isQuotaEnabled(volinfo) {
+		return http.StatusBadRequest, errQuotaNotEnabled
+	}
+	return http.StatusOK, nil
+}
+
+// cleanQuotaPath returns the cleaned absolute directory path within the volume
+func cleanQuotaPath(p string) (string, error) {
+	if !path.IsAbs(p) {
+		return "", errInvalidQuotaPath
+	}
+	return path.Clean(p), nil
+}
+
 func quotaListHandler(w http.ResponseWriter, r *http.Request) {
-	// implement the help logic and send response back as below
+	// Collect inputs from URL
+	volname := mux.Vars(r)["volname"]
+
 	ctx := r.Context()
-	restutils.SendHTTPResponse(ctx, w, http.StatusOK, "todo: quota list")
+	logger := gdctx.GetReqLogger(ctx)
+
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if status, err := validateQuotaVolume(volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	limits, err := getLimits(volinfo.ID.String())
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := make(quotaapi.ListResp, 0, len(limits))
+	if len(limits) == 0 {
+		restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+		return
+	}
+
+	txn := transaction.NewTxn(ctx)
+	defer txn.Done()
+
+	// Some nodes may not be up, which is okay.
+	txn.DontCheckAlive = true
+	txn.DisableRollback = true
+
+	txn.Nodes = volinfo.Nodes()
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc: "quota-list.Usage",
+			Nodes:  txn.Nodes,
+		},
+	}
+	if err := txn.Ctx.Set("volinfo", volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	if err := txn.Ctx.Set("limits", limits); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("volname",
+			volinfo.Name).Error("failed to get quota usage")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp = createListResp(txn.Ctx, volinfo, limits)
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, resp)
+}
+
+// createListResp aggregates usage reported by the nodes. Usage of a
+// subvolume is the largest usage reported by any of its bricks and usage of
+// the directory is the sum of usage across subvolumes.
+func createListResp(ctx transaction.TxnCtx, volinfo *volume.Volinfo, limits []quotaapi.DirLimit) quotaapi.ListResp {
+	subvolMax := make(subvolUsage)
+	for _, node := range volinfo.Nodes() {
+		var tmp subvolUsage
+		if err := ctx.GetNodeResult(node, quotaUsageTxnKey, &tmp); err != nil {
+			// skip if we do not have information
+			continue
+		}
+		for dirpath, subvols := range tmp {
+			if _, ok := subvolMax[dirpath]; !ok {
+				subvolMax[dirpath] = make(map[string]uint64)
+			}
+			for subvol, used := range subvols {
+				if used > subvolMax[dirpath][subvol] {
+					subvolMax[dirpath][subvol] = used
+				}
+			}
+		}
+	}
+
+	resp := make(quotaapi.ListResp, 0, len(limits))
+	for _, limit := range limits {
+		info := quotaapi.LimitInfo{DirLimit: limit}
+		for _, used := range subvolMax[limit.Path] {
+			info.Used += used
+		}
+
+		if info.Used < limit.HardLimit {
+			info.Available = limit.HardLimit - info.Used
+		} else {
+			info.HardLimitExceeded = true
+		}
+
+		softLimit := limit.SoftLimit
+		if softLimit == 0 {
+			softLimit = defaultSoftLimitPercent(volinfo)
+		}
+		info.SoftLimitExceeded = info.Used*100 >= limit.HardLimit*softLimit
+		resp = append(resp, info)
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Path < resp[j].Path
+	})
+	return resp
+}
+
+// defaultSoftLimitPercent returns the soft limit percentage applicable to
+// limits which do not set one explicitly
+func defaultSoftLimitPercent(volinfo *volume.Volinfo) uint64 {
+	if val, ok := volinfo.Options[quotaDefaultSoftLimitKey]; ok {
+		// The option may be specified with a trailing '%'
+		if len(val) > 0 && val[len(val)-1] == '%' {
+			val = val[:len(val)-1]
+		}
+		if pct, err := strconv.ParseUint(val, 10, 64); err == nil && pct > 0 && pct <= 100 {
+			return pct
+		}
+	}
+	return 80
 }
 
 func quotaLimitHandler(w http.ResponseWriter, r *http.Request) {
-	// implement the help logic and send response back as below
+	// Collect inputs from URL
+	volname := mux.Vars(r)["volname"]
+
 	ctx := r.Context()
-	restutils.SendHTTPResponse(ctx, w, http.StatusOK, "Todo: limitusage")
+	logger := gdctx.GetReqLogger(ctx)
+
+	var req quotaapi.SetLimitReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, errors.ErrJSONParsingFailed)
+		return
+	}
+
+	dirpath, err := cleanQuotaPath(req.Path)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.HardLimit == 0 {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, errInvalidHardLimit)
+		return
+	}
+
+	if req.SoftLimit > 100 {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, errInvalidSoftLimit)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	// Validate volume existence
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if status, err := validateQuotaVolume(volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	limit := quotaapi.DirLimit{
+		Path:      dirpath,
+		HardLimit: req.HardLimit,
+		SoftLimit: req.SoftLimit,
+	}
+
+	// Save the existing limit, if any, for transaction failure scenario
+	oldLimit, err := getLimit(volinfo.ID.String(), dirpath)
+	if err == nil {
+		if err := txn.Ctx.Set("oldlimit", oldLimit); err != nil {
+			restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+			return
+		}
+	} else if err != errQuotaLimitNotFound {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("volinfo", volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	if err := txn.Ctx.Set("limit", &limit); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	txn.Nodes = volinfo.Nodes()
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc:   "quota-limit.SetXattr",
+			UndoFunc: "quota-limit.SetXattr.Undo",
+			Nodes:    txn.Nodes,
+		},
+		{
+			DoFunc:   "quota-limit.Store",
+			UndoFunc: "quota-limit.Store.Undo",
+			Nodes:    []uuid.UUID{gdctx.MyUUID},
+		},
+		{
+			DoFunc: "quota.RestartQuotad",
+			Nodes:  txn.Nodes,
+		},
+	}
+
+	if err = txn.Do(); err != nil {
+		logger.WithError(err).WithField("volname",
+			volinfo.Name).Error("failed to set quota limit")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusOK, limit)
 }
 
 func quotaRemoveHandler(w http.ResponseWriter, r *http.Request) {
-	// implement the help logic and send response back as below
+	// Collect inputs from URL
+	volname := mux.Vars(r)["volname"]
+
 	ctx := r.Context()
-	restutils.SendHTTPResponse(ctx, w, http.StatusOK, "Todo: quota Remove")
+	logger := gdctx.GetReqLogger(ctx)
+
+	var req quotaapi.RemoveLimitReq
+	if err := restutils.UnmarshalRequest(r, &req); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, errors.ErrJSONParsingFailed)
+		return
+	}
+
+	dirpath, err := cleanQuotaPath(req.Path)
+	if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	txn, err := transaction.NewTxnWithLocks(ctx, volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+	defer txn.Done()
+
+	// Validate volume existence
+	volinfo, err := volume.GetVolume(volname)
+	if err != nil {
+		status, err := restutils.ErrToStatusCode(err)
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	if status, err := validateQuotaVolume(volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, status, err)
+		return
+	}
+
+	oldLimit, err := getLimit(volinfo.ID.String(), dirpath)
+	if err == errQuotaLimitNotFound {
+		restutils.SendHTTPError(ctx, w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := txn.Ctx.Set("volinfo", volinfo); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	if err := txn.Ctx.Set("oldlimit", oldLimit); err != nil {
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	txn.Nodes = volinfo.Nodes()
+	txn.Steps = []*transaction.Step{
+		{
+			DoFunc:   "quota-remove.RemoveXattr",
+			UndoFunc: "quota-remove.RemoveXattr.Undo",
+			Nodes:    txn.Nodes,
+		},
+		{
+			DoFunc:   "quota-remove.Store",
+			UndoFunc: "quota-remove.Store.Undo",
+			Nodes:    []uuid.UUID{gdctx.MyUUID},
+		},
+		{
+			DoFunc: "quota.RestartQuotad",
+			Nodes:  txn.Nodes,
+		},
+	}
+
+	if err = txn.Do(); err != nil {
+		logger.WithError(err).WithField("volname",
+			volinfo.Name).Error("failed to remove quota limit")
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	restutils.SendHTTPResponse(ctx, w, http.StatusNoContent, nil)
 }
@@ -0,0 +1,132 @@
+package quota
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// usageCtx is a transaction context holding the usage reported by the nodes
+type usageCtx struct {
+	transaction.TxnCtx
+	usage map[string]subvolUsage
+}
+
+func (c *usageCtx) GetNodeResult(peerID uuid.UUID, key string, value interface{}) error {
+	usage, ok := c.usage[peerID.String()]
+	if !ok || key != quotaUsageTxnKey {
+		return errors.New("key not present")
+	}
+	*value.(*subvolUsage) = usage
+	return nil
+}
+
+// TestCreateListResp validates createListResp()
+func TestCreateListResp(t *testing.T) {
+	node1, node2, node3 := uuid.NewRandom(), uuid.NewRandom(), uuid.NewRandom()
+
+	// Distributed replicate volume with a replica of each subvolume on
+	// node1 and node2, node3 did not report its usage
+	volinfo := &volume.Volinfo{
+		Name: "vol",
+		Subvols: []volume.Subvol{
+			{Name: "vol-replicate-0", Bricks: []brick.Brickinfo{{PeerID: node1}, {PeerID: node2}}},
+			{Name: "vol-replicate-1", Bricks: []brick.Brickinfo{{PeerID: node1}, {PeerID: node2}}},
+			{Name: "vol-replicate-2", Bricks: []brick.Brickinfo{{PeerID: node3}}},
+		},
+		Options: map[string]string{},
+	}
+
+	ctx := &usageCtx{usage: map[string]subvolUsage{
+		node1.String(): {
+			"/a": {"vol-replicate-0": 100, "vol-replicate-1": 20},
+			"/b": {"vol-replicate-0": 119},
+			"/c": {"vol-replicate-0": 60},
+			"/d": {"vol-replicate-0": 200},
+		},
+		node2.String(): {
+			"/a": {"vol-replicate-0": 90, "vol-replicate-1": 30},
+			"/b": {"vol-replicate-0": 110},
+			"/c": {"vol-replicate-0": 60},
+		},
+	}}
+
+	tests := []struct {
+		name          string
+		limit         quotaapi.DirLimit
+		defaultOption string
+		used          uint64
+		available     uint64
+		softExceeded  bool
+		hardExceeded  bool
+	}{
+		{
+			name:      "largest usage of each subvolume is summed",
+			limit:     quotaapi.DirLimit{Path: "/a", HardLimit: 1000},
+			used:      130,
+			available: 870,
+		},
+		{
+			name:      "soft limit is not rounded down",
+			limit:     quotaapi.DirLimit{Path: "/b", HardLimit: 150, SoftLimit: 80},
+			used:      119,
+			available: 31,
+		},
+		{
+			name:         "usage above the soft limit",
+			limit:        quotaapi.DirLimit{Path: "/b", HardLimit: 148, SoftLimit: 80},
+			used:         119,
+			available:    29,
+			softExceeded: true,
+		},
+		{
+			name:      "usage just below the soft limit",
+			limit:     quotaapi.DirLimit{Path: "/c", HardLimit: 99, SoftLimit: 61},
+			used:      60,
+			available: 39,
+		},
+		{
+			name:          "default soft limit of the volume",
+			limit:         quotaapi.DirLimit{Path: "/c", HardLimit: 99},
+			defaultOption: "60%",
+			used:          60,
+			available:     39,
+			softExceeded:  true,
+		},
+		{
+			name:         "hard limit exceeded",
+			limit:        quotaapi.DirLimit{Path: "/d", HardLimit: 200, SoftLimit: 100},
+			used:         200,
+			softExceeded: true,
+			hardExceeded: true,
+		},
+		{
+			name:      "no usage reported",
+			limit:     quotaapi.DirLimit{Path: "/e", HardLimit: 10},
+			available: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		volinfo.Options[quotaDefaultSoftLimitKey] = tt.defaultOption
+		resp := createListResp(ctx, volinfo, []quotaapi.DirLimit{tt.limit})
+		assert.Equal(t, 1, len(resp), tt.name)
+		assert.Equal(t, tt.limit, resp[0].DirLimit, tt.name)
+		assert.Equal(t, tt.used, resp[0].Used, tt.name)
+		assert.Equal(t, tt.available, resp[0].Available, tt.name)
+		assert.Equal(t, tt.softExceeded, resp[0].SoftLimitExceeded, tt.name)
+		assert.Equal(t, tt.hardExceeded, resp[0].HardLimitExceeded, tt.name)
+	}
+
+	// Limits are listed by path
+	resp := createListResp(ctx, volinfo, []quotaapi.DirLimit{{Path: "/d", HardLimit: 1}, {Path: "/a", HardLimit: 1}})
+	assert.Equal(t, "/a", resp[0].Path)
+	assert.Equal(t, "/d", resp[1].Path)
+}
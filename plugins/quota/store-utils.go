@@ -0,0 +1,87 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gluster/glusterd2/glusterd2/store"
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
+
+	"github.com/coreos/etcd/clientv3"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	quotaPrefix string = "quota/"
+)
+
+// limitKey returns the store key of the limit set on dirpath of a volume.
+// Limits are keyed on volume ID so that they are not tied to volume name.
+func limitKey(volid string, dirpath string) string {
+	return quotaPrefix + volid + dirpath
+}
+
+// getLimit fetches the limit set on dirpath of a volume from the store
+func getLimit(volid string, dirpath string) (*quotaapi.DirLimit, error) {
+	resp, e := store.Get(context.TODO(), limitKey(volid, dirpath))
+	if e != nil {
+		log.WithError(e).Error("Couldn't retrieve quota limit from store")
+		return nil, e
+	}
+
+	if resp.Count != 1 {
+		return nil, errQuotaLimitNotFound
+	}
+
+	var limit quotaapi.DirLimit
+	if e = json.Unmarshal(resp.Kvs[0].Value, &limit); e != nil {
+		log.WithError(e).Error("Failed to unmarshal the data into quota limit object")
+		return nil, e
+	}
+	return &limit, nil
+}
+
+// getLimits returns all limits set on a volume
+func getLimits(volid string) ([]quotaapi.DirLimit, error) {
+	resp, e := store.Get(context.TODO(), quotaPrefix+volid+"/", clientv3.WithPrefix())
+	if e != nil {
+		return nil, e
+	}
+
+	limits := make([]quotaapi.DirLimit, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var limit quotaapi.DirLimit
+
+		if err := json.Unmarshal(kv.Value, &limit); err != nil {
+			log.WithError(err).WithField("limit", string(kv.Key)).Error("Failed to unmarshal quota limit")
+			continue
+		}
+		limits = append(limits, limit)
+	}
+
+	return limits, nil
+}
+
+// addOrUpdateLimit marshals the limit object and passes to store to add/update
+func addOrUpdateLimit(volid string, limit *quotaapi.DirLimit) error {
+	json, e := json.Marshal(limit)
+	if e != nil {
+		log.WithError(e).Error("Failed to marshal the quota limit object")
+		return e
+	}
+
+	if _, e = store.Put(context.TODO(), limitKey(volid, limit.Path), string(json)); e != nil {
+		log.WithError(e).Error("Couldn't add quota limit to store")
+		return e
+	}
+	return nil
+}
+
+// deleteLimit deletes the limit set on dirpath of a volume from store
+func deleteLimit(volid string, dirpath string) error {
+	if _, e := store.Delete(context.TODO(), limitKey(volid, dirpath)); e != nil {
+		log.WithError(e).Error("Couldn't delete quota limit from store")
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package quota
+
+import (
+	"github.com/gluster/glusterd2/glusterd2/gdctx"
+	"github.com/gluster/glusterd2/glusterd2/transaction"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	quotaUsageTxnKey string = "quotausage"
+)
+
+// subvolUsage maps a directory path to the bytes used by it on each subvolume
+type subvolUsage map[string]map[string]uint64
+
+func getVolinfoAndLimit(c transaction.TxnCtx, limitKey string) (*volume.Volinfo, *quotaapi.DirLimit, error) {
+	var volinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		c.Logger().WithError(err).WithField(
+			"key", "volinfo").Error("failed to get value for key from context")
+		return nil, nil, err
+	}
+
+	var limit quotaapi.DirLimit
+	if err := c.Get(limitKey, &limit); err != nil {
+		c.Logger().WithError(err).WithField(
+			"key", limitKey).Error("failed to get value for key from context")
+		return nil, nil, err
+	}
+	return &volinfo, &limit, nil
+}
+
+func txnQuotaSetLimit(c transaction.TxnCtx) error {
+	volinfo, limit, err := getVolinfoAndLimit(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	if err := setLimitOnLocalBricks(volinfo, limit); err != nil {
+		c.Logger().WithError(err).WithFields(log.Fields{
+			"volume": volinfo.Name,
+			"path":   limit.Path,
+		}).Error("failed to set quota limit on bricks")
+		return err
+	}
+	return nil
+}
+
+// txnQuotaUndoSetLimit restores the limit that was set on the directory
+// before the transaction, or removes the limit if there was none.
+func txnQuotaUndoSetLimit(c transaction.TxnCtx) error {
+	volinfo, limit, err := getVolinfoAndLimit(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	var oldLimit quotaapi.DirLimit
+	if err := c.Get("oldlimit", &oldLimit); err == nil {
+		return setLimitOnLocalBricks(volinfo, &oldLimit)
+	}
+	return removeLimitFromLocalBricks(volinfo, limit.Path)
+}
+
+func txnQuotaRemoveLimit(c transaction.TxnCtx) error {
+	volinfo, limit, err := getVolinfoAndLimit(c, "oldlimit")
+	if err != nil {
+		return err
+	}
+
+	if err := removeLimitFromLocalBricks(volinfo, limit.Path); err != nil {
+		c.Logger().WithError(err).WithFields(log.Fields{
+			"volume": volinfo.Name,
+			"path":   limit.Path,
+		}).Error("failed to remove quota limit from bricks")
+		return err
+	}
+	return nil
+}
+
+func txnQuotaUndoRemoveLimit(c transaction.TxnCtx) error {
+	volinfo, limit, err := getVolinfoAndLimit(c, "oldlimit")
+	if err != nil {
+		return err
+	}
+	return setLimitOnLocalBricks(volinfo, limit)
+}
+
+func txnQuotaStoreLimit(c transaction.TxnCtx) error {
+	volinfo, limit, err := getVolinfoAndLimit(c, "limit")
+	if err != nil {
+		return err
+	}
+	return addOrUpdateLimit(volinfo.ID.String(), limit)
+}
+
+func txnQuotaUndoStoreLimit(c transaction.TxnCtx) error {
+	volinfo, limit, err := getVolinfoAndLimit(c, "limit")
+	if err != nil {
+		return err
+	}
+
+	var oldLimit quotaapi.DirLimit
+	if err := c.Get("oldlimit", &oldLimit); err == nil {
+		return addOrUpdateLimit(volinfo.ID.String(), &oldLimit)
+	}
+	return deleteLimit(volinfo.ID.String(), limit.Path)
+}
+
+func txnQuotaDeleteLimit(c transaction.TxnCtx) error {
+	volinfo, limit, err := getVolinfoAndLimit(c, "oldlimit")
+	if err != nil {
+		return err
+	}
+	return deleteLimit(volinfo.ID.String(), limit.Path)
+}
+
+func txnQuotaUndoDeleteLimit(c transaction.TxnCtx) error {
+	volinfo, limit, err := getVolinfoAndLimit(c, "oldlimit")
+	if err != nil {
+		return err
+	}
+	return addOrUpdateLimit(volinfo.ID.String(), limit)
+}
+
+// txnQuotaRestartQuotad restarts quotad so that the limits are reloaded
+func txnQuotaRestartQuotad(c transaction.TxnCtx) error {
+	var volinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		c.Logger().WithError(err).WithField(
+			"key", "volinfo").Error("failed to get value for key from context")
+		return err
+	}
+
+	quotadDaemon, err := NewQuotad()
+	if err != nil {
+		return err
+	}
+	return restartQuotad(quotadDaemon, &volinfo, c.Logger())
+}
+
+// txnQuotaUsage collects the usage of all directories having a limit from the
+// local bricks. For each subvolume, the largest usage reported among its
+// local bricks is recorded.
+func txnQuotaUsage(c transaction.TxnCtx) error {
+	var volinfo volume.Volinfo
+	if err := c.Get("volinfo", &volinfo); err != nil {
+		c.Logger().WithError(err).WithField(
+			"key", "volinfo").Error("failed to get value for key from context")
+		return err
+	}
+
+	var limits []quotaapi.DirLimit
+	if err := c.Get("limits", &limits); err != nil {
+		c.Logger().WithError(err).WithField(
+			"key", "limits").Error("failed to get value for key from context")
+		return err
+	}
+
+	usage := make(subvolUsage)
+	for _, limit := range limits {
+		usage[limit.Path] = make(map[string]uint64)
+		for _, subvol := range volinfo.Subvols {
+			for _, b := range subvol.GetLocalBricks() {
+				used, err := dirUsageOnBrick(b, limit.Path)
+				if err != nil {
+					c.Logger().WithError(err).WithFields(log.Fields{
+						"brick": b.String(),
+						"path":  limit.Path,
+					}).Warn("failed to get directory usage from brick")
+					continue
+				}
+				// Bricks of a disperse subvolume store only a
+				// fragment of each file
+				if subvol.Type == volume.SubvolDisperse {
+					used *= uint64(subvol.DisperseCount - subvol.RedundancyCount)
+				}
+				if used > usage[limit.Path][subvol.Name] {
+					usage[limit.Path][subvol.Name] = used
+				}
+			}
+		}
+	}
+
+	// Store the results in transaction context. This will be consumed by
+	// the node that initiated the transaction.
+	return c.SetNodeResult(gdctx.MyUUID, quotaUsageTxnKey, usage)
+}
@@ -0,0 +1,129 @@
+package quota
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/gluster/glusterd2/glusterd2/brick"
+	"github.com/gluster/glusterd2/glusterd2/volume"
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// quotaLimitXattrKey holds the hard and soft limit of a directory. The
+	// marker and quota xlators on the brick enforce the limit set here.
+	quotaLimitXattrKey = "trusted.glusterfs.quota.limit-set"
+	// quotaSizeXattrKey holds the contribution of a directory accounted by
+	// the marker xlator on the brick.
+	quotaSizeXattrKey = "trusted.glusterfs.quota.size"
+	// quotaLimitXattrSize is the size of the limit-set xattr value which
+	// is made up of two 64 bit integers in network byte order.
+	quotaLimitXattrSize = 16
+	// defaultSoftLimit is used when neither the request nor the volume
+	// option specify the soft limit percentage.
+	defaultSoftLimit = -1
+)
+
+var (
+	setxattr    = unix.Setxattr
+	getxattr    = unix.Getxattr
+	removexattr = unix.Removexattr
+)
+
+// encodeLimit returns the on-disk representation of the limit as understood
+// by the quota xlator.
+func encodeLimit(limit *quotaapi.DirLimit) []byte {
+	var softLimit int64 = defaultSoftLimit
+	if limit.SoftLimit != 0 {
+		softLimit = int64(limit.SoftLimit)
+	}
+
+	data := make([]byte, quotaLimitXattrSize)
+	binary.BigEndian.PutUint64(data[:8], limit.HardLimit)
+	binary.BigEndian.PutUint64(data[8:], uint64(softLimit))
+	return data
+}
+
+// decodeSize returns the bytes accounted in the quota size xattr. Depending
+// on the glusterfs version, the xattr either holds just the size or the size
+// followed by file and directory counts.
+func decodeSize(data []byte) (uint64, error) {
+	if len(data) < 8 {
+		return 0, fmt.Errorf("invalid quota size xattr of length %d", len(data))
+	}
+	size := int64(binary.BigEndian.Uint64(data[:8]))
+	if size < 0 {
+		return 0, nil
+	}
+	return uint64(size), nil
+}
+
+// brickDirPath returns the absolute path of dirpath on the given brick,
+// failing if the directory is absent on it.
+func brickDirPath(b brick.Brickinfo, dirpath string) (string, error) {
+	p := path.Join(b.Path, dirpath)
+	fi, err := os.Stat(p)
+	if err != nil {
+		return "", err
+	}
+	if !fi.IsDir() {
+		return "", fmt.Errorf("%s is not a directory on brick %s", dirpath, b.String())
+	}
+	return p, nil
+}
+
+// setLimitOnLocalBricks sets the limit xattr on dirpath on all local bricks
+// of the volume
+func setLimitOnLocalBricks(v *volume.Volinfo, limit *quotaapi.DirLimit) error {
+	value := encodeLimit(limit)
+	for _, b := range v.GetLocalBricks() {
+		p, err := brickDirPath(b, limit.Path)
+		if err != nil {
+			return err
+		}
+		if err := setxattr(p, quotaLimitXattrKey, value, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeLimitFromLocalBricks removes the limit xattr from dirpath on all
+// local bricks of the volume
+func removeLimitFromLocalBricks(v *volume.Volinfo, dirpath string) error {
+	for _, b := range v.GetLocalBricks() {
+		p, err := brickDirPath(b, dirpath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if err := removexattr(p, quotaLimitXattrKey); err != nil && err != unix.ENODATA {
+			return err
+		}
+	}
+	return nil
+}
+
+// dirUsageOnBrick returns the bytes accounted for dirpath on a brick
+func dirUsageOnBrick(b brick.Brickinfo, dirpath string) (uint64, error) {
+	p, err := brickDirPath(b, dirpath)
+	if err != nil {
+		return 0, err
+	}
+
+	data := make([]byte, 24)
+	sz, err := getxattr(p, quotaSizeXattrKey, data)
+	if err == unix.ENODATA {
+		// Marker has not accounted the directory yet
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return decodeSize(data[:sz])
+}
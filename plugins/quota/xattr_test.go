@@ -0,0 +1,84 @@
+package quota
+
+import (
+	"testing"
+
+	quotaapi "github.com/gluster/glusterd2/plugins/quota/api"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEncodeLimit validates encodeLimit()
+func TestEncodeLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit quotaapi.DirLimit
+		want  []byte
+	}{
+		{
+			name:  "explicit soft limit",
+			limit: quotaapi.DirLimit{Path: "/dir", HardLimit: 1024, SoftLimit: 80},
+			want:  []byte{0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 0, 80},
+		},
+		{
+			name:  "default soft limit",
+			limit: quotaapi.DirLimit{Path: "/dir", HardLimit: 1 << 40},
+			want:  []byte{0, 0, 1, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+		{
+			name:  "full soft limit",
+			limit: quotaapi.DirLimit{Path: "/", HardLimit: 1, SoftLimit: 100},
+			want:  []byte{0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 100},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, encodeLimit(&tt.limit), tt.name)
+	}
+}
+
+// TestDecodeSize validates decodeSize()
+func TestDecodeSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    uint64
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			data:    []byte{},
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			data:    []byte{0, 0, 0, 0, 0, 4, 0},
+			wantErr: true,
+		},
+		{
+			name: "size only",
+			data: []byte{0, 0, 0, 0, 0, 0, 4, 0},
+			want: 1024,
+		},
+		{
+			name: "size with file and directory counts",
+			data: []byte{0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 1},
+			want: 65536,
+		},
+		{
+			name: "negative size",
+			data: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfc, 0x00},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		size, err := decodeSize(tt.data)
+		if tt.wantErr {
+			assert.NotNil(t, err, tt.name)
+			continue
+		}
+		assert.Nil(t, err, tt.name)
+		assert.Equal(t, tt.want, size, tt.name)
+	}
+}
@@ -112,3 +112,9 @@ func NewRebalanceProcess(rinfo rebalanceapi.RebalInfo) (*Process, error) {
 func (r *Process) ID() string {
 	return r.rInfo.Volname + "-rebalance"
 }
+
+// Transient returns true as the rebalance process exits once the rebalance
+// is complete, and must not be restarted
+func (r *Process) Transient() bool {
+	return true
+}
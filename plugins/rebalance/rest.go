@@ -1,6 +1,7 @@
 package rebalance
 
 import (
+	"context"
 	"io"
 	"net/http"
 
@@ -17,7 +18,9 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func createRebalanceInfo(volname string, req *rebalanceapi.StartReq) *rebalanceapi.RebalInfo {
+// CreateRebalanceInfo returns a new rebalance info for the volume as per the
+// start request
+func CreateRebalanceInfo(volname string, req *rebalanceapi.StartReq) *rebalanceapi.RebalInfo {
 	return &rebalanceapi.RebalInfo{
 		Volname:     volname,
 		RebalanceID: uuid.NewRandom(),
@@ -45,7 +48,7 @@ func rebalanceStartHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rebalinfo := createRebalanceInfo(volname, &req)
+	rebalinfo := CreateRebalanceInfo(volname, &req)
 	if rebalinfo == nil {
 		logger.WithError(err).Error("failed to create Rebalance info")
 		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err)
@@ -82,7 +85,14 @@ func rebalanceStartHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// TODO: Check for remove-brick
+	// Data is being migrated off the decommissioned bricks of a volume
+	// being shrunk, which has to be committed or stopped first
+	for _, b := range vol.GetBricks() {
+		if b.Decommissioned {
+			restutils.SendHTTPError(ctx, w, http.StatusConflict, errors.ErrVolShrinkInProgress)
+			return
+		}
+	}
 
 	// Start the rebalance process on all nodes
 	// Only this node will save the rebalinfo in the store
@@ -249,13 +259,31 @@ func rebalanceStatusHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = txn.Ctx.Set("volname", volname)
+	response, err := GetRebalanceStatus(ctx, vol, rebalinfo)
 	if err != nil {
-		logger.WithError(err).Error("failed to set volname in transaction context")
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err.Error())
+		errMsg := "Failed to create rebalance status response"
+		logger.WithError(err).Error("rebalanceStatusHandler:" + errMsg)
+		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError,
+			errMsg)
 		return
 	}
 
+	restutils.SendHTTPResponse(r.Context(), w, http.StatusOK, response)
+}
+
+// GetRebalanceStatus queries the rebalance processes of the volume and returns
+// their consolidated status. The caller is expected to hold the volume lock.
+func GetRebalanceStatus(ctx context.Context, vol *volume.Volinfo, rebalinfo *rebalanceapi.RebalInfo) (*rebalanceapi.RebalStatus, error) {
+	logger := gdctx.GetReqLogger(ctx)
+
+	txn := transaction.NewTxn(ctx)
+	defer txn.Done()
+
+	if err := txn.Ctx.Set("volname", vol.Name); err != nil {
+		logger.WithError(err).Error("failed to set volname in transaction context")
+		return nil, err
+	}
+
 	// The status will be a combination of those from the running rebalance processes
 	// and the status stored in rebalinfo (by the processes that have completed)
 
@@ -268,28 +296,16 @@ func rebalanceStatusHandler(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	err = txn.Ctx.Set("rinfo", rebalinfo)
-	if err != nil {
+	if err := txn.Ctx.Set("rinfo", rebalinfo); err != nil {
 		logger.WithError(err).Error("failed to set rebalance info in transaction context")
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError, err.Error())
-		return
-	}
-
-	err = txn.Do()
-	if err != nil {
-		logger.WithError(err).WithField("volname", volname).Error("failed to query rebalance status for volume")
+		return nil, err
 	}
 
-	response, err := createRebalanceStatusResp(txn.Ctx, vol)
-	if err != nil {
-		errMsg := "Failed to create rebalance status response"
-		logger.WithError(err).Error("rebalanceStatusHandler:" + errMsg)
-		restutils.SendHTTPError(ctx, w, http.StatusInternalServerError,
-			errMsg)
-		return
+	if err := txn.Do(); err != nil {
+		logger.WithError(err).WithField("volname", vol.Name).Error("failed to query rebalance status for volume")
 	}
 
-	restutils.SendHTTPResponse(r.Context(), w, http.StatusOK, response)
+	return createRebalanceStatusResp(txn.Ctx, vol)
 }
 
 func createRebalanceStatusResp(ctx transaction.TxnCtx, volinfo *volume.Volinfo) (*rebalanceapi.RebalStatus, error) {
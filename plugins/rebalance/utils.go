@@ -91,6 +91,16 @@ func StoreRebalanceInfo(rinfo *rebalanceapi.RebalInfo) error {
 	return nil
 }
 
+// DeleteRebalanceInfo deletes the stored rebalance details of a volume
+func DeleteRebalanceInfo(volname string) error {
+	_, err := store.Delete(context.TODO(), rebalancePrefix+volname)
+	if err != nil {
+		log.WithError(err).Error("Couldn't delete rebalance info from store")
+		return err
+	}
+	return nil
+}
+
 func getCmd(req *rebalanceapi.StartReq) rebalanceapi.Command {
 
 	switch req.Option {